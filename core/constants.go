@@ -6,17 +6,56 @@ var (
 	ErrCollectionExists   = "collection: %s is already exists"
 	ErrCollectionNotLoad  = "collection: %s is not loaded in memory"
 	ErrAlreadyRelease     = "collection: %s is already release"
+	ErrUnknownIndexType   = "index type: %s is not supported"
+	ErrAlterIndexType     = "collection: %s is a %s collection, only hnsw collections can be altered"
+	ErrRebuildRunning     = "collection: %s is already rebuilding its index"
+	ErrInvalidAlter       = "alter collection: %s must be positive"
+	ErrAlterFields        = "collection: %s has vector fields, its graphs can not be altered"
+)
+
+var (
+	ErrFieldsIndexType      = "vector fields are only supported by hnsw collections"
+	ErrNoVectorFields       = "collection: %s has no vector fields"
+	ErrVectorField          = "vector field: %q needs a name and a dimension"
+	ErrDuplicateVectorField = "vector field: %s is declared twice"
+	ErrUnknownVectorField   = "vector field: %s not found"
+	ErrMissingVectorField   = "vector field: %s is missing"
+	ErrVectorFieldDim       = "vector field: %s has dimension %d, got %d"
+	ErrFieldsRecordDim      = "vector fields: record has dimension %d, got %d"
+	ErrNoFieldQueries       = "vector fields: search needs a field query"
+	ErrFieldWeight          = "vector field: %s weight must not be negative"
+	ErrHybridWeight         = "hybrid search: dense and sparse weights must not be negative"
+	ErrTextField            = "text field: name must not be empty"
+	ErrDuplicateTextField   = "text field: %s is declared twice"
+	ErrDiversifyLambda      = "diversify: lambda must be between 0 and 1"
+	ErrGroupDiversify       = "group_by search: diversify is not supported"
+	ErrRecommendFields      = "collection: %s has vector fields, recommend is not supported"
+	ErrRecommendPositive    = "recommend: needs a positive example"
+	ErrRecommendRecord      = "recommend: record %s not found"
+	ErrRecommendDim         = "recommend: example vector has dimension %d, expected %d"
+	ErrRangeSearch          = "radius search: needs a vector, it does not combine with field, sparse, group_by or diversify searches"
+	ErrPageToken            = "page token: invalid or of another query"
+	ErrPageOffset           = "page token: offset must be 0 with a page token"
+	ErrPageSearch           = "paging: does not combine with group_by or diversify"
 )
 
 const (
 	COSINE            = "cosine-dot"
 	EUCLIDEAN         = "euclidean"
+	INNER_PRODUCT     = "inner-product"
+	MANHATTAN         = "manhattan"
+	HAMMING           = "hamming"
 	NONE_QAUNTIZATION = "none"
 	F16_QUANTIZATION  = "f16"
 	F8_QUANTIZATION   = "f8"
 	BF16_QUANTIZATION = "bf16"
 	PQ_QUANTIZATION   = "productQuantization"
 	BQ_QUANTIZATION   = "binaryQuantization"
+	HNSW_INDEX        = "hnsw"
+	VAMANA_INDEX      = "vamana"
+	IVF_INDEX         = "ivf"
+	HNSW_PQ_INDEX     = "hnsw-pq"
+	FLAT_INDEX        = "flat"
 )
 
 var (
@@ -36,3 +75,15 @@ var (
 var (
 	indexRule = "./data_dir/%s.bin"
 )
+
+var (
+	vamanaRule      = "./data_dir/%s.vamana.raw"
+	vamanaBlockRule = "./data_dir/%s.vamana.blocks"
+	ivfRule         = "./data_dir/%s.ivf.raw"
+	hnswPqRule      = "./data_dir/%s.hnswpq.raw"
+	flatRule        = "./data_dir/%s.flat.raw"
+	sparseRule      = "./data_dir/%s.sparse.raw"
+)
+
+// a geo filter drops most of the ann candidates, search wider before filtering
+const geoOversample = 4
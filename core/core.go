@@ -3,19 +3,28 @@ package core
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/nnv/core/sparse"
 	"github.com/sjy-dv/nnv/core/vectorindex"
 	"github.com/sjy-dv/nnv/diskv"
 	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
 	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type Core struct {
-	DataStore *autoMap[*vectorindex.Hnsw]
+	DataStore *autoMap[collectionIndex]
 	CommitLog *diskv.DB
+	// last rebuild of each collection started by AlterCollection
+	rebuilds  *autoMap[*rebuildState]
+	alterLock sync.Mutex
+	// max_query_time_ms of each loaded collection
+	queryTimeouts *autoMap[time.Duration]
+	// sparse vectors of each loaded collection
+	sparseStores *autoMap[*sparse.Index]
 }
 
 func NewCore() (*Core, error) {
@@ -31,8 +40,11 @@ func NewCore() (*Core, error) {
 		return nil, err
 	}
 	return &Core{
-		DataStore: NewAutoMap[*vectorindex.Hnsw](),
-		CommitLog: diskdb,
+		DataStore:     NewAutoMap[collectionIndex](),
+		CommitLog:     diskdb,
+		rebuilds:      NewAutoMap[*rebuildState](),
+		queryTimeouts: NewAutoMap[time.Duration](),
+		sparseStores:  NewAutoMap[*sparse.Index](),
 	}, nil
 }
 
@@ -78,7 +90,21 @@ func (xx *Core) CreateCollection(ctx context.Context,
 			return
 		}
 		distFn, distFnName := protoDistHelper(req.GetDistance())
-		searchAlgo, searchOpts := protoSearchAlgoHelper(req.GetCollectionConfig().GetSearchAlgorithm())
+		searchAlgo, _ := protoSearchAlgoHelper(req.GetCollectionConfig().GetSearchAlgorithm())
+		kind, ok := indexKinds[req.GetIndexType()]
+		if !ok {
+			c <- failFn(fmt.Sprintf(ErrUnknownIndexType, req.GetIndexType()))
+			return
+		}
+		if len(req.GetVectorFields()) > 0 && req.GetIndexType() != coreproto.IndexType_Hnsw {
+			c <- failFn(ErrFieldsIndexType)
+			return
+		}
+		textFields, err := diskTextFieldsHelper(req.GetTextFields())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 
 		// save config
 		diskCol := diskproto.Collection{
@@ -95,29 +121,39 @@ func (xx *Core) CreateCollection(ctx context.Context,
 			VectorDimension:           req.GetVectorDimension(),
 			Distance:                  distFnName,
 			Quantization:              "None", // after update
+			IndexType:                 kind.name,
+			MaxQueryTimeMs:            req.GetMaxQueryTimeMs(),
+			TextFields:                textFields,
+		}
+		index, err := kind.create(req.GetCollectionName(), req, distFn, &diskCol)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
 		}
 
 		diskBytes, err := proto.Marshal(&diskCol)
 		if err != nil {
+			closeIndexHelper(index)
 			c <- failFn(err.Error())
 			return
 		}
 		diskFlushName := fmt.Sprintf(diskRule0, req.GetCollectionName())
 		err = xx.CommitLog.Put([]byte(diskFlushName), diskBytes)
 		if err != nil {
+			closeIndexHelper(index)
 			c <- failFn(err.Error())
 			return
 		}
-		hnsw := vectorindex.NewHnsw(uint(req.GetVectorDimension()),
-			distFn,
-			searchOpts)
-		xx.DataStore.Set(req.GetCollectionName(), hnsw)
+		xx.setIndex(req.GetCollectionName(), index)
+		xx.setQueryTimeout(req.GetCollectionName(), req.GetMaxQueryTimeMs())
+		xx.sparseStores.Set(req.GetCollectionName(), sparse.NewIndex())
 		err = indexdb.CreateIndex(req.GetCollectionName())
 		if err != nil {
 			xx.diskClear(req.GetCollectionName())
 			c <- failFn(err.Error())
 			return
 		}
+		textFieldsHelper(req.GetCollectionName(), textFields)
 		err = xx.saveCollection(req.GetCollectionName())
 		if err != nil {
 			xx.diskClear(req.GetCollectionName())
@@ -205,20 +241,16 @@ func (xx *Core) CollectionInfof(ctx context.Context, req *coreproto.CollectionNa
 			c <- failFn(fmt.Sprintf(ErrCollectionNotLoad, req.GetCollectionName()))
 			return
 		}
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
+		index := xx.DataStore.Get(req.GetCollectionName())
+		info := xx.collectionInfoHelper(req.GetCollectionName(), index)
+		if rebuild := xx.rebuilds.Get(req.GetCollectionName()); rebuild != nil {
+			info.Rebuild = rebuild.progress()
+		}
 
 		c <- reply{
 			Result: &coreproto.CollectionMsg{
 				Status: true,
-				Info: &coreproto.CollectionInfo{
-					CollectionName:    req.GetCollectionName(),
-					CollectionConfig:  reverseConfigHelper(hnsw.Config()),
-					VectorDimension:   hnsw.Dim(),
-					CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
-					CollectionLength:  uint64(hnsw.Len()),
-					Distance:          reverseprotoDistHelper(hnsw.Distance()),
-					CompressionHelper: coreproto.Quantization_None,
-				},
+				Info:   info,
 			},
 		}
 	}()
@@ -255,19 +287,11 @@ func (xx *Core) LoadCollection(ctx context.Context, req *coreproto.CollectionNam
 			return
 		}
 		if alreadyLoadCollection(req.GetCollectionName()) {
-			hnsw := xx.DataStore.Get(req.GetCollectionName())
+			index := xx.DataStore.Get(req.GetCollectionName())
 			c <- reply{
 				Result: &coreproto.CollectionMsg{
 					Status: true,
-					Info: &coreproto.CollectionInfo{
-						CollectionName:    req.GetCollectionName(),
-						CollectionConfig:  reverseConfigHelper(hnsw.Config()),
-						VectorDimension:   hnsw.Dim(),
-						CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
-						CollectionLength:  uint64(hnsw.Len()),
-						Distance:          reverseprotoDistHelper(hnsw.Distance()),
-						CompressionHelper: coreproto.Quantization_None,
-					},
+					Info:   xx.collectionInfoHelper(req.GetCollectionName(), index),
 				},
 			}
 			return
@@ -284,8 +308,7 @@ func (xx *Core) LoadCollection(ctx context.Context, req *coreproto.CollectionNam
 			c <- failFn(err.Error())
 			return
 		}
-		err = xx.snapShotHelper(req.GetCollectionName(), dp.GetVectorDimension(),
-			reversesingleprotoDistHelper(dp.GetDistance()), reverseSearchAlgoHelper(dp.GetSearchAlgorithm()))
+		err = xx.loadIndexHelper(req.GetCollectionName(), &dp)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
@@ -296,20 +319,13 @@ func (xx *Core) LoadCollection(ctx context.Context, req *coreproto.CollectionNam
 			c <- failFn(err.Error())
 			return
 		}
+		textFieldsHelper(req.GetCollectionName(), dp.GetTextFields())
 		stateTrueHelper(req.GetCollectionName())
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
+		index := xx.DataStore.Get(req.GetCollectionName())
 		c <- reply{
 			Result: &coreproto.CollectionMsg{
 				Status: true,
-				Info: &coreproto.CollectionInfo{
-					CollectionName:    req.GetCollectionName(),
-					CollectionConfig:  reverseConfigHelper(hnsw.Config()),
-					VectorDimension:   hnsw.Dim(),
-					CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
-					CollectionLength:  uint64(hnsw.Len()),
-					Distance:          reverseprotoDistHelper(hnsw.Distance()),
-					CompressionHelper: coreproto.Quantization_None,
-				},
+				Info:   xx.collectionInfoHelper(req.GetCollectionName(), index),
 			},
 		}
 	}()
@@ -378,6 +394,86 @@ func (xx *Core) ReleaseCollection(ctx context.Context, req *coreproto.Collection
 	return res.Result, res.Error
 }
 
+func (xx *Core) AlterCollection(ctx context.Context, req *coreproto.AlterCollectionRequest) (
+	*coreproto.AlterCollectionResponse, error) {
+	type reply struct {
+		Result *coreproto.AlterCollectionResponse
+		Error  error
+	}
+	c := make(chan reply, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c <- reply{
+					Error: fmt.Errorf(panicr, r),
+				}
+			}
+		}()
+		failFn := func(errMsg string) reply {
+			return reply{
+				Result: &coreproto.AlterCollectionResponse{
+					Status: false,
+					Error:  errorWrap(errMsg),
+				},
+			}
+		}
+		err := collectionStatusHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		xx.alterLock.Lock()
+		defer xx.alterLock.Unlock()
+
+		index := xx.DataStore.Get(req.GetCollectionName())
+		graph, ok := index.(hnswIndex)
+		if !ok {
+			if _, ok := index.(rebuildIndex); ok {
+				c <- failFn(fmt.Sprintf(ErrRebuildRunning, req.GetCollectionName()))
+				return
+			}
+			if _, ok := index.(fieldsIndex); ok {
+				c <- failFn(fmt.Sprintf(ErrAlterFields, req.GetCollectionName()))
+				return
+			}
+			c <- failFn(fmt.Sprintf(ErrAlterIndexType, req.GetCollectionName(), index.Stats().GetIndexType()))
+			return
+		}
+		config, rebuild, err := alterHnswHelper(graph.Config(), req)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		if !rebuild {
+			graph.SetEf(config.Ef)
+			if err := xx.archiveHnswHelper(req.GetCollectionName(), graph.Config()); err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			c <- reply{
+				Result: &coreproto.AlterCollectionResponse{
+					Status:           true,
+					CollectionConfig: reverseConfigHelper(graph.Config()),
+				},
+			}
+			return
+		}
+		// the new ef serves the old graph until the swap as well
+		graph.SetEf(config.Ef)
+		state := xx.startRebuildHelper(req.GetCollectionName(), graph, config)
+		c <- reply{
+			Result: &coreproto.AlterCollectionResponse{
+				Status:           true,
+				CollectionConfig: reverseConfigHelper(config),
+				Rebuild:          state.progress(),
+			},
+		}
+	}()
+	res := <-c
+	return res.Result, res.Error
+}
+
 func (xx *Core) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 	*coreproto.Response, error) {
 	type reply struct {
@@ -407,23 +503,44 @@ func (xx *Core) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error())
 			return
 		}
+		index := xx.DataStore.Get(req.GetCollectionName())
+		vector, err := datasetVectorHelper(index, req)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		sparseVector, err := protoSparseHelper(req.GetSparseVector())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 		cloneMap := req.GetMetadata().AsMap()
 		err = indexdb.indexes[req.GetCollectionName()].Add(autoId, cloneMap)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
-		err = hnsw.Insert(autoId, req.GetVector(), cloneMap, hnsw.RandomLevel())
+		err = index.Insert(autoId, vector, cloneMap)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
+		if sparseVector.Len() > 0 {
+			err = xx.sparseStores.Get(req.GetCollectionName()).Insert(autoId, sparseVector)
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+		}
+		if scan, ok := index.(flatIndex); ok && scan.PromoteDue() {
+			go xx.promoteFlatHelper(req.GetCollectionName(), scan)
+		}
 		diskkv := diskproto.Dataset{}
 		diskkv.CollectionUniqueId = autoId
 		diskkv.Metadata = req.GetMetadata()
 		diskkv.UserSpecificId = req.GetId()
-		diskkv.Vector = req.GetVector()
+		diskkv.Vector = vector
+		diskkv.SparseVector = diskSparseHelper(req.GetSparseVector())
 		diskb, err := proto.Marshal(&diskkv)
 		if err != nil {
 			c <- failFn(err.Error())
@@ -481,18 +598,28 @@ func (xx *Core) Update(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn("", true)
 			return
 		}
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
-		vertex, err := hnsw.GetVertex(getId[0])
+		index := xx.DataStore.Get(req.GetCollectionName())
+		vector, err := datasetVectorHelper(index, req)
+		if err != nil {
+			c <- failFn(err.Error(), false)
+			return
+		}
+		sparseVector, err := protoSparseHelper(req.GetSparseVector())
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], vertex.Metadata())
+		metadata, err := index.Metadata(getId[0])
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = hnsw.Remove(getId[0])
+		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], metadata)
+		if err != nil {
+			c <- failFn(err.Error(), false)
+			return
+		}
+		err = index.Remove(getId[0])
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
@@ -502,16 +629,26 @@ func (xx *Core) Update(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = hnsw.Insert(getId[0], req.GetVector(), req.GetMetadata().AsMap(), hnsw.RandomLevel())
+		err = index.Insert(getId[0], vector, req.GetMetadata().AsMap())
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
+		store := xx.sparseStores.Get(req.GetCollectionName())
+		store.Remove(getId[0])
+		if sparseVector.Len() > 0 {
+			err = store.Insert(getId[0], sparseVector)
+			if err != nil {
+				c <- failFn(err.Error(), false)
+				return
+			}
+		}
 		diskkv := diskproto.Dataset{}
 		diskkv.CollectionUniqueId = getId[0]
 		diskkv.Metadata = req.GetMetadata()
 		diskkv.UserSpecificId = req.GetId()
-		diskkv.Vector = req.GetVector()
+		diskkv.Vector = vector
+		diskkv.SparseVector = diskSparseHelper(req.GetSparseVector())
 		diskb, err := proto.Marshal(&diskkv)
 		if err != nil {
 			c <- failFn(err.Error(), false)
@@ -576,22 +713,23 @@ func (xx *Core) Delete(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- successFn()
 			return
 		}
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
-		vertex, err := hnsw.GetVertex(getId[0])
+		index := xx.DataStore.Get(req.GetCollectionName())
+		metadata, err := index.Metadata(getId[0])
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], vertex.Metadata())
+		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], metadata)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		err = hnsw.Remove(getId[0])
+		err = index.Remove(getId[0])
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
+		xx.sparseStores.Get(req.GetCollectionName()).Remove(getId[0])
 		err = xx.CommitLog.Delete([]byte(fmt.Sprintf(diskRule1, req.GetCollectionName(), getId[0])))
 		if err != nil {
 			c <- failFn(err.Error())
@@ -634,28 +772,111 @@ func (xx *Core) VectorSearch(ctx context.Context, req *coreproto.SearchRequest)
 			return
 		}
 
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
-		candidates, err := hnsw.Search(context.TODO(), req.GetVector(), uint(req.GetTopK()))
+		ctx, cancel := xx.queryContextHelper(ctx, req.GetCollectionName())
+		defer cancel()
+		index := xx.DataStore.Get(req.GetCollectionName())
+		proj := projectionHelper(req)
+		nodeIds := make(map[string]uint64)
+		if req.GetGroupBy() != "" {
+			groups, scores, err := xx.groupSearchHelper(ctx, index, req)
+			if err != nil {
+				if cerr := contextErrorHelper(err); cerr != nil {
+					c <- reply{Error: cerr}
+					return
+				}
+				c <- failFn(err.Error())
+				return
+			}
+			resultGroups := make([]*coreproto.Group, 0, len(groups))
+			for _, group := range groups {
+				g := &coreproto.Group{Value: group.value, Candidates: make([]*coreproto.Candidates, 0, len(group.candidates))}
+				for _, candidate := range group.candidates {
+					n, err := searchCandidateHelper(index, candidate, scores, proj)
+					if err != nil {
+						c <- failFn(err.Error())
+						return
+					}
+					nodeIds[n.Id] = candidate.Id
+					g.Candidates = append(g.Candidates, n)
+				}
+				if proj.vectors() {
+					if err := xx.vectorsHelper(req.GetCollectionName(), g.Candidates, nodeIds); err != nil {
+						c <- failFn(err.Error())
+						return
+					}
+				}
+				resultGroups = append(resultGroups, g)
+			}
+			c <- reply{
+				Result: &coreproto.SearchResponse{
+					Status: true,
+					Groups: resultGroups,
+				},
+			}
+			return
+		}
+		page, err := pageQueryHelper(req)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
+		var (
+			candidates vectorindex.SearchResult
+			scores     *searchScores
+		)
+		if req.Radius != nil {
+			candidates, err = rangeHelper(ctx, index, req, page.fetch(req.GetTopK()))
+		} else if req.GetDiversify() != nil {
+			fetchK := diversifyFetchHelper(req.GetDiversify(), req.GetTopK())
+			candidates, scores, err = xx.candidatesHelper(ctx, index, req, uint(fetchK))
+		} else {
+			candidates, scores, err = xx.candidatesHelper(ctx, index, req, uint(page.fetch(req.GetTopK())))
+		}
+		if err != nil {
+			if cerr := contextErrorHelper(err); cerr != nil {
+				c <- reply{Error: cerr}
+				return
+			}
+			c <- failFn(err.Error())
+			return
+		}
+		if req.GetDiversify() != nil {
+			candidates, err = diversifyHelper(index, candidates, scores, req.GetDiversify(), uint(req.GetTopK()))
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+		}
 		resultSet := make([]*coreproto.Candidates, 0, req.GetTopK())
 		for _, candidate := range candidates {
-			n := new(coreproto.Candidates)
-			n.Id = candidate.Metadata["_id"].(string)
-			n.Metadata, err = structpb.NewStruct(candidate.Metadata)
+			n, err := searchCandidateHelper(index, candidate, scores, proj)
 			if err != nil {
 				c <- failFn(err.Error())
 				return
 			}
-			n.Score = scoreHelper(candidate.Score, hnsw.Distance())
+			if n.GetScore() < req.GetMinScoreThreshold() {
+				continue
+			}
+			nodeIds[n.Id] = candidate.Id
 			resultSet = append(resultSet, n)
 		}
+		// diversified results keep their pick order
+		var nextPageToken string
+		if req.GetDiversify() == nil {
+			resultSet, nextPageToken = page.page(resultSet, req.GetTopK())
+		}
+		// only the vectors of the page are read
+		if proj.vectors() {
+			if err := xx.vectorsHelper(req.GetCollectionName(), resultSet, nodeIds); err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+		}
 		c <- reply{
 			Result: &coreproto.SearchResponse{
-				Status:     true,
-				Candidates: resultSet,
+				Status:        true,
+				Candidates:    resultSet,
+				NextPageToken: nextPageToken,
 			},
 		}
 	}()
@@ -694,10 +915,22 @@ func (xx *Core) FilterSearch(ctx context.Context, req *coreproto.SearchRequest)
 			return
 		}
 
-		candidates := indexdb.indexes[req.GetCollectionName()].PureSearch(req.GetFilter())
-		resultSet := make([]*coreproto.Candidates, 0, req.GetTopK())
+		geo, err := geoQueryHelper(req.GetGeoFilter())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		page, err := pageQueryHelper(req)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		candidates := indexdb.indexes[req.GetCollectionName()].PureGeoSearch(req.GetFilter(), geo)
+		start, end := page.span(len(candidates), req.GetTopK())
+		proj := projectionHelper(req)
+		resultSet := make([]*coreproto.Candidates, 0, end-start)
 
-		for _, id := range candidates {
+		for _, id := range candidates[start:end] {
 			data, err := xx.CommitLog.Get([]byte(fmt.Sprintf(diskRule1, req.GetCollectionName(), id)))
 			if err != nil {
 				c <- failFn(err.Error())
@@ -711,14 +944,25 @@ func (xx *Core) FilterSearch(ctx context.Context, req *coreproto.SearchRequest)
 			}
 			n := new(coreproto.Candidates)
 			n.Id = dec.GetUserSpecificId()
-			n.Metadata = dec.GetMetadata()
+			n.Metadata = proj.fields(dec.GetMetadata())
 			n.Score = 100
+			if proj.vectors() {
+				n.Vector = dec.GetVector()
+			}
+			if geo != nil {
+				n.GeoDistance, _ = indexdb.indexes[req.GetCollectionName()].GeoDistance(geo.Field, id, geo.Origin())
+			}
 			resultSet = append(resultSet, n)
 		}
+		var nextPageToken string
+		if end < len(candidates) && len(resultSet) > 0 {
+			nextPageToken = page.next(len(resultSet), resultSet[len(resultSet)-1])
+		}
 		c <- reply{
 			Result: &coreproto.SearchResponse{
-				Status:     true,
-				Candidates: resultSet,
+				Status:        true,
+				Candidates:    resultSet,
+				NextPageToken: nextPageToken,
 			},
 		}
 	}()
@@ -756,9 +1000,32 @@ func (xx *Core) HybridSearch(ctx context.Context, req *coreproto.SearchRequest)
 			return
 		}
 
-		hnsw := xx.DataStore.Get(req.GetCollectionName())
-		candidates, err := hnsw.Search(context.TODO(), req.GetVector(), uint(req.GetTopK()*3))
+		geo, err := geoQueryHelper(req.GetGeoFilter())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		searchK := max(req.GetTopK()*3, diversifyFetchHelper(req.GetDiversify(), req.GetTopK()))
+		if geo != nil {
+			searchK *= geoOversample
+		}
+		ctx, cancel := xx.queryContextHelper(ctx, req.GetCollectionName())
+		defer cancel()
+		index := xx.DataStore.Get(req.GetCollectionName())
+		var (
+			candidates vectorindex.SearchResult
+			scores     *searchScores
+		)
+		if req.GetTextQuery().GetQuery() != "" {
+			candidates, scores, err = xx.textCandidatesHelper(ctx, index, req, geo, uint(searchK))
+		} else {
+			candidates, scores, err = xx.hybridCandidatesHelper(ctx, index, req, geo, uint(searchK))
+		}
 		if err != nil {
+			if cerr := contextErrorHelper(err); cerr != nil {
+				c <- reply{Error: cerr}
+				return
+			}
 			c <- failFn(err.Error())
 			return
 		}
@@ -766,32 +1033,69 @@ func (xx *Core) HybridSearch(ctx context.Context, req *coreproto.SearchRequest)
 		for _, cc := range candidates {
 			vid = append(vid, cc.Id)
 		}
-		//
-		mergeCandidates := indexdb.indexes[req.GetCollectionName()].SearchWitCandidates(vid, req.GetFilter())
+		// text candidates are filtered and ranked already
+		mergeCandidates := vid
+		if req.GetTextQuery().GetQuery() == "" {
+			mergeCandidates = indexdb.indexes[req.GetCollectionName()].SearchWitCandidatesGeo(vid, req.GetFilter(), geo)
+			// the filter gives the ids in id order, the ann order is kept
+			// unless the geo filter sorts by distance
+			if geo == nil || !geo.SortByDistance {
+				mergeCandidates = annOrderHelper(vid, mergeCandidates)
+			}
+		}
 
 		// find for in for => O(n^2)
 		// in map => space complexity is grow but fast O(n)
 		resultSet := make([]*coreproto.Candidates, 0, req.GetTopK())
+		proj := projectionHelper(req)
+		nodeIds := make(map[string]uint64)
 		pos := 1
-		chkmap := make(map[uint64]bool)
-		for _, mc := range mergeCandidates {
-			chkmap[mc] = true
+		chkmap := make(map[uint64]vectorindex.SearchResultItem)
+		for _, cc := range candidates {
+			chkmap[cc.Id] = cc
 		}
-		for _, candidate := range candidates {
+		if req.GetDiversify() != nil {
+			merged := make(vectorindex.SearchResult, 0, len(mergeCandidates))
+			for _, mc := range mergeCandidates {
+				merged = append(merged, chkmap[mc])
+			}
+			merged, err = diversifyHelper(index, merged, scores, req.GetDiversify(), uint(req.GetTopK()))
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			mergeCandidates = mergeCandidates[:0]
+			for _, mc := range merged {
+				mergeCandidates = append(mergeCandidates, mc.Id)
+			}
+		}
+		// merged candidates keep the ann order, or the geo distance order when asked
+		for _, mc := range mergeCandidates {
 			if pos >= int(req.GetTopK()) {
 				break
 			}
-			n := new(coreproto.Candidates)
-			n.Id = candidate.Metadata["_id"].(string)
-			n.Metadata, err = structpb.NewStruct(candidate.Metadata)
+			candidate := chkmap[mc]
+			n, err := searchCandidateHelper(index, candidate, scores, proj)
 			if err != nil {
 				c <- failFn(err.Error())
 				return
 			}
-			n.Score = scoreHelper(candidate.Score, hnsw.Distance())
+			if n.GetScore() < req.GetMinScoreThreshold() {
+				continue
+			}
+			if geo != nil {
+				n.GeoDistance, _ = indexdb.indexes[req.GetCollectionName()].GeoDistance(geo.Field, mc, geo.Origin())
+			}
+			nodeIds[n.Id] = mc
 			resultSet = append(resultSet, n)
 			pos++
 		}
+		if proj.vectors() {
+			if err := xx.vectorsHelper(req.GetCollectionName(), resultSet, nodeIds); err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+		}
 		c <- reply{
 			Result: &coreproto.SearchResponse{
 				Status:     true,
@@ -823,7 +1127,7 @@ func (xx *Core) CompareDist(ctx context.Context, req *coreproto.CompXyDist) (
 		score := provider.Distance(req.GetVectorX(), req.GetVectorY())
 		c <- reply{
 			Result: &coreproto.XyDist{
-				Score: scoreHelper(score, distname),
+				Score: scoreHelper(score, distname, uint32(len(req.GetVectorX()))),
 			},
 		}
 	}()
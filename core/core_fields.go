@@ -0,0 +1,388 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+// rrf rank constant of a search that leaves rrf_k unset
+const defaultRrfK = 60
+
+type vectorField struct {
+	name   string
+	offset int
+	dim    int
+	dist   string
+	space  distance.Space
+	graph  *vectorindex.Hnsw
+}
+
+/* fieldsIndex is an hnsw collection with named vector fields, every field is
+ * its own graph over the same record ids. A record travels as its field
+ * vectors laid out in the declared order, so the commit log, the rollback and
+ * the handlers still see one vector per record. The metadata is kept once, in
+ * the graph of the first field, and a search with a plain vector goes to the
+ * first field too. */
+type fieldsIndex struct {
+	fields []*vectorField
+	dim    uint32
+}
+
+func newFieldsHelper(specs []*diskproto.VectorField, options []vectorindex.HnswOption) (fieldsIndex, error) {
+	index := fieldsIndex{fields: make([]*vectorField, 0, len(specs))}
+	for _, spec := range specs {
+		if spec.GetName() == "" || spec.GetDim() == 0 {
+			return fieldsIndex{}, fmt.Errorf(ErrVectorField, spec.GetName())
+		}
+		if index.field(spec.GetName()) != nil {
+			return fieldsIndex{}, fmt.Errorf(ErrDuplicateVectorField, spec.GetName())
+		}
+		space := reversesingleprotoDistHelper(spec.GetDistance())
+		index.fields = append(index.fields, &vectorField{
+			name:   spec.GetName(),
+			offset: int(index.dim),
+			dim:    int(spec.GetDim()),
+			dist:   spec.GetDistance(),
+			space:  space,
+			graph:  vectorindex.NewHnsw(uint(spec.GetDim()), space, options...),
+		})
+		index.dim += spec.GetDim()
+	}
+	return index, nil
+}
+
+// diskFieldsHelper turns the requested fields into their archived form.
+func diskFieldsHelper(fields []*coreproto.VectorField) []*diskproto.VectorField {
+	specs := make([]*diskproto.VectorField, 0, len(fields))
+	for _, field := range fields {
+		_, dist := protoDistHelper(field.GetDistance())
+		specs = append(specs, &diskproto.VectorField{
+			Name:     field.GetName(),
+			Dim:      field.GetDim(),
+			Distance: dist,
+		})
+	}
+	return specs
+}
+
+func (xx fieldsIndex) field(name string) *vectorField {
+	for _, field := range xx.fields {
+		if field.name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// compose lays the vector of every field out in the declared order.
+func (xx fieldsIndex) compose(vectors map[string]gomath.Vector) (gomath.Vector, error) {
+	for name := range vectors {
+		if xx.field(name) == nil {
+			return nil, fmt.Errorf(ErrUnknownVectorField, name)
+		}
+	}
+	out := make(gomath.Vector, 0, xx.dim)
+	for _, field := range xx.fields {
+		vector, ok := vectors[field.name]
+		if !ok {
+			return nil, fmt.Errorf(ErrMissingVectorField, field.name)
+		}
+		if len(vector) != field.dim {
+			return nil, fmt.Errorf(ErrVectorFieldDim, field.name, field.dim, len(vector))
+		}
+		out = append(out, vector...)
+	}
+	return out, nil
+}
+
+// Insert adds the record to every field graph, a failed field takes it out
+// of the graphs it already went in.
+func (xx fieldsIndex) Insert(id uint64, vector gomath.Vector, metadata vectorindex.Metadata) error {
+	if len(vector) != int(xx.dim) {
+		return fmt.Errorf(ErrFieldsRecordDim, xx.dim, len(vector))
+	}
+	for i, field := range xx.fields {
+		if i > 0 {
+			metadata = nil
+		}
+		value := vector[field.offset : field.offset+field.dim]
+		if err := field.graph.Insert(id, value, metadata, field.graph.RandomLevel()); err != nil {
+			for _, inserted := range xx.fields[:i] {
+				inserted.graph.Remove(id)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx fieldsIndex) Remove(id uint64) error {
+	var first error
+	for _, field := range xx.fields {
+		if err := field.graph.Remove(id); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (xx fieldsIndex) Get(id uint64) (gomath.Vector, error) {
+	out := make(gomath.Vector, 0, xx.dim)
+	for _, field := range xx.fields {
+		vector, err := field.graph.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vector...)
+	}
+	return out, nil
+}
+
+func (xx fieldsIndex) Metadata(id uint64) (vectorindex.Metadata, error) {
+	vertex, err := xx.fields[0].graph.GetVertex(id)
+	if err != nil {
+		return nil, err
+	}
+	return vertex.Metadata(), nil
+}
+
+// Search has no field to put a plain vector on, fields are searched with SearchFields.
+func (xx fieldsIndex) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	return nil, errors.New(ErrNoFieldQueries)
+}
+
+func (xx fieldsIndex) Len() int {
+	return xx.fields[0].graph.Len()
+}
+
+func (xx fieldsIndex) Dim() uint32 {
+	return xx.dim
+}
+
+func (xx fieldsIndex) Distance() string {
+	return xx.fields[0].graph.Distance()
+}
+
+func (xx fieldsIndex) BytesSize() uint64 {
+	var size uint64
+	for _, field := range xx.fields {
+		size += field.graph.BytesSize()
+	}
+	return size
+}
+
+// Commit writes the graph of each field in order, every one prefixed by its length.
+func (xx fieldsIndex) Commit(w io.Writer) error {
+	for _, field := range xx.fields {
+		var buf bytes.Buffer
+		if err := field.graph.Commit(&buf, true); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, uint64(buf.Len())); err != nil {
+			return err
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx fieldsIndex) Load(r io.Reader) error {
+	for _, field := range xx.fields {
+		var size uint64
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return err
+		}
+		if err := field.graph.Load(bytes.NewReader(data), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx fieldsIndex) Stats() *coreproto.CollectionInfo {
+	info := indexStatsHelper(coreproto.IndexType_Hnsw, xx.Len(), xx.BytesSize())
+	info.CollectionConfig = reverseConfigHelper(xx.fields[0].graph.Config())
+	info.VectorFields = make([]*coreproto.VectorField, 0, len(xx.fields))
+	for _, field := range xx.fields {
+		info.VectorFields = append(info.VectorFields, &coreproto.VectorField{
+			Name:     field.name,
+			Dim:      uint32(field.dim),
+			Distance: reverseprotoDistHelper(field.dist),
+		})
+	}
+	return info
+}
+
+type fieldQuery struct {
+	field  *vectorField
+	query  gomath.Vector
+	weight float32
+	ranks  map[uint64]int
+}
+
+func (xx fieldsIndex) planQueries(queries []*coreproto.FieldQuery) ([]*fieldQuery, error) {
+	if len(queries) == 0 {
+		return nil, errors.New(ErrNoFieldQueries)
+	}
+	plan := make([]*fieldQuery, 0, len(queries))
+	var weights float32
+	for _, query := range queries {
+		field := xx.field(query.GetField())
+		if field == nil {
+			return nil, fmt.Errorf(ErrUnknownVectorField, query.GetField())
+		}
+		if len(query.GetVector()) != field.dim {
+			return nil, fmt.Errorf(ErrVectorFieldDim, field.name, field.dim, len(query.GetVector()))
+		}
+		if query.GetWeight() < 0 {
+			return nil, fmt.Errorf(ErrFieldWeight, field.name)
+		}
+		vector := gomath.Vector(query.GetVector())
+		if field.dist == COSINE {
+			vector = vectorindex.Normalize(vector)
+		}
+		plan = append(plan, &fieldQuery{field: field, query: vector, weight: query.GetWeight()})
+		weights += query.GetWeight()
+	}
+	for _, query := range plan {
+		if weights == 0 {
+			query.weight = 1 / float32(len(plan))
+		} else {
+			query.weight /= weights
+		}
+	}
+	return plan, nil
+}
+
+/* SearchFields searches the graph of every queried field for k records and
+ * fuses the rankings. Each record found by any field is scored exactly on all
+ * the queried fields, fieldScores holds these 0-100 scores. The scores of the
+ * result are final, the weighted mean of the field scores or the rrf sum. */
+func (xx fieldsIndex) SearchFields(ctx context.Context, queries []*coreproto.FieldQuery, fusion coreproto.Fusion, rrfK uint32, k uint,
+) (vectorindex.SearchResult, map[uint64]map[string]float32, error) {
+	plan, err := xx.planQueries(queries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rrfK == 0 {
+		rrfK = defaultRrfK
+	}
+	found := make([]uint64, 0, int(k)*len(plan))
+	for _, query := range plan {
+		result, err := query.field.graph.Search(ctx, query.query, k)
+		if err != nil {
+			return nil, nil, err
+		}
+		query.ranks = make(map[uint64]int, len(result))
+		for rank, item := range result {
+			query.ranks[item.Id] = rank + 1
+			found = append(found, item.Id)
+		}
+	}
+
+	fieldScores := make(map[uint64]map[string]float32, len(found))
+	fused := make(vectorindex.SearchResult, 0, len(found))
+	for _, id := range found {
+		if _, ok := fieldScores[id]; ok {
+			continue
+		}
+		scores, err := xx.fieldScores(plan, id)
+		if err != nil {
+			// removed while searching
+			continue
+		}
+		metadata, err := xx.Metadata(id)
+		if err != nil {
+			continue
+		}
+		var score float32
+		for _, query := range plan {
+			switch fusion {
+			case coreproto.Fusion_Rrf:
+				if rank, ok := query.ranks[id]; ok {
+					score += query.weight / float32(int(rrfK)+rank)
+				}
+			default:
+				score += query.weight * scores[query.field.name]
+			}
+		}
+		fieldScores[id] = scores
+		fused = append(fused, vectorindex.SearchResultItem{Id: id, Metadata: metadata, Score: score})
+	}
+	return rankFusedHelper(fused, k), fieldScores, nil
+}
+
+// rankFusedHelper orders fused results best first, ties by id, and keeps k.
+func rankFusedHelper(fused vectorindex.SearchResult, k uint) vectorindex.SearchResult {
+	sort.Slice(fused, func(i, j int) bool {
+		if fused[i].Score != fused[j].Score {
+			return fused[i].Score > fused[j].Score
+		}
+		return fused[i].Id < fused[j].Id
+	})
+	if len(fused) > int(k) {
+		fused = fused[:k]
+	}
+	return fused
+}
+
+func (xx fieldsIndex) fieldScores(plan []*fieldQuery, id uint64) (map[string]float32, error) {
+	scores := make(map[string]float32, len(plan))
+	for _, query := range plan {
+		vector, err := query.field.graph.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		field := query.field
+		scores[field.name] = scoreHelper(field.space.Distance(query.query, vector), field.graph.Distance(), uint32(field.dim))
+	}
+	return scores, nil
+}
+
+// datasetVectorHelper is the vector kept for a change, the field vectors sent
+// to a collection with vector fields are laid out as one.
+func datasetVectorHelper(index collectionIndex, req *coreproto.DatasetChange) (gomath.Vector, error) {
+	if len(req.GetVectors()) == 0 {
+		return req.GetVector(), nil
+	}
+	fields, ok := index.(fieldsIndex)
+	if !ok {
+		return nil, fmt.Errorf(ErrNoVectorFields, req.GetCollectionName())
+	}
+	vectors := make(map[string]gomath.Vector, len(req.GetVectors()))
+	for name, field := range req.GetVectors() {
+		vectors[name] = field.GetVector()
+	}
+	return fields.compose(vectors)
+}
+
+// fieldCandidatesHelper runs the field queries of a search on a collection with vector fields.
+func fieldCandidatesHelper(ctx context.Context, index collectionIndex, req *coreproto.SearchRequest, k uint,
+) (vectorindex.SearchResult, *searchScores, error) {
+	fields, ok := index.(fieldsIndex)
+	if !ok {
+		return nil, nil, fmt.Errorf(ErrNoVectorFields, req.GetCollectionName())
+	}
+	candidates, fieldScores, err := fields.SearchFields(ctx, req.GetFieldQueries(), req.GetFusion(), req.GetRrfK(), k)
+	if err != nil {
+		return nil, nil, err
+	}
+	return candidates, &searchScores{fields: fieldScores}, nil
+}
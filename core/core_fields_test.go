@@ -0,0 +1,142 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFields(t *testing.T) fieldsIndex {
+	index, err := newFieldsHelper([]*diskproto.VectorField{
+		{Name: "a", Dim: 2, Distance: MANHATTAN},
+		{Name: "b", Dim: 2, Distance: MANHATTAN},
+	}, nil)
+	assert.Nil(t, err)
+	return index
+}
+
+// insertFields adds the records, a: (x, 0) and b: (y, 0) for each id: {x, y}
+func insertFields(t *testing.T, index fieldsIndex, records map[uint64][2]float32) {
+	for id, record := range records {
+		vector, err := index.compose(map[string]gomath.Vector{"a": {record[0], 0}, "b": {record[1], 0}})
+		assert.Nil(t, err)
+		assert.Nil(t, index.Insert(id, vector, vectorindex.Metadata{"_id": fmt.Sprint(id)}))
+	}
+}
+
+func TestFieldsCompose(t *testing.T) {
+	index := newTestFields(t)
+
+	vector, err := index.compose(map[string]gomath.Vector{"b": {3, 4}, "a": {1, 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, gomath.Vector{1, 2, 3, 4}, vector)
+
+	_, err = index.compose(map[string]gomath.Vector{"a": {1, 2}, "b": {3, 4}, "c": {5, 6}})
+	assert.EqualError(t, err, fmt.Sprintf(ErrUnknownVectorField, "c"))
+	_, err = index.compose(map[string]gomath.Vector{"a": {1, 2}})
+	assert.EqualError(t, err, fmt.Sprintf(ErrMissingVectorField, "b"))
+	_, err = index.compose(map[string]gomath.Vector{"a": {1, 2}, "b": {3}})
+	assert.EqualError(t, err, fmt.Sprintf(ErrVectorFieldDim, "b", 2, 1))
+
+	_, err = newFieldsHelper([]*diskproto.VectorField{{Name: "a", Dim: 2}, {Name: "a", Dim: 2}}, nil)
+	assert.EqualError(t, err, fmt.Sprintf(ErrDuplicateVectorField, "a"))
+	_, err = newFieldsHelper([]*diskproto.VectorField{{Name: "a"}}, nil)
+	assert.EqualError(t, err, fmt.Sprintf(ErrVectorField, "a"))
+}
+
+func TestFieldsInsertRollback(t *testing.T) {
+	index := newTestFields(t)
+	assert.EqualError(t, index.Insert(1, gomath.Vector{1, 2, 3}, nil), fmt.Sprintf(ErrFieldsRecordDim, 4, 3))
+
+	// the second field already holds the id, the first one gives it back
+	assert.Nil(t, index.field("b").graph.Insert(1, gomath.Vector{0, 0}, nil, 0))
+	err := index.Insert(1, gomath.Vector{1, 2, 3, 4}, vectorindex.Metadata{"_id": "1"})
+	assert.ErrorIs(t, err, vectorindex.ItemAlreadyExistsError)
+	assert.Equal(t, 0, index.field("a").graph.Len())
+	_, err = index.field("a").graph.Get(1)
+	assert.ErrorIs(t, err, vectorindex.ItemNotFoundError)
+}
+
+func TestFieldsSearchFusion(t *testing.T) {
+	index := newTestFields(t)
+	insertFields(t, index, map[uint64][2]float32{1: {0, 3}, 2: {1, 0}, 3: {2, 1}})
+	queries := []*coreproto.FieldQuery{
+		{Field: "a", Vector: []float32{0, 0}},
+		{Field: "b", Vector: []float32{0, 0}},
+	}
+
+	// weighted: the mean of 100 - distance, 1 and 3 tie and go by id
+	result, fieldScores, err := index.SearchFields(context.Background(), queries, coreproto.Fusion_WeightedSum, 0, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 1, 3}, resultIds(result))
+	assert.InDelta(t, 99.5, result[0].Score, 1e-4)
+	assert.InDelta(t, 98.5, result[1].Score, 1e-4)
+	assert.InDelta(t, 98.5, result[2].Score, 1e-4)
+	assert.Equal(t, map[string]float32{"a": 100, "b": 97}, fieldScores[1])
+
+	// weights are scaled to sum to 1, a alone decides
+	queries[0].Weight, queries[1].Weight = 2, 0
+	result, _, err = index.SearchFields(context.Background(), queries, coreproto.Fusion_WeightedSum, 0, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2}, resultIds(result))
+	assert.InDelta(t, 100, result[0].Score, 1e-4)
+
+	// rrf: a ranks 1, 2, 3 and b ranks 2, 3, 1
+	queries[0].Weight, queries[1].Weight = 0, 0
+	result, _, err = index.SearchFields(context.Background(), queries, coreproto.Fusion_Rrf, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 1, 3}, resultIds(result))
+	assert.InDelta(t, 0.5/3+0.5/2, result[0].Score, 1e-6)
+	assert.InDelta(t, 0.5/2+0.5/4, result[1].Score, 1e-6)
+	assert.InDelta(t, 0.5/4+0.5/3, result[2].Score, 1e-6)
+
+	_, _, err = index.SearchFields(context.Background(), nil, coreproto.Fusion_Rrf, 0, 3)
+	assert.EqualError(t, err, ErrNoFieldQueries)
+	_, err = index.Search(context.Background(), gomath.Vector{0, 0}, 3)
+	assert.EqualError(t, err, ErrNoFieldQueries)
+	_, _, err = index.SearchFields(context.Background(), []*coreproto.FieldQuery{{Field: "a", Vector: []float32{0, 0}, Weight: -1}}, coreproto.Fusion_Rrf, 0, 3)
+	assert.EqualError(t, err, fmt.Sprintf(ErrFieldWeight, "a"))
+}
+
+func TestFieldsCommitAndLoad(t *testing.T) {
+	index := newTestFields(t)
+	insertFields(t, index, map[uint64][2]float32{1: {0, 3}, 2: {1, 0}, 3: {2, 1}})
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	data := buf.Bytes()
+
+	loaded := newTestFields(t)
+	assert.Nil(t, loaded.Load(bytes.NewReader(data)))
+	assert.Equal(t, index.Len(), loaded.Len())
+	for _, id := range []uint64{1, 2, 3} {
+		vector, err := index.Get(id)
+		assert.Nil(t, err)
+		loadedVector, err := loaded.Get(id)
+		assert.Nil(t, err)
+		assert.Equal(t, vector, loadedVector)
+		metadata, err := loaded.Metadata(id)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(id), metadata["_id"])
+	}
+	_, err := loaded.field("b").graph.GetVertex(1)
+	assert.Nil(t, err)
+
+	// each field graph is length prefixed, a cut stream fails in the last one
+	assert.NotNil(t, newTestFields(t).Load(bytes.NewReader(data[:len(data)-1])))
+}
+
+func resultIds(result vectorindex.SearchResult) []uint64 {
+	ids := make([]uint64, 0, len(result))
+	for _, item := range result {
+		ids = append(ids, item.Id)
+	}
+	return ids
+}
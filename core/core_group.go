@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/index"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// times a grouped search doubles its fetch at most, groups that are still
+// short after that are given as they are
+const groupMaxWiden = 6
+
+type candidateGroup struct {
+	value      string
+	candidates vectorindex.SearchResult
+}
+
+func groupLimitHelper(req *coreproto.SearchRequest) (size int, limit int) {
+	size, limit = int(req.GetGroupSize()), int(req.GetLimit())
+	if size == 0 {
+		size = 1
+	}
+	if limit == 0 {
+		limit = int(req.GetTopK())
+	}
+	return size, limit
+}
+
+/* groupHelper puts the candidates into groups by the value of field, in the
+ * order of their best candidate. The first limit groups keep up to size
+ * candidates each, candidates without the field are left out. full tells
+ * there are limit groups and none of them is short. */
+func groupHelper(candidates vectorindex.SearchResult, field string, size, limit int) (groups []candidateGroup, full bool) {
+	pos := make(map[string]int)
+	for _, candidate := range candidates {
+		val, ok := candidate.Metadata[field]
+		if !ok || val == nil {
+			continue
+		}
+		value := index.FilterValue(val)
+		i, ok := pos[value]
+		if !ok {
+			if len(groups) == limit {
+				continue
+			}
+			i = len(groups)
+			pos[value] = i
+			groups = append(groups, candidateGroup{value: value})
+		}
+		if len(groups[i].candidates) < size {
+			groups[i].candidates = append(groups[i].candidates, candidate)
+		}
+	}
+	if len(groups) < limit {
+		return groups, false
+	}
+	for _, group := range groups {
+		if len(group.candidates) < size {
+			return groups, false
+		}
+	}
+	return groups, true
+}
+
+/* groupSearchHelper searches until limit groups of size candidates are
+ * filled. Each round doubles the fetch, which widens the ef of a graph and
+ * the heap of a flat scan, and it stops early once the collection has no
+ * more candidates to give. */
+func (xx *Core) groupSearchHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest,
+) ([]candidateGroup, *searchScores, error) {
+	if req.GetDiversify() != nil {
+		return nil, nil, errors.New(ErrGroupDiversify)
+	}
+	if req.Radius != nil {
+		return nil, nil, errors.New(ErrRangeSearch)
+	}
+	if req.GetOffset() > 0 || req.GetPageToken() != "" {
+		return nil, nil, errors.New(ErrPageSearch)
+	}
+	size, limit := groupLimitHelper(req)
+	if limit == 0 {
+		return nil, nil, nil
+	}
+	fetchK := size * limit
+	for widen := 0; ; widen++ {
+		candidates, scores, err := xx.candidatesHelper(ctx, collection, req, uint(fetchK))
+		if err != nil {
+			return nil, nil, err
+		}
+		kept := thresholdHelper(collection, candidates, scores, req.GetMinScoreThreshold())
+		groups, full := groupHelper(kept, req.GetGroupBy(), size, limit)
+		// the candidates come best first, once the threshold cuts into them a
+		// wider fetch only adds lower scores
+		if full || len(kept) < len(candidates) || len(candidates) < fetchK || fetchK >= collection.Len() || widen == groupMaxWiden {
+			return groups, scores, nil
+		}
+		fetchK *= 2
+	}
+}
+
+// thresholdHelper keeps the candidates that score at least minScore, the
+// score a candidate is answered with.
+func thresholdHelper(collection collectionIndex, candidates vectorindex.SearchResult, scores *searchScores,
+	minScore float32) vectorindex.SearchResult {
+	kept := make(vectorindex.SearchResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		score := candidate.Score
+		if scores == nil {
+			score = scoreHelper(candidate.Score, collection.Distance(), collection.Dim())
+		}
+		if score >= minScore {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
+
+func searchCandidateHelper(collection collectionIndex, candidate vectorindex.SearchResultItem, scores *searchScores,
+	proj *projection) (*coreproto.Candidates, error) {
+	var err error
+	n := new(coreproto.Candidates)
+	n.Id = candidate.Metadata["_id"].(string)
+	n.Metadata, err = structpb.NewStruct(proj.metadata(candidate.Metadata))
+	if err != nil {
+		return nil, err
+	}
+	if scores != nil {
+		scores.fill(n, candidate)
+	} else {
+		n.Score = scoreHelper(candidate.Score, collection.Distance(), collection.Dim())
+	}
+	return n, nil
+}
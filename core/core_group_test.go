@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupHelper(t *testing.T) {
+	// best first, 4 has no field and 8 a null one
+	groups := []any{"a", "b", "a", nil, "c", "a", "b", "null"}
+	candidates := make(vectorindex.SearchResult, 0, len(groups))
+	for i, group := range groups {
+		metadata := vectorindex.Metadata{}
+		switch group {
+		case nil:
+		case "null":
+			metadata["g"] = nil
+		default:
+			metadata["g"] = group
+		}
+		candidates = append(candidates, vectorindex.SearchResultItem{Id: uint64(i + 1), Metadata: metadata})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		size   int
+		limit  int
+		groups map[string][]uint64
+		order  []string
+		full   bool
+	}{
+		{"one of the best groups", 1, 2, map[string][]uint64{"a": {1}, "b": {2}}, []string{"a", "b"}, true},
+		{"size truncates groups", 2, 2, map[string][]uint64{"a": {1, 3}, "b": {2, 7}}, []string{"a", "b"}, true},
+		{"short groups", 3, 3, map[string][]uint64{"a": {1, 3, 6}, "b": {2, 7}, "c": {5}}, []string{"a", "b", "c"}, false},
+		{"fewer groups than limit", 1, 5, map[string][]uint64{"a": {1}, "b": {2}, "c": {5}}, []string{"a", "b", "c"}, false},
+		{"limit truncates groups", 5, 1, map[string][]uint64{"a": {1, 3, 6}}, []string{"a"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			found, full := groupHelper(candidates, "g", tc.size, tc.limit)
+			assert.Equal(t, tc.full, full)
+			order := make([]string, 0, len(found))
+			for _, group := range found {
+				order = append(order, group.value)
+				assert.Equal(t, tc.groups[group.value], resultIds(group.candidates))
+			}
+			assert.Equal(t, tc.order, order)
+		})
+	}
+}
+
+// searchCounter records the k of every search of the index it wraps.
+type searchCounter struct {
+	collectionIndex
+	searches []uint
+}
+
+func (xx *searchCounter) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	xx.searches = append(xx.searches, k)
+	return xx.collectionIndex.Search(ctx, query, k)
+}
+
+func TestGroupSearchHelperWidens(t *testing.T) {
+	// record i lies at (i, 0), the query at the origin
+	newCollection := func(n int, group func(i int) string) *searchCounter {
+		graph := hnswIndex{vectorindex.NewHnsw(2, distance.NewEuclidean())}
+		for i := 0; i < n; i++ {
+			metadata := vectorindex.Metadata{"_id": fmt.Sprint(i), "g": group(i)}
+			assert.Nil(t, graph.Insert(uint64(i), gomath.Vector{float32(i), 0}, metadata))
+		}
+		return &searchCounter{collectionIndex: graph}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		n        int
+		group    func(i int) string
+		size     uint32
+		limit    uint32
+		minScore float32
+		groups   []string
+		searches []uint
+	}{
+		{"first fetch fills the groups", 20, func(i int) string { return fmt.Sprint(i % 2) }, 2, 2, 0,
+			[]string{"0", "1"}, []uint{4}},
+		{"widens to the farthest group", 20, func(i int) string {
+			if i == 19 {
+				return "far"
+			}
+			return "near"
+		}, 1, 2, 0, []string{"near", "far"}, []uint{2, 4, 8, 16, 32}},
+		{"stops where the threshold cuts", 20, func(i int) string {
+			if i == 19 {
+				return "far"
+			}
+			return "near"
+		}, 1, 2, 90, []string{"near"}, []uint{2, 4, 8, 16}},
+		{"stops once the collection is exhausted", 20, func(i int) string { return fmt.Sprint(i % 2) }, 1, 3, 0,
+			[]string{"0", "1"}, []uint{3, 6, 12, 24}},
+		{"stops after the widen rounds", 400, func(i int) string { return "same" }, 1, 2, 0,
+			[]string{"same"}, []uint{2, 4, 8, 16, 32, 64, 128}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			collection := newCollection(tc.n, tc.group)
+			groups, _, err := (&Core{}).groupSearchHelper(context.Background(), collection, &coreproto.SearchRequest{
+				Vector: []float32{0, 0}, GroupBy: "g", GroupSize: tc.size, Limit: tc.limit,
+				MinScoreThreshold: tc.minScore,
+			})
+			assert.Nil(t, err)
+			values := make([]string, 0, len(groups))
+			for _, group := range groups {
+				values = append(values, group.value)
+				assert.LessOrEqual(t, len(group.candidates), int(tc.size))
+			}
+			assert.Equal(t, tc.groups, values)
+			assert.Equal(t, tc.searches, collection.searches)
+		})
+	}
+
+	// the best result of each group orders the groups
+	collection := newCollection(20, func(i int) string { return fmt.Sprint(i % 3) })
+	groups, _, err := (&Core{}).groupSearchHelper(context.Background(), collection, &coreproto.SearchRequest{
+		Vector: []float32{0, 0}, GroupBy: "g", GroupSize: 2, TopK: 3,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, groups, 3)
+	assert.Equal(t, []uint64{0, 3}, resultIds(groups[0].candidates))
+	assert.Equal(t, []uint64{1, 4}, resultIds(groups[1].candidates))
+	assert.Equal(t, []uint64{2, 5}, resultIds(groups[2].candidates))
+}
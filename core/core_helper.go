@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/sjy-dv/nnv/pkg/distance"
 	"github.com/sjy-dv/nnv/pkg/index"
 	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/grpc/status"
 )
 
 func errorWrap(errMsg string) *coreproto.Error {
@@ -21,10 +23,24 @@ func errorWrap(errMsg string) *coreproto.Error {
 	}
 }
 
+// contextErrorHelper turns a search stopped by its ctx into the grpc
+// DEADLINE_EXCEEDED or CANCELLED status, other errors give nil.
+func contextErrorHelper(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
 func (xx *Core) diskClear(collectionName string) {
 	//delete disk config
 	configKey := fmt.Sprintf(diskRule0, collectionName)
-	xx.DataStore.Del(collectionName)
+	xx.dropIndex(collectionName)
+	xx.rebuilds.Del(collectionName)
+	xx.queryTimeouts.Del(collectionName)
+	xx.sparseStores.Del(collectionName)
+	os.Remove(fmt.Sprintf(sparseRule, collectionName))
+	vamanaClearHelper(collectionName)
 	xx.CommitLog.Delete([]byte(configKey))
 	xx.CommitLog.AscendKeys([]byte(fmt.Sprintf(diskRule2, collectionName)),
 		true, func(k []byte) (bool, error) {
@@ -37,7 +53,9 @@ func (xx *Core) diskClear(collectionName string) {
 }
 
 func (xx *Core) memFree(collectionName string) {
-	xx.DataStore.Del(collectionName)
+	xx.dropIndex(collectionName)
+	xx.queryTimeouts.Del(collectionName)
+	xx.sparseStores.Del(collectionName)
 
 	indexdb.indexLock.Lock()
 	delete(indexdb.indexes, collectionName)
@@ -49,47 +67,59 @@ func (xx *Core) memFree(collectionName string) {
 }
 
 func (xx *Core) createSnapshotHelper(collectionName string) error {
-	var buf bytes.Buffer
 	index := xx.DataStore.Get(collectionName)
-	err := index.Commit(&buf, true)
-	if err != nil {
-		return err
+	if index == nil {
+		return fmt.Errorf(ErrCollectionNotLoad, collectionName)
 	}
-	return os.WriteFile(fmt.Sprintf(noQuantizationRule, collectionName), buf.Bytes(), 0644)
-}
-
-func (xx *Core) snapShotHelper(collectionName string, dim uint32, dist distance.Space, searchOpts vectorindex.HnswOption) error {
-	data, err := os.ReadFile(fmt.Sprintf(noQuantizationRule, collectionName))
-	if err != nil {
+	kind := indexKinds[index.Stats().GetIndexType()]
+	var buf bytes.Buffer
+	if err := index.Commit(&buf); err != nil {
 		return err
 	}
-	buf := bytes.NewBuffer(data)
-	hnsw := vectorindex.NewHnsw(uint(dim), dist, searchOpts)
-	err = hnsw.Load(buf, true)
-	if err != nil {
+	if err := xx.commitSparseHelper(collectionName); err != nil {
 		return err
 	}
-	xx.DataStore.Set(collectionName, hnsw)
-	return nil
+	return os.WriteFile(fmt.Sprintf(kind.snapshotRule, collectionName), buf.Bytes(), 0644)
 }
 
 func protoDistHelper(dist coreproto.Distance) (distance.Space, string) {
-	if dist == coreproto.Distance_Cosine {
+	switch dist {
+	case coreproto.Distance_Cosine:
 		return distance.NewCosine(), COSINE
+	case coreproto.Distance_InnerProduct:
+		return distance.NewInnerProduct(), INNER_PRODUCT
+	case coreproto.Distance_Manhattan:
+		return distance.NewManhattan(), MANHATTAN
+	case coreproto.Distance_Hamming:
+		return distance.NewHamming(), HAMMING
 	}
 	return distance.NewEuclidean(), EUCLIDEAN
 }
 
 func reverseprotoDistHelper(dist string) coreproto.Distance {
-	if dist == COSINE {
+	switch dist {
+	case COSINE:
 		return coreproto.Distance_Cosine
+	case INNER_PRODUCT:
+		return coreproto.Distance_InnerProduct
+	case MANHATTAN:
+		return coreproto.Distance_Manhattan
+	case HAMMING:
+		return coreproto.Distance_Hamming
 	}
 	return coreproto.Distance_Euclidean
 }
 
 func reversesingleprotoDistHelper(dist string) distance.Space {
-	if dist == COSINE {
+	switch dist {
+	case COSINE:
 		return distance.NewCosine()
+	case INNER_PRODUCT:
+		return distance.NewInnerProduct()
+	case MANHATTAN:
+		return distance.NewManhattan()
+	case HAMMING:
+		return distance.NewHamming()
 	}
 	return distance.NewEuclidean()
 }
@@ -109,6 +139,10 @@ func reverseSearchAlgoHelper(alg string) vectorindex.HnswOption {
 }
 
 func reverseConfigHelper(config vectorindex.ProtoConfig) *coreproto.HnswConfig {
+	var seed *int64
+	if config.Seeded {
+		seed = &config.Seed
+	}
 	return &coreproto.HnswConfig{
 		SearchAlgorithm: func() coreproto.SearchAlgorithm {
 			if config.SearchAlgorithm == "simple" {
@@ -124,6 +158,7 @@ func reverseConfigHelper(config vectorindex.ProtoConfig) *coreproto.HnswConfig {
 		MMax0:                     int32(config.MMax0),
 		HeuristicExtendCandidates: config.HeuristicExtendCandidates,
 		HeuristicKeepPruned:       config.HeuristicKeepPruned,
+		Seed:                      seed,
 	}
 }
 
@@ -221,18 +256,71 @@ func (xx *Core) rollbackForConsistentHelper(collectionName string, commitId uint
 	if err := xx.CommitLog.Delete([]byte(fmt.Sprintf(diskRule1, collectionName, commitId))); err != nil {
 		// using log after
 	}
-	hnsw := xx.DataStore.Get(collectionName)
-	if err := hnsw.Remove(commitId); err != nil {
+	index := xx.DataStore.Get(collectionName)
+	if err := index.Remove(commitId); err != nil {
 		//
 	}
+	if store := xx.sparseStores.Get(collectionName); store != nil {
+		store.Remove(commitId)
+	}
 	if err := indexdb.indexes[collectionName].Remove(commitId, metadata); err != nil {
 		//
 	}
 }
 
-func scoreHelper(score float32, dist string) float32 {
-	if dist == COSINE {
+// annOrderHelper gives the ids of ranked that are in matched, in the order of
+// ranked.
+func annOrderHelper(ranked, matched []uint64) []uint64 {
+	keep := make(map[uint64]struct{}, len(matched))
+	for _, id := range matched {
+		keep[id] = struct{}{}
+	}
+	out := make([]uint64, 0, len(matched))
+	for _, id := range ranked {
+		if _, ok := keep[id]; ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func geoQueryHelper(f *coreproto.GeoFilter) (*index.GeoQuery, error) {
+	if f == nil {
+		return nil, nil
+	}
+	if f.GetField() == "" {
+		return nil, errors.New("geo filter field is empty")
+	}
+	q := &index.GeoQuery{Field: f.GetField(), SortByDistance: f.GetSortByDistance()}
+	if f.GetRadiusMeters() > 0 {
+		q.Center = index.GeoPoint{Lat: f.GetCenter().GetLat(), Lon: f.GetCenter().GetLon()}
+		q.Radius = f.GetRadiusMeters()
+		return q, nil
+	}
+	box := f.GetBoundingBox()
+	if box == nil {
+		return nil, errors.New("geo filter needs a radius or a bounding box")
+	}
+	q.MaxLat, q.MinLon = box.GetTopLeft().GetLat(), box.GetTopLeft().GetLon()
+	q.MinLat, q.MaxLon = box.GetBottomRight().GetLat(), box.GetBottomRight().GetLon()
+	if q.MinLat > q.MaxLat {
+		return nil, errors.New("geo filter bounding box top is below bottom")
+	}
+	return q, nil
+}
+
+func scoreHelper(score float32, dist string, dim uint32) float32 {
+	switch dist {
+	case COSINE:
 		return ((2 - score) / 2) * 100
+	case INNER_PRODUCT:
+		// unbounded, squash the inner product (-score) with a sigmoid
+		return float32(100 / (1 + math.Exp(float64(score))))
+	case HAMMING:
+		if dim == 0 {
+			return 0
+		}
+		return (1 - score/float32(dim)) * 100
 	}
 	return float32(math.Max(0, float64(100-score)))
 }
@@ -0,0 +1,616 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/nnv/core/flat"
+	"github.com/sjy-dv/nnv/core/ivf"
+	"github.com/sjy-dv/nnv/core/pqhnsw"
+	"github.com/sjy-dv/nnv/core/vamana"
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/fs"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/sjy-dv/nnv/pkg/index"
+	"google.golang.org/protobuf/proto"
+)
+
+// collectionIndex is the vector index behind a collection. The handlers only
+// talk to this interface, an index type is added by implementing it and
+// registering an indexKind.
+type collectionIndex interface {
+	Insert(id uint64, vector gomath.Vector, metadata vectorindex.Metadata) error
+	Remove(id uint64) error
+	Get(id uint64) (gomath.Vector, error)
+	Metadata(id uint64) (vectorindex.Metadata, error)
+	Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error)
+	Len() int
+	Dim() uint32
+	Distance() string
+	Commit(w io.Writer) error
+	Load(r io.Reader) error
+	// Stats fills the index part of the collection info, the type, size and config.
+	Stats() *coreproto.CollectionInfo
+}
+
+/* indexKind builds the index of one coreproto.IndexType. The name is kept in
+ * the collection archive so a load opens the same kind, Commit of the index is
+ * written to snapshotRule. */
+type indexKind struct {
+	name         string
+	snapshotRule string
+	// create builds the index of a new collection and records its resolved
+	// config in the archive
+	create func(collectionName string, spec *coreproto.CollectionSpec, dist distance.Space, archive *diskproto.Collection) (collectionIndex, error)
+	// open builds an empty index from the archive for the snapshot to load into
+	open func(collectionName string, archive *diskproto.Collection) (collectionIndex, error)
+}
+
+var indexKinds = map[coreproto.IndexType]indexKind{
+	coreproto.IndexType_Hnsw: {
+		name:         HNSW_INDEX,
+		snapshotRule: noQuantizationRule,
+		create:       createHnswHelper,
+		open:         openHnswHelper,
+	},
+	coreproto.IndexType_Vamana: {
+		name:         VAMANA_INDEX,
+		snapshotRule: vamanaRule,
+		create:       createVamanaHelper,
+		open:         openVamanaHelper,
+	},
+	coreproto.IndexType_Ivf: {
+		name:         IVF_INDEX,
+		snapshotRule: ivfRule,
+		create:       createIvfHelper,
+		open:         openIvfHelper,
+	},
+	coreproto.IndexType_HnswPq: {
+		name:         HNSW_PQ_INDEX,
+		snapshotRule: hnswPqRule,
+		create:       createHnswPqHelper,
+		open:         openHnswPqHelper,
+	},
+	coreproto.IndexType_Flat: {
+		name:         FLAT_INDEX,
+		snapshotRule: flatRule,
+		create:       createFlatHelper,
+		open:         openFlatHelper,
+	},
+}
+
+// indexKindHelper finds the kind of an archived collection, archives written
+// before index types existed hold an hnsw graph.
+func indexKindHelper(name string) (indexKind, error) {
+	if name == "" {
+		return indexKinds[coreproto.IndexType_Hnsw], nil
+	}
+	for _, kind := range indexKinds {
+		if kind.name == name {
+			return kind, nil
+		}
+	}
+	return indexKind{}, fmt.Errorf(ErrUnknownIndexType, name)
+}
+
+// setIndex replaces the index of a collection, a replaced vamana graph releases its block file.
+func (xx *Core) setIndex(collectionName string, index collectionIndex) {
+	previous := xx.DataStore.Get(collectionName)
+	xx.DataStore.Set(collectionName, index)
+	closeIndexHelper(previous)
+}
+
+func (xx *Core) dropIndex(collectionName string) {
+	previous := xx.DataStore.Get(collectionName)
+	xx.DataStore.Del(collectionName)
+	closeIndexHelper(previous)
+}
+
+// closeIndexHelper releases what a replaced index holds, a running rebuild is cancelled.
+func closeIndexHelper(index collectionIndex) {
+	if serving, ok := index.(rebuildIndex); ok {
+		serving.rebuild.cancel()
+	}
+	if closer, ok := index.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// loadIndexHelper opens the archived index kind and loads its last snapshot.
+func (xx *Core) loadIndexHelper(collectionName string, archive *diskproto.Collection) error {
+	kind, err := indexKindHelper(archive.GetIndexType())
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(fmt.Sprintf(kind.snapshotRule, collectionName))
+	if err != nil {
+		return err
+	}
+	index, err := kind.open(collectionName, archive)
+	if err != nil {
+		return err
+	}
+	if err := index.Load(bytes.NewReader(data)); err != nil {
+		closeIndexHelper(index)
+		return err
+	}
+	if err := xx.loadSparseHelper(collectionName); err != nil {
+		closeIndexHelper(index)
+		return err
+	}
+	xx.setIndex(collectionName, index)
+	xx.setQueryTimeout(collectionName, archive.GetMaxQueryTimeMs())
+	return nil
+}
+
+func (xx *Core) collectionInfoHelper(collectionName string, index collectionIndex) *coreproto.CollectionInfo {
+	info := index.Stats()
+	info.CollectionName = collectionName
+	info.VectorDimension = index.Dim()
+	info.Distance = reverseprotoDistHelper(index.Distance())
+	info.MaxQueryTimeMs = uint32(xx.queryTimeouts.Get(collectionName).Milliseconds())
+	info.TextFields = textFieldsInfoHelper(collectionName)
+	return info
+}
+
+func (xx *Core) setQueryTimeout(collectionName string, ms uint32) {
+	if ms == 0 {
+		xx.queryTimeouts.Del(collectionName)
+		return
+	}
+	xx.queryTimeouts.Set(collectionName, time.Duration(ms)*time.Millisecond)
+}
+
+// queryContextHelper bounds a search by the rpc ctx and the collection max query time.
+func (xx *Core) queryContextHelper(ctx context.Context, collectionName string) (context.Context, context.CancelFunc) {
+	if timeout := xx.queryTimeouts.Get(collectionName); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func indexStatsHelper(indexType coreproto.IndexType, length int, bytesSize uint64) *coreproto.CollectionInfo {
+	return &coreproto.CollectionInfo{
+		IndexType:        indexType,
+		CollectionSize:   fmt.Sprintf("%d bytes", bytesSize),
+		CollectionLength: uint64(length),
+	}
+}
+
+// searchHelper passes the per query nprobe to ivf collections.
+func searchHelper(ctx context.Context, index collectionIndex, query gomath.Vector, k uint, nprobe uint32) (vectorindex.SearchResult, error) {
+	if inverted, ok := index.(ivfIndex); ok {
+		return inverted.SearchProbe(ctx, query, k, uint(nprobe))
+	}
+	return index.Search(ctx, query, k)
+}
+
+// hybridCandidatesHelper lets a flat collection scan only the ids that pass
+// the filter, the other kinds oversample and filter the candidates afterwards.
+func (xx *Core) hybridCandidatesHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest, geo *index.GeoQuery, k uint,
+) (vectorindex.SearchResult, *searchScores, error) {
+	scan, ok := collection.(flatIndex)
+	if !ok || (len(req.GetFilter()) == 0 && geo == nil) || len(req.GetSparseVector().GetIndices()) > 0 {
+		return xx.candidatesHelper(ctx, collection, req, k)
+	}
+	matched := indexdb.indexes[req.GetCollectionName()].PureGeoSearch(req.GetFilter(), geo)
+	allow := make(map[uint64]struct{}, len(matched))
+	for _, id := range matched {
+		allow[id] = struct{}{}
+	}
+	candidates, err := scan.SearchFilter(ctx, req.GetVector(), k, func(id uint64) bool {
+		_, ok := allow[id]
+		return ok
+	})
+	return candidates, nil, err
+}
+
+type hnswIndex struct {
+	*vectorindex.Hnsw
+}
+
+func (xx hnswIndex) Insert(id uint64, vector gomath.Vector, metadata vectorindex.Metadata) error {
+	return xx.Hnsw.Insert(id, vector, metadata, xx.RandomLevel())
+}
+
+// InsertBulk builds the copied vectors of a flat promotion on every core.
+func (xx hnswIndex) InsertBulk(items []vectorindex.BuildItem) error {
+	_, err := xx.BuildSlice(context.TODO(), items,
+		vectorindex.BuildProgressEvery(100000),
+		vectorindex.BuildProgress(func(done, total int) {
+			log.Debug().Int("done", done).Int("total", total).Msg("hnsw bulk build")
+		}))
+	return err
+}
+
+func (xx hnswIndex) Metadata(id uint64) (vectorindex.Metadata, error) {
+	vertex, err := xx.GetVertex(id)
+	if err != nil {
+		return nil, err
+	}
+	return vertex.Metadata(), nil
+}
+
+func (xx hnswIndex) Commit(w io.Writer) error {
+	return xx.Hnsw.Commit(w, true)
+}
+
+func (xx hnswIndex) Load(r io.Reader) error {
+	return xx.Hnsw.Load(r, true)
+}
+
+func (xx hnswIndex) Stats() *coreproto.CollectionInfo {
+	info := indexStatsHelper(coreproto.IndexType_Hnsw, xx.Len(), xx.BytesSize())
+	info.CollectionConfig = reverseConfigHelper(xx.Config())
+	return info
+}
+
+func createHnswHelper(collectionName string, spec *coreproto.CollectionSpec, dist distance.Space, archive *diskproto.Collection) (collectionIndex, error) {
+	_, searchOpts := protoSearchAlgoHelper(spec.GetCollectionConfig().GetSearchAlgorithm())
+	options := []vectorindex.HnswOption{searchOpts}
+	if config := spec.GetCollectionConfig(); config != nil && config.Seed != nil {
+		options = append(options, vectorindex.HnswSeed(config.GetSeed()))
+		archive.HnswSeed = config.Seed
+	}
+	if len(spec.GetVectorFields()) > 0 {
+		specs := diskFieldsHelper(spec.GetVectorFields())
+		fields, err := newFieldsHelper(specs, options)
+		if err != nil {
+			return nil, err
+		}
+		archive.VectorFields = specs
+		archive.VectorDimension = fields.Dim()
+		archive.Distance = specs[0].GetDistance()
+		return fields, nil
+	}
+	return hnswIndex{vectorindex.NewHnsw(uint(spec.GetVectorDimension()), dist, options...)}, nil
+}
+
+func openHnswHelper(collectionName string, archive *diskproto.Collection) (collectionIndex, error) {
+	options := []vectorindex.HnswOption{reverseSearchAlgoHelper(archive.GetSearchAlgorithm())}
+	if archive.HnswSeed != nil {
+		options = append(options, vectorindex.HnswSeed(archive.GetHnswSeed()))
+	}
+	if len(archive.GetVectorFields()) > 0 {
+		return newFieldsHelper(archive.GetVectorFields(), options)
+	}
+	return hnswIndex{vectorindex.NewHnsw(uint(archive.GetVectorDimension()),
+		reversesingleprotoDistHelper(archive.GetDistance()), options...)}, nil
+}
+
+type vamanaIndex struct {
+	*vamana.Vamana
+}
+
+func (xx vamanaIndex) Stats() *coreproto.CollectionInfo {
+	info := indexStatsHelper(coreproto.IndexType_Vamana, xx.Len(), xx.BytesSize())
+	info.VamanaConfig = reverseVamanaConfigHelper(xx.Config())
+	return info
+}
+
+// createVamanaHelper creates an empty vamana graph over a fresh block file.
+func createVamanaHelper(collectionName string, spec *coreproto.CollectionSpec, dist distance.Space, archive *diskproto.Collection) (collectionIndex, error) {
+	blockFile := fmt.Sprintf(vamanaBlockRule, collectionName)
+	if err := os.Remove(blockFile); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	file, err := fs.Open(blockFile, fs.OSFileSystem)
+	if err != nil {
+		return nil, err
+	}
+	graph := vamana.NewVamana(file, uint(spec.GetVectorDimension()), dist, protoVamanaHelper(spec.GetVamanaConfig())...)
+	// keep the resolved defaults so a reload builds the same block layout
+	config := graph.Config()
+	archive.VamanaMaxDegree = uint32(config.MaxDegree)
+	archive.VamanaBuildListSize = uint32(config.BuildListSize)
+	archive.VamanaSearchListSize = uint32(config.SearchListSize)
+	archive.VamanaAlpha = config.Alpha
+	archive.VamanaBeamWidth = uint32(config.BeamWidth)
+	archive.VamanaPqSubvectors = uint32(config.PQSubvectors)
+	return vamanaIndex{graph}, nil
+}
+
+func openVamanaHelper(collectionName string, archive *diskproto.Collection) (collectionIndex, error) {
+	file, err := fs.Open(fmt.Sprintf(vamanaBlockRule, collectionName), fs.OSFileSystem)
+	if err != nil {
+		return nil, err
+	}
+	return vamanaIndex{vamana.NewVamana(file, uint(archive.GetVectorDimension()),
+		reversesingleprotoDistHelper(archive.GetDistance()),
+		protoVamanaHelper(diskVamanaHelper(archive))...)}, nil
+}
+
+func protoVamanaHelper(config *coreproto.VamanaConfig) []vamana.VamanaOption {
+	options := make([]vamana.VamanaOption, 0, 6)
+	if config.GetMaxDegree() > 0 {
+		options = append(options, vamana.VamanaMaxDegree(int(config.GetMaxDegree())))
+	}
+	if config.GetBuildListSize() > 0 {
+		options = append(options, vamana.VamanaBuildListSize(int(config.GetBuildListSize())))
+	}
+	if config.GetSearchListSize() > 0 {
+		options = append(options, vamana.VamanaSearchListSize(int(config.GetSearchListSize())))
+	}
+	if config.GetAlpha() > 0 {
+		options = append(options, vamana.VamanaAlpha(config.GetAlpha()))
+	}
+	if config.GetBeamWidth() > 0 {
+		options = append(options, vamana.VamanaBeamWidth(int(config.GetBeamWidth())))
+	}
+	if config.GetPqSubvectors() > 0 {
+		options = append(options, vamana.VamanaPQSubvectors(int(config.GetPqSubvectors())))
+	}
+	return options
+}
+
+func reverseVamanaConfigHelper(config vamana.ProtoConfig) *coreproto.VamanaConfig {
+	return &coreproto.VamanaConfig{
+		MaxDegree:      uint32(config.MaxDegree),
+		BuildListSize:  uint32(config.BuildListSize),
+		SearchListSize: uint32(config.SearchListSize),
+		Alpha:          config.Alpha,
+		BeamWidth:      uint32(config.BeamWidth),
+		PqSubvectors:   uint32(config.PQSubvectors),
+	}
+}
+
+func diskVamanaHelper(dp *diskproto.Collection) *coreproto.VamanaConfig {
+	return &coreproto.VamanaConfig{
+		MaxDegree:      dp.GetVamanaMaxDegree(),
+		BuildListSize:  dp.GetVamanaBuildListSize(),
+		SearchListSize: dp.GetVamanaSearchListSize(),
+		Alpha:          dp.GetVamanaAlpha(),
+		BeamWidth:      dp.GetVamanaBeamWidth(),
+		PqSubvectors:   dp.GetVamanaPqSubvectors(),
+	}
+}
+
+func vamanaClearHelper(collectionName string) {
+	os.Remove(fmt.Sprintf(vamanaRule, collectionName))
+	os.Remove(fmt.Sprintf(vamanaBlockRule, collectionName))
+}
+
+type ivfIndex struct {
+	*ivf.Ivf
+}
+
+func (xx ivfIndex) Stats() *coreproto.CollectionInfo {
+	info := indexStatsHelper(coreproto.IndexType_Ivf, xx.Len(), xx.BytesSize())
+	config := xx.Config()
+	info.IvfConfig = &coreproto.IvfConfig{
+		Nlist:     uint32(config.Nlist),
+		Nprobe:    uint32(config.Nprobe),
+		TrainSize: uint32(config.TrainSize),
+	}
+	return info
+}
+
+func createIvfHelper(collectionName string, spec *coreproto.CollectionSpec, dist distance.Space, archive *diskproto.Collection) (collectionIndex, error) {
+	inverted, err := ivf.NewIvf(uint(spec.GetVectorDimension()), dist, protoIvfHelper(spec.GetIvfConfig())...)
+	if err != nil {
+		return nil, err
+	}
+	config := inverted.Config()
+	archive.IvfNlist = uint32(config.Nlist)
+	archive.IvfNprobe = uint32(config.Nprobe)
+	archive.IvfTrainSize = uint32(config.TrainSize)
+	return ivfIndex{inverted}, nil
+}
+
+// openIvfHelper uses the default config, Load restores the committed one.
+func openIvfHelper(collectionName string, archive *diskproto.Collection) (collectionIndex, error) {
+	inverted, err := ivf.NewIvf(uint(archive.GetVectorDimension()), reversesingleprotoDistHelper(archive.GetDistance()))
+	if err != nil {
+		return nil, err
+	}
+	return ivfIndex{inverted}, nil
+}
+
+func protoIvfHelper(config *coreproto.IvfConfig) []ivf.IvfOption {
+	options := make([]ivf.IvfOption, 0, 3)
+	if config.GetNlist() > 0 {
+		options = append(options, ivf.IvfNlist(int(config.GetNlist())))
+	}
+	if config.GetNprobe() > 0 {
+		options = append(options, ivf.IvfNprobe(int(config.GetNprobe())))
+	}
+	if config.GetTrainSize() > 0 {
+		options = append(options, ivf.IvfTrainSize(int(config.GetTrainSize())))
+	}
+	return options
+}
+
+type hnswPqIndex struct {
+	*pqhnsw.PQHnsw
+}
+
+func (xx hnswPqIndex) Stats() *coreproto.CollectionInfo {
+	info := indexStatsHelper(coreproto.IndexType_HnswPq, xx.Len(), xx.BytesSize())
+	info.HnswPqConfig = reverseHnswPqConfigHelper(xx.Config())
+	return info
+}
+
+func createHnswPqHelper(collectionName string, spec *coreproto.CollectionSpec, dist distance.Space, archive *diskproto.Collection) (collectionIndex, error) {
+	graph, err := pqhnsw.NewPQHnsw(uint(spec.GetVectorDimension()), dist, protoHnswPqHelper(spec.GetHnswPqConfig())...)
+	if err != nil {
+		return nil, err
+	}
+	config := graph.Config()
+	archive.HnswPqM = uint32(config.M)
+	archive.HnswPqEfConstruction = uint32(config.EfConstruction)
+	archive.HnswPqEf = uint32(config.Ef)
+	archive.HnswPqCentroids = uint32(config.Centroids)
+	archive.HnswPqSubvectors = uint32(config.Subvectors)
+	archive.HnswPqTrainSize = uint32(config.TrainSize)
+	archive.HnswPqOptimizedRotation = config.Rotation
+	return hnswPqIndex{graph}, nil
+}
+
+func openHnswPqHelper(collectionName string, archive *diskproto.Collection) (collectionIndex, error) {
+	graph, err := pqhnsw.NewPQHnsw(uint(archive.GetVectorDimension()),
+		reversesingleprotoDistHelper(archive.GetDistance()),
+		protoHnswPqHelper(&coreproto.HnswPqConfig{
+			M:                 archive.GetHnswPqM(),
+			EfConstruction:    archive.GetHnswPqEfConstruction(),
+			Ef:                archive.GetHnswPqEf(),
+			Centroids:         archive.GetHnswPqCentroids(),
+			Subvectors:        archive.GetHnswPqSubvectors(),
+			TrainSize:         archive.GetHnswPqTrainSize(),
+			OptimizedRotation: archive.GetHnswPqOptimizedRotation(),
+		})...)
+	if err != nil {
+		return nil, err
+	}
+	return hnswPqIndex{graph}, nil
+}
+
+func protoHnswPqHelper(config *coreproto.HnswPqConfig) []pqhnsw.PQHnswOption {
+	options := make([]pqhnsw.PQHnswOption, 0, 7)
+	if config.GetM() > 0 {
+		options = append(options, pqhnsw.PQHnswM(int(config.GetM())))
+	}
+	if config.GetEfConstruction() > 0 {
+		options = append(options, pqhnsw.PQHnswEfConstruction(int(config.GetEfConstruction())))
+	}
+	if config.GetEf() > 0 {
+		options = append(options, pqhnsw.PQHnswEf(int(config.GetEf())))
+	}
+	if config.GetCentroids() > 0 {
+		options = append(options, pqhnsw.PQHnswCentroids(int(config.GetCentroids())))
+	}
+	if config.GetSubvectors() > 0 {
+		options = append(options, pqhnsw.PQHnswSubvectors(int(config.GetSubvectors())))
+	}
+	if config.GetTrainSize() > 0 {
+		options = append(options, pqhnsw.PQHnswTrainSize(int(config.GetTrainSize())))
+	}
+	if config.GetOptimizedRotation() {
+		options = append(options, pqhnsw.PQHnswRotation(true))
+	}
+	return options
+}
+
+func reverseHnswPqConfigHelper(config pqhnsw.ProtoConfig) *coreproto.HnswPqConfig {
+	return &coreproto.HnswPqConfig{
+		M:                 uint32(config.M),
+		EfConstruction:    uint32(config.EfConstruction),
+		Ef:                uint32(config.Ef),
+		Centroids:         uint32(config.Centroids),
+		Subvectors:        uint32(config.Subvectors),
+		TrainSize:         uint32(config.TrainSize),
+		OptimizedRotation: config.Rotation,
+	}
+}
+
+type flatIndex struct {
+	*flat.Flat
+}
+
+func (xx flatIndex) Stats() *coreproto.CollectionInfo {
+	info := indexStatsHelper(coreproto.IndexType_Flat, xx.Len(), xx.BytesSize())
+	config := xx.Config()
+	info.CompressionHelper = reverseFlatQuantizationHelper(config.Quantization)
+	info.FlatConfig = &coreproto.FlatConfig{
+		Shards:           uint32(config.Shards),
+		PromoteThreshold: uint32(config.PromoteThreshold),
+	}
+	return info
+}
+
+func createFlatHelper(collectionName string, spec *coreproto.CollectionSpec, dist distance.Space, archive *diskproto.Collection) (collectionIndex, error) {
+	options := make([]flat.FlatOption, 0, 3)
+	switch spec.GetCompressionHelper() {
+	case coreproto.Quantization_None:
+	case coreproto.Quantization_F16:
+		options = append(options, flat.FlatQuantization(flat.F16))
+	case coreproto.Quantization_BF16:
+		options = append(options, flat.FlatQuantization(flat.BF16))
+	default:
+		return nil, fmt.Errorf("flat index does not support %s quantization", spec.GetCompressionHelper())
+	}
+	if spec.GetFlatConfig().GetShards() > 0 {
+		options = append(options, flat.FlatShards(int(spec.GetFlatConfig().GetShards())))
+	}
+	if spec.GetFlatConfig().GetPromoteThreshold() > 0 {
+		options = append(options, flat.FlatPromoteThreshold(int(spec.GetFlatConfig().GetPromoteThreshold())))
+	}
+	scan, err := flat.NewFlat(uint(spec.GetVectorDimension()), dist, options...)
+	if err != nil {
+		return nil, err
+	}
+	config := scan.Config()
+	archive.Quantization = config.Quantization.String()
+	archive.FlatShards = uint32(config.Shards)
+	archive.FlatPromoteThreshold = uint32(config.PromoteThreshold)
+	return flatIndex{scan}, nil
+}
+
+// openFlatHelper uses the default config, Load restores the committed one.
+func openFlatHelper(collectionName string, archive *diskproto.Collection) (collectionIndex, error) {
+	scan, err := flat.NewFlat(uint(archive.GetVectorDimension()), reversesingleprotoDistHelper(archive.GetDistance()))
+	if err != nil {
+		return nil, err
+	}
+	return flatIndex{scan}, nil
+}
+
+func reverseFlatQuantizationHelper(q flat.Quantization) coreproto.Quantization {
+	switch q {
+	case flat.F16:
+		return coreproto.Quantization_F16
+	case flat.BF16:
+		return coreproto.Quantization_BF16
+	}
+	return coreproto.Quantization_None
+}
+
+// promoteFlatHelper moves a flat collection that crossed its promote threshold
+// to an hnsw graph built from the archived hnsw config.
+func (xx *Core) promoteFlatHelper(collectionName string, scan flatIndex) {
+	key := []byte(fmt.Sprintf(diskRule0, collectionName))
+	raw, err := xx.CommitLog.Get(key)
+	if err != nil {
+		log.Warn().Err(err).Str("collection", collectionName).Msg("flat promotion")
+		return
+	}
+	archive := diskproto.Collection{}
+	if err := proto.Unmarshal(raw, &archive); err != nil {
+		log.Warn().Err(err).Str("collection", collectionName).Msg("flat promotion")
+		return
+	}
+	graph, err := openHnswHelper(collectionName, &archive)
+	if err != nil {
+		log.Warn().Err(err).Str("collection", collectionName).Msg("flat promotion")
+		return
+	}
+	if err := scan.Promote(graph); err != nil {
+		if !errors.Is(err, flat.ErrPromoting) {
+			log.Warn().Err(err).Str("collection", collectionName).Msg("flat promotion")
+		}
+		return
+	}
+	// the collection may have been released while the graph was built
+	if current, ok := xx.DataStore.Get(collectionName).(flatIndex); !ok || current != scan {
+		return
+	}
+	archive.IndexType = HNSW_INDEX
+	raw, err = proto.Marshal(&archive)
+	if err == nil {
+		err = xx.CommitLog.Put(key, raw)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("collection", collectionName).Msg("flat promotion")
+	}
+	xx.setIndex(collectionName, graph)
+	os.Remove(fmt.Sprintf(flatRule, collectionName))
+	log.Info().Str("collection", collectionName).Int("size", graph.Len()).Msg("flat collection promoted to hnsw")
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/sjy-dv/nnv/pkg/mmr"
+)
+
+// candidates a diversified search picks from per result, unless fetch_k says otherwise
+const mmrOversample = 4
+
+// diversifyFetchHelper is how many candidates a search fetches, topK without diversify.
+func diversifyFetchHelper(diversify *coreproto.Diversify, topK uint64) uint64 {
+	if diversify == nil {
+		return topK
+	}
+	if diversify.GetFetchK() == 0 {
+		return topK * mmrOversample
+	}
+	return max(uint64(diversify.GetFetchK()), topK)
+}
+
+func diversifyLambdaHelper(diversify *coreproto.Diversify) (float32, error) {
+	if diversify.Lambda == nil {
+		return mmr.DefaultLambda, nil
+	}
+	if diversify.GetLambda() < 0 || diversify.GetLambda() > 1 {
+		return 0, errors.New(ErrDiversifyLambda)
+	}
+	return diversify.GetLambda(), nil
+}
+
+/* diversifyHelper picks k of the candidates by maximal marginal relevance. The
+ * relevance is the score of a candidate scaled to [0, 1] over the candidates,
+ * the similarity of two candidates is the score of their distance in the space
+ * of the collection. */
+func diversifyHelper(collection collectionIndex, candidates vectorindex.SearchResult, scores *searchScores,
+	diversify *coreproto.Diversify, k uint) (vectorindex.SearchResult, error) {
+	lambda, err := diversifyLambdaHelper(diversify)
+	if err != nil {
+		return nil, err
+	}
+	relevance := make([]float32, len(candidates))
+	vectors := make([]gomath.Vector, len(candidates))
+	for i, candidate := range candidates {
+		if scores != nil {
+			relevance[i] = candidate.Score
+		} else {
+			relevance[i] = scoreHelper(candidate.Score, collection.Distance(), collection.Dim())
+		}
+		if vectors[i], err = collection.Get(candidate.Id); err != nil {
+			return nil, err
+		}
+	}
+	space := reversesingleprotoDistHelper(collection.Distance())
+	picked := mmr.Select(mmr.Normalize(relevance), func(i, j int) float32 {
+		return scoreHelper(space.Distance(vectors[i], vectors[j]), collection.Distance(), collection.Dim()) / 100
+	}, lambda, int(k))
+	out := make(vectorindex.SearchResult, len(picked))
+	for i, pick := range picked {
+		out[i] = candidates[pick]
+	}
+	return out, nil
+}
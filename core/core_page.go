@@ -0,0 +1,84 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/paging"
+	"google.golang.org/protobuf/proto"
+)
+
+// pageQuery is where the page of a search starts, served results are ahead of
+// it and token is nil on the first page or with an offset.
+type pageQuery struct {
+	fingerprint uint64
+	token       *paging.Token
+	served      uint64
+}
+
+func pageQueryHelper(req *coreproto.SearchRequest) (*pageQuery, error) {
+	if (req.GetOffset() > 0 || req.GetPageToken() != "") && (req.GetGroupBy() != "" || req.GetDiversify() != nil) {
+		return nil, errors.New(ErrPageSearch)
+	}
+	query := proto.Clone(req).(*coreproto.SearchRequest)
+	query.TopK, query.Offset, query.PageToken, query.WithLatency = 0, 0, "", false
+	query.IncludeFields, query.ExcludeFields, query.WithVector = nil, nil, false
+	fingerprint, err := paging.Fingerprint(query)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPageToken() == "" {
+		return &pageQuery{fingerprint: fingerprint, served: uint64(req.GetOffset())}, nil
+	}
+	if req.GetOffset() > 0 {
+		return nil, errors.New(ErrPageOffset)
+	}
+	token, err := paging.Decode(req.GetPageToken())
+	if err != nil || token.Fingerprint != fingerprint {
+		return nil, errors.New(ErrPageToken)
+	}
+	return &pageQuery{fingerprint: fingerprint, token: &token, served: uint64(token.Served)}, nil
+}
+
+// fetch is how many candidates the page needs searched, one past it tells if
+// results are left. The larger k widens the ef of a graph as pages go deeper.
+func (xx *pageQuery) fetch(topK uint64) uint64 {
+	if topK == 0 {
+		return 0
+	}
+	return xx.served + topK + 1
+}
+
+/* page sorts the results by score and id and cuts out the page of topK, or
+ * everything after the start with topK 0. next continues after the page. */
+func (xx *pageQuery) page(results []*coreproto.Candidates, topK uint64) (page []*coreproto.Candidates, next string) {
+	paging.Sort(results)
+	size := int(topK)
+	if size == 0 {
+		size = len(results)
+	}
+	page, more := paging.Page(results, xx.token, int(xx.served), size)
+	if !more || len(page) == 0 {
+		return page, ""
+	}
+	return page, xx.next(len(page), page[len(page)-1])
+}
+
+// span is the page of topK within n matches that keep their own order, the
+// matches of a filter search are paged by position.
+func (xx *pageQuery) span(n int, topK uint64) (start, end int) {
+	start = int(min(xx.served, uint64(n)))
+	if topK == 0 {
+		return start, n
+	}
+	return start, int(min(xx.served+topK, uint64(n)))
+}
+
+func (xx *pageQuery) next(pageLen int, last *coreproto.Candidates) string {
+	return paging.Token{
+		Fingerprint: xx.fingerprint,
+		Served:      uint32(xx.served) + uint32(pageLen),
+		Score:       last.GetScore(),
+		Id:          last.GetId(),
+	}.Encode()
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// projection is the part of each record a search gives back, nil gives all of
+// the metadata and no vector.
+type projection struct {
+	include    map[string]struct{}
+	exclude    map[string]struct{}
+	withVector bool
+}
+
+func projectionHelper(req *coreproto.SearchRequest) *projection {
+	if len(req.GetIncludeFields()) == 0 && len(req.GetExcludeFields()) == 0 && !req.GetWithVector() {
+		return nil
+	}
+	proj := &projection{withVector: req.GetWithVector()}
+	if len(req.GetIncludeFields()) > 0 {
+		proj.include = make(map[string]struct{}, len(req.GetIncludeFields()))
+		for _, field := range req.GetIncludeFields() {
+			proj.include[field] = struct{}{}
+		}
+	}
+	proj.exclude = make(map[string]struct{}, len(req.GetExcludeFields()))
+	for _, field := range req.GetExcludeFields() {
+		proj.exclude[field] = struct{}{}
+	}
+	return proj
+}
+
+// vectors tells the search gives the vectors of its results.
+func (xx *projection) vectors() bool {
+	return xx != nil && xx.withVector
+}
+
+func (xx *projection) keep(field string) bool {
+	if xx.include != nil {
+		if _, ok := xx.include[field]; !ok {
+			return false
+		}
+	}
+	_, drop := xx.exclude[field]
+	return !drop
+}
+
+// metadata cuts the metadata of an index down to the projected fields, the
+// index map is shared so the fields are copied out of it.
+func (xx *projection) metadata(metadata map[string]any) map[string]any {
+	if xx == nil || (xx.include == nil && len(xx.exclude) == 0) {
+		return metadata
+	}
+	out := make(map[string]any, len(metadata))
+	for field, value := range metadata {
+		if xx.keep(field) {
+			out[field] = value
+		}
+	}
+	return out
+}
+
+// fields does the same for metadata read back from the commit log.
+func (xx *projection) fields(metadata *structpb.Struct) *structpb.Struct {
+	if xx == nil || (xx.include == nil && len(xx.exclude) == 0) {
+		return metadata
+	}
+	out := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(metadata.GetFields()))}
+	for field, value := range metadata.GetFields() {
+		if xx.keep(field) {
+			out.Fields[field] = value
+		}
+	}
+	return out
+}
+
+/* vectorsHelper sets the vector of each candidate from its commit log record,
+ * which keeps the vector as it was written where the index may hold it
+ * normalized or compressed. nodeIds maps the candidate ids to the records. */
+func (xx *Core) vectorsHelper(collectionName string, candidates []*coreproto.Candidates, nodeIds map[string]uint64) error {
+	for _, candidate := range candidates {
+		data, err := xx.CommitLog.Get([]byte(fmt.Sprintf(diskRule1, collectionName, nodeIds[candidate.GetId()])))
+		if err != nil {
+			return err
+		}
+		dec := diskproto.Dataset{}
+		if err := proto.Unmarshal(data, &dec); err != nil {
+			return err
+		}
+		candidate.Vector = dec.GetVector()
+	}
+	return nil
+}
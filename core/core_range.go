@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+const (
+	// results a radius search gives at most
+	rangeMaxResults = 10000
+	// first fetch of a radius search on an index without a beam to widen
+	rangeFirstFetch = 64
+)
+
+// rangeIndex widens its own search beam until it has left the radius.
+type rangeIndex interface {
+	SearchRange(ctx context.Context, query gomath.Vector, radius float32, limit uint) (vectorindex.SearchResult, error)
+}
+
+func rangeLimitHelper(topK uint64) uint {
+	if topK == 0 || topK > rangeMaxResults {
+		return rangeMaxResults
+	}
+	return uint(topK)
+}
+
+/* rangeHelper gives up to k candidates within the radius of the request,
+ * closest first, or up to rangeMaxResults with k 0. Hnsw graphs widen their beam, the other kinds search again with a
+ * doubled k until the farthest candidate lies outside the radius. */
+func rangeHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest, k uint64,
+) (vectorindex.SearchResult, error) {
+	if len(req.GetVector()) == 0 || len(req.GetFieldQueries()) > 0 || len(req.GetSparseVector().GetIndices()) > 0 ||
+		req.GetDiversify() != nil {
+		return nil, errors.New(ErrRangeSearch)
+	}
+	radius, limit := req.GetRadius(), rangeLimitHelper(k)
+	if graph, ok := collection.(rangeIndex); ok {
+		return graph.SearchRange(ctx, req.GetVector(), radius, limit)
+	}
+	fetch := min(uint(rangeFirstFetch), limit)
+	for {
+		found, err := searchHelper(ctx, collection, req.GetVector(), fetch, req.GetNprobe())
+		if err != nil {
+			return nil, err
+		}
+		farthest := float32(math.Inf(-1))
+		for _, candidate := range found {
+			farthest = max(farthest, candidate.Score)
+		}
+		if farthest > radius || uint(len(found)) < fetch || fetch == limit {
+			within := make(vectorindex.SearchResult, 0, len(found))
+			for _, candidate := range found {
+				if candidate.Score <= radius {
+					within = append(within, candidate)
+				}
+			}
+			sort.Stable(within)
+			return within, nil
+		}
+		fetch = min(fetch*2, limit)
+	}
+}
@@ -0,0 +1,465 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"google.golang.org/protobuf/proto"
+)
+
+// commit log entries read per lock of the commit log during a rebuild
+const rebuildScanBatch = 1024
+
+/* rebuildIndex serves a collection while AlterCollection builds a shadow graph
+ * with the new config. Searches and writes go to the serving graph, writes are
+ * journaled and replayed on the shadow before the swap. A caller that got the
+ * rebuildIndex before the swap is forwarded to the shadow after it, the graph
+ * is not embedded so nothing reaches the old graph past the swap. */
+type rebuildIndex struct {
+	graph   hnswIndex
+	rebuild *rebuildState
+}
+
+type rebuildChange struct {
+	remove   bool
+	id       uint64
+	vector   gomath.Vector
+	metadata vectorindex.Metadata
+}
+
+type rebuildState struct {
+	// writes hold it shared, the swap holds it exclusive
+	lock        sync.RWMutex
+	journalLock sync.Mutex
+	journal     []rebuildChange
+	next        collectionIndex
+	cancel      context.CancelFunc
+
+	state    atomic.Int32
+	done     atomic.Uint64
+	total    uint64
+	errorMsg string
+}
+
+func (xx *rebuildState) progress() *coreproto.RebuildProgress {
+	progress := &coreproto.RebuildProgress{
+		State: coreproto.RebuildState(xx.state.Load()),
+		Done:  xx.done.Load(),
+		Total: xx.total,
+	}
+	if progress.State == coreproto.RebuildState_Failed {
+		progress.ErrorMessage = xx.errorMsg
+	}
+	return progress
+}
+
+func (xx *rebuildState) fail(err error) {
+	xx.errorMsg = err.Error()
+	xx.state.Store(int32(coreproto.RebuildState_Failed))
+}
+
+func (xx *rebuildState) record(c rebuildChange) {
+	xx.journalLock.Lock()
+	xx.journal = append(xx.journal, c)
+	xx.journalLock.Unlock()
+}
+
+func (xx rebuildIndex) serving() collectionIndex {
+	if next := xx.rebuild.next; next != nil {
+		return next
+	}
+	return xx.graph
+}
+
+func (xx rebuildIndex) Insert(id uint64, vector gomath.Vector, metadata vectorindex.Metadata) error {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	if err := xx.serving().Insert(id, vector, metadata); err != nil {
+		return err
+	}
+	if xx.rebuild.next == nil {
+		xx.rebuild.record(rebuildChange{id: id, vector: vector, metadata: metadata})
+	}
+	return nil
+}
+
+func (xx rebuildIndex) Remove(id uint64) error {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	if err := xx.serving().Remove(id); err != nil {
+		return err
+	}
+	if xx.rebuild.next == nil {
+		xx.rebuild.record(rebuildChange{remove: true, id: id})
+	}
+	return nil
+}
+
+func (xx rebuildIndex) Get(id uint64) (gomath.Vector, error) {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	return xx.serving().Get(id)
+}
+
+func (xx rebuildIndex) Metadata(id uint64) (vectorindex.Metadata, error) {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	return xx.serving().Metadata(id)
+}
+
+func (xx rebuildIndex) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	xx.rebuild.lock.RLock()
+	serving := xx.serving()
+	xx.rebuild.lock.RUnlock()
+	return serving.Search(ctx, query, k)
+}
+
+func (xx rebuildIndex) Len() int {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	return xx.serving().Len()
+}
+
+func (xx rebuildIndex) Dim() uint32 {
+	return xx.graph.Dim()
+}
+
+func (xx rebuildIndex) Distance() string {
+	return xx.graph.Distance()
+}
+
+func (xx rebuildIndex) Commit(w io.Writer) error {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	return xx.serving().Commit(w)
+}
+
+func (xx rebuildIndex) Load(r io.Reader) error {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	return xx.serving().Load(r)
+}
+
+func (xx rebuildIndex) Stats() *coreproto.CollectionInfo {
+	xx.rebuild.lock.RLock()
+	defer xx.rebuild.lock.RUnlock()
+	return xx.serving().Stats()
+}
+
+// alterHnswHelper applies the set fields of req on config, it reports
+// whether a build time field changed.
+func alterHnswHelper(config vectorindex.ProtoConfig, req *coreproto.AlterCollectionRequest) (vectorindex.ProtoConfig, bool, error) {
+	next := config
+	if req.SearchAlgorithm != nil {
+		next.SearchAlgorithm, _ = protoSearchAlgoHelper(req.GetSearchAlgorithm())
+	}
+	if req.M != nil {
+		next.M = int(req.GetM())
+		// derived from m unless they are set too
+		next.MMax = -1
+		next.MMax0 = -1
+		next.LevelMultiplier = -1
+	}
+	if req.LevelMultiplier != nil {
+		next.LevelMultiplier = req.GetLevelMultiplier()
+	}
+	if req.Ef != nil {
+		next.Ef = int(req.GetEf())
+	}
+	if req.EfConstruction != nil {
+		next.EfConstruction = int(req.GetEfConstruction())
+	}
+	if req.MMax != nil {
+		next.MMax = int(req.GetMMax())
+	}
+	if req.MMax0 != nil {
+		next.MMax0 = int(req.GetMMax0())
+	}
+	if req.HeuristicExtendCandidates != nil {
+		next.HeuristicExtendCandidates = req.GetHeuristicExtendCandidates()
+	}
+	if req.HeuristicKeepPruned != nil {
+		next.HeuristicKeepPruned = req.GetHeuristicKeepPruned()
+	}
+
+	if next.MMax == -1 {
+		next.MMax = next.M
+	}
+	if next.MMax0 == -1 {
+		next.MMax0 = 2 * next.M
+	}
+	if next.LevelMultiplier == -1 {
+		next.LevelMultiplier = 1.0 / gomath.Log(float32(next.M))
+	}
+
+	for name, value := range map[string]int{
+		"ef":              next.Ef,
+		"ef_construction": next.EfConstruction,
+		"m":               next.M,
+	} {
+		if value <= 0 {
+			return config, false, fmt.Errorf(ErrInvalidAlter, name)
+		}
+	}
+	if (req.MMax != nil && next.MMax <= 0) || (req.MMax0 != nil && next.MMax0 <= 0) {
+		return config, false, fmt.Errorf(ErrInvalidAlter, "m_max")
+	}
+	if req.LevelMultiplier != nil && next.LevelMultiplier <= 0 {
+		return config, false, fmt.Errorf(ErrInvalidAlter, "level_multiplier")
+	}
+
+	rebuild := next.SearchAlgorithm != config.SearchAlgorithm ||
+		next.LevelMultiplier != config.LevelMultiplier ||
+		next.EfConstruction != config.EfConstruction ||
+		next.M != config.M ||
+		next.MMax != config.MMax ||
+		next.MMax0 != config.MMax0 ||
+		next.HeuristicExtendCandidates != config.HeuristicExtendCandidates ||
+		next.HeuristicKeepPruned != config.HeuristicKeepPruned
+	return next, rebuild, nil
+}
+
+func hnswOptionsHelper(config vectorindex.ProtoConfig) []vectorindex.HnswOption {
+	options := []vectorindex.HnswOption{
+		reverseSearchAlgoHelper(config.SearchAlgorithm),
+		vectorindex.HnswLevelMultiplier(config.LevelMultiplier),
+		vectorindex.HnswEf(config.Ef),
+		vectorindex.HnswEfConstruction(config.EfConstruction),
+		vectorindex.HnswM(config.M),
+		vectorindex.HnswMmax(config.MMax),
+		vectorindex.HnswMmax0(config.MMax0),
+		vectorindex.HnswHeuristicExtendCandidates(config.HeuristicExtendCandidates),
+		vectorindex.HnswHeuristicKeepPruned(config.HeuristicKeepPruned),
+	}
+	if config.Seeded {
+		options = append(options, vectorindex.HnswSeed(config.Seed))
+	}
+	return options
+}
+
+// archiveHnswHelper writes the hnsw config of the collection archive.
+func (xx *Core) archiveHnswHelper(collectionName string, config vectorindex.ProtoConfig) error {
+	key := []byte(fmt.Sprintf(diskRule0, collectionName))
+	raw, err := xx.CommitLog.Get(key)
+	if err != nil {
+		return err
+	}
+	archive := diskproto.Collection{}
+	if err := proto.Unmarshal(raw, &archive); err != nil {
+		return err
+	}
+	archive.SearchAlgorithm = config.SearchAlgorithm
+	archive.LevelMultiplier = config.LevelMultiplier
+	archive.Ef = int32(config.Ef)
+	archive.EfConstruction = int32(config.EfConstruction)
+	archive.M = int32(config.M)
+	archive.MMax = int32(config.MMax)
+	archive.MMax0 = int32(config.MMax0)
+	archive.HeuristicExtendCandidates = config.HeuristicExtendCandidates
+	archive.HeuristicKeepPruned = config.HeuristicKeepPruned
+	archive.HnswSeed = nil
+	if config.Seeded {
+		archive.HnswSeed = &config.Seed
+	}
+	raw, err = proto.Marshal(&archive)
+	if err != nil {
+		return err
+	}
+	return xx.CommitLog.Put(key, raw)
+}
+
+// startRebuildHelper puts a rebuildIndex in front of the collection graph and
+// builds the shadow graph in the background.
+func (xx *Core) startRebuildHelper(collectionName string, graph hnswIndex, config vectorindex.ProtoConfig) *rebuildState {
+	ctx, cancel := context.WithCancel(context.Background())
+	state := &rebuildState{
+		cancel: cancel,
+		total:  uint64(graph.Len()),
+	}
+	state.state.Store(int32(coreproto.RebuildState_Running))
+	serving := rebuildIndex{graph: graph, rebuild: state}
+	xx.rebuilds.Set(collectionName, state)
+	xx.DataStore.Set(collectionName, serving)
+
+	go func() {
+		defer cancel()
+		if err := xx.rebuildHelper(ctx, collectionName, serving, config); err != nil {
+			log.Warn().Err(err).Str("collection", collectionName).Msg("index rebuild failed")
+			state.lock.Lock()
+			state.next = graph
+			if current, ok := xx.DataStore.Get(collectionName).(rebuildIndex); ok && current == serving {
+				xx.DataStore.Set(collectionName, graph)
+			}
+			state.lock.Unlock()
+			state.fail(err)
+			return
+		}
+		log.Info().Str("collection", collectionName).Uint64("size", state.done.Load()).Msg("index rebuild swapped in")
+	}()
+	return state
+}
+
+func (xx *Core) rebuildHelper(ctx context.Context, collectionName string, serving rebuildIndex, config vectorindex.ProtoConfig) error {
+	state := serving.rebuild
+	shadow := hnswIndex{vectorindex.NewHnsw(uint(serving.Dim()),
+		reversesingleprotoDistHelper(serving.Distance()), hnswOptionsHelper(config)...)}
+
+	feed := make(chan vectorindex.BuildItem, rebuildScanBatch)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(feed)
+		scanErr <- xx.scanDatasetHelper(ctx, collectionName, feed)
+	}()
+	options := []vectorindex.BuildOption{
+		vectorindex.BuildProgressEvery(rebuildScanBatch),
+		vectorindex.BuildProgress(func(done, total int) {
+			state.done.Store(uint64(done))
+		}),
+	}
+	if config.Seeded {
+		// parallel inserts would make the seeded graph depend on scheduling
+		options = append(options, vectorindex.BuildWorkers(1))
+	}
+	_, err := shadow.Build(ctx, feed, options...)
+	if err != nil {
+		return err
+	}
+	if err := <-scanErr; err != nil {
+		return err
+	}
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if current, ok := xx.DataStore.Get(collectionName).(rebuildIndex); !ok || current != serving {
+		return errors.New("collection was released during the rebuild")
+	}
+	for _, c := range state.journal {
+		var err error
+		if c.remove {
+			err = shadow.Remove(c.id)
+		} else {
+			err = shadow.Insert(c.id, c.vector, c.metadata)
+		}
+		if err != nil && !errors.Is(err, vectorindex.ItemNotFoundError) && !errors.Is(err, vectorindex.ItemAlreadyExistsError) {
+			return err
+		}
+	}
+	if err := reconcileHnswHelper(serving.graph, shadow); err != nil {
+		return err
+	}
+	state.next = shadow
+	state.journal = nil
+	state.done.Store(uint64(shadow.Len()))
+	xx.DataStore.Set(collectionName, shadow)
+	state.state.Store(int32(coreproto.RebuildState_Swapped))
+
+	if err := xx.archiveHnswHelper(collectionName, shadow.Config()); err != nil {
+		log.Warn().Err(err).Str("collection", collectionName).Msg("rebuilt index config is not archived")
+	}
+	if err := xx.createSnapshotHelper(collectionName); err != nil {
+		log.Warn().Err(err).Str("collection", collectionName).Msg("rebuilt index snapshot failed")
+	}
+	return nil
+}
+
+// reconcileHnswHelper makes shadow hold the vertices of serving. The commit
+// log entry of a write lands after its index write, a write racing the scan
+// can be missing from the commit log the shadow was built from.
+func reconcileHnswHelper(serving, shadow hnswIndex) error {
+	live := make(map[uint64]struct{}, serving.Len())
+	for _, id := range serving.Ids() {
+		live[id] = struct{}{}
+		if _, err := shadow.Get(id); err == nil {
+			continue
+		}
+		vector, err := serving.Get(id)
+		if err != nil {
+			continue
+		}
+		metadata, err := serving.Metadata(id)
+		if err != nil {
+			continue
+		}
+		if err := shadow.Insert(id, vector, metadata); err != nil && !errors.Is(err, vectorindex.ItemAlreadyExistsError) {
+			return err
+		}
+	}
+	for _, id := range shadow.Ids() {
+		if _, ok := live[id]; !ok {
+			shadow.Remove(id)
+		}
+	}
+	return nil
+}
+
+/* scanDatasetHelper streams the vectors of a collection from the commit log,
+ * the commit log is locked for one batch at a time so writes go on. The scan
+ * ends at the last key the collection had when it started, later writes reach
+ * the shadow through the journal and the reconcile instead of keeping the
+ * scan going. */
+func (xx *Core) scanDatasetHelper(ctx context.Context, collectionName string, feed chan<- vectorindex.BuildItem) error {
+	prefix := []byte(fmt.Sprintf(diskRule2, collectionName))
+	var end []byte
+	xx.CommitLog.DescendLessOrEqual(append(bytes.Clone(prefix), 0xff), func(k []byte, v []byte) (bool, error) {
+		if bytes.HasPrefix(k, prefix) {
+			end = bytes.Clone(k)
+		}
+		return false, nil
+	})
+	if end == nil {
+		return nil
+	}
+	from := prefix
+	for {
+		batch := make([]vectorindex.BuildItem, 0, rebuildScanBatch)
+		var last []byte
+		var err error
+		more := false
+		xx.CommitLog.AscendGreaterOrEqual(from, func(k []byte, v []byte) (bool, error) {
+			if !bytes.HasPrefix(k, prefix) || bytes.Compare(k, end) > 0 {
+				return false, nil
+			}
+			last = append(last[:0], k...)
+			// the archive and collections named with this prefix share the range
+			if _, parseErr := strconv.ParseUint(string(k[len(prefix):]), 10, 64); parseErr != nil {
+				return true, nil
+			}
+			dataset := diskproto.Dataset{}
+			if err = proto.Unmarshal(v, &dataset); err != nil {
+				return false, nil
+			}
+			batch = append(batch, vectorindex.BuildItem{
+				Id:       dataset.GetCollectionUniqueId(),
+				Vector:   dataset.GetVector(),
+				Metadata: dataset.GetMetadata().AsMap(),
+			})
+			more = len(batch) == rebuildScanBatch
+			return !more, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range batch {
+			select {
+			case feed <- item:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if !more {
+			return nil
+		}
+		from = append(last, 0)
+	}
+}
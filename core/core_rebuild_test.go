@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitRebuild polls the rebuild of a collection until it is no longer running.
+func waitRebuild(t *testing.T, c *Core, collectionName string) *coreproto.RebuildProgress {
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if progress := c.rebuilds.Get(collectionName).progress(); progress.GetState() != coreproto.RebuildState_Running {
+			return progress
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("rebuild did not finish")
+	return nil
+}
+
+// graphIds gives the record ids held by the graph of a collection.
+func graphIds(t *testing.T, graph hnswIndex) map[string]struct{} {
+	ids := make(map[string]struct{}, graph.Len())
+	for _, id := range graph.Ids() {
+		metadata, err := graph.Metadata(id)
+		assert.Nil(t, err)
+		ids[metadata["_id"].(string)] = struct{}{}
+	}
+	return ids
+}
+
+func TestRebuildKeepsWritesDuringBuild(t *testing.T) {
+	c := newTestCore(t)
+	testCollection(t, c, &coreproto.CollectionSpec{
+		CollectionName: "rebuild", VectorDimension: 16, Distance: coreproto.Distance_Euclidean,
+		IndexType: coreproto.IndexType_Hnsw,
+	})
+	live := make(map[string]struct{})
+	for i := 0; i < 3000; i++ {
+		id := fmt.Sprint(i)
+		testInsert(t, c, "rebuild", id, gomath.RandomUniformVector(16), nil)
+		live[id] = struct{}{}
+	}
+
+	m := int32(8)
+	res, err := c.AlterCollection(context.Background(), &coreproto.AlterCollectionRequest{CollectionName: "rebuild", M: &m})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	assert.Equal(t, coreproto.RebuildState_Running, res.GetRebuild().GetState())
+
+	// a fixed run of writes, the early ones land while the scan is going and
+	// have to reach the shadow through the scan, the journal or the reconcile
+	for i := 0; i < 300; i++ {
+		id := fmt.Sprintf("new-%d", i)
+		testInsert(t, c, "rebuild", id, gomath.RandomUniformVector(16), nil)
+		live[id] = struct{}{}
+		removed := fmt.Sprint(i)
+		dres, err := c.Delete(context.Background(), &coreproto.DatasetChange{Id: removed, CollectionName: "rebuild"})
+		assert.Nil(t, err)
+		assert.True(t, dres.GetStatus())
+		delete(live, removed)
+	}
+
+	progress := waitRebuild(t, c, "rebuild")
+	assert.Equal(t, coreproto.RebuildState_Swapped, progress.GetState())
+	graph, ok := c.DataStore.Get("rebuild").(hnswIndex)
+	assert.True(t, ok)
+	assert.Equal(t, 8, graph.Config().M)
+	assert.Greater(t, progress.GetDone(), uint64(0))
+	assert.Equal(t, live, graphIds(t, graph))
+
+	// the swapped graph takes writes without the rebuild in front of it
+	testInsert(t, c, "rebuild", "after", gomath.RandomUniformVector(16), nil)
+	live["after"] = struct{}{}
+	assert.Equal(t, live, graphIds(t, graph))
+}
+
+func TestRebuildJournalReplay(t *testing.T) {
+	c := newTestCore(t)
+	testCollection(t, c, &coreproto.CollectionSpec{
+		CollectionName: "journal", VectorDimension: 4, Distance: coreproto.Distance_Euclidean,
+		IndexType: coreproto.IndexType_Hnsw,
+	})
+	for i := 0; i < 100; i++ {
+		testInsert(t, c, "journal", fmt.Sprint(i), gomath.RandomUniformVector(4), nil)
+	}
+	graph := c.DataStore.Get("journal").(hnswIndex)
+	config := graph.Config()
+	config.M = 8
+	state := &rebuildState{cancel: func() {}, total: uint64(graph.Len())}
+	state.state.Store(int32(coreproto.RebuildState_Running))
+	serving := rebuildIndex{graph: graph, rebuild: state}
+	c.rebuilds.Set("journal", state)
+	c.DataStore.Set("journal", serving)
+
+	// index writes the commit log does not hold, only the journal brings
+	// them to the shadow
+	assert.Nil(t, serving.Insert(1000, gomath.RandomUniformVector(4), vectorindex.Metadata{"_id": "1000"}))
+	assert.Nil(t, serving.Remove(graph.Ids()[0]))
+	assert.Len(t, state.journal, 2)
+
+	assert.Nil(t, c.rebuildHelper(context.Background(), "journal", serving, config))
+	assert.Nil(t, state.journal)
+	assert.Equal(t, coreproto.RebuildState_Swapped, state.progress().GetState())
+	shadow := c.DataStore.Get("journal").(hnswIndex)
+	assert.Equal(t, graphIds(t, graph), graphIds(t, shadow))
+	assert.Equal(t, 8, shadow.Config().M)
+
+	// a caller still holding the rebuild index is forwarded to the shadow
+	assert.Nil(t, serving.Insert(1001, gomath.RandomUniformVector(4), vectorindex.Metadata{"_id": "1001"}))
+	_, err := shadow.Get(1001)
+	assert.Nil(t, err)
+	assert.Empty(t, state.journal)
+	assert.Equal(t, shadow.Len(), serving.Len())
+	assert.Equal(t, int32(8), serving.Stats().GetCollectionConfig().GetM())
+}
+
+func TestReconcileHnswHelper(t *testing.T) {
+	serving := hnswIndex{vectorindex.NewHnsw(4, distance.NewEuclidean())}
+	shadow := hnswIndex{vectorindex.NewHnsw(4, distance.NewEuclidean())}
+	for id := uint64(0); id < 10; id++ {
+		assert.Nil(t, serving.Insert(id, gomath.RandomUniformVector(4), vectorindex.Metadata{"_id": fmt.Sprint(id)}))
+	}
+	for id := uint64(5); id < 15; id++ {
+		assert.Nil(t, shadow.Insert(id, gomath.RandomUniformVector(4), vectorindex.Metadata{"_id": fmt.Sprint(id)}))
+	}
+	assert.Nil(t, reconcileHnswHelper(serving, shadow))
+	assert.Equal(t, graphIds(t, serving), graphIds(t, shadow))
+	vector, err := serving.Get(2)
+	assert.Nil(t, err)
+	reconciled, err := shadow.Get(2)
+	assert.Nil(t, err)
+	assert.Equal(t, vector, reconciled)
+}
+
+func TestRebuildFailureFallsBack(t *testing.T) {
+	c := newTestCore(t)
+	testCollection(t, c, &coreproto.CollectionSpec{
+		CollectionName: "broken", VectorDimension: 4, Distance: coreproto.Distance_Euclidean,
+		IndexType: coreproto.IndexType_Hnsw,
+	})
+	for i := 0; i < 100; i++ {
+		testInsert(t, c, "broken", fmt.Sprint(i), gomath.RandomUniformVector(4), nil)
+	}
+	graph := c.DataStore.Get("broken").(hnswIndex)
+	// a record the scan cannot read fails the rebuild
+	assert.Nil(t, c.CommitLog.Put([]byte(fmt.Sprintf(diskRule1, "broken", 1<<40)), []byte{0xff, 0xff}))
+
+	m := int32(8)
+	res, err := c.AlterCollection(context.Background(), &coreproto.AlterCollectionRequest{CollectionName: "broken", M: &m})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+
+	progress := waitRebuild(t, c, "broken")
+	assert.Equal(t, coreproto.RebuildState_Failed, progress.GetState())
+	assert.NotEmpty(t, progress.GetErrorMessage())
+	current, ok := c.DataStore.Get("broken").(hnswIndex)
+	assert.True(t, ok)
+	assert.Equal(t, graph, current)
+	assert.Equal(t, 16, current.Config().M)
+
+	// the old graph serves again and a new alter may start
+	testInsert(t, c, "broken", "after", gomath.RandomUniformVector(4), nil)
+	_, ok = graphIds(t, current)["after"]
+	assert.True(t, ok)
+}
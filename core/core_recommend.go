@@ -0,0 +1,248 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+// a filtered recommend search drops candidates afterwards, search wider first
+const recommendOversample = 3
+
+func (xx *Core) Recommend(ctx context.Context, req *coreproto.RecommendRequest) (
+	*coreproto.SearchResponse, error) {
+	type reply struct {
+		Result *coreproto.SearchResponse
+		Error  error
+	}
+	c := make(chan reply, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c <- reply{
+					Error: fmt.Errorf(panicr, r),
+				}
+			}
+		}()
+		failFn := func(errMsg string) reply {
+			return reply{
+				Result: &coreproto.SearchResponse{
+					Status: false,
+					Error:  errorWrap(errMsg),
+				},
+			}
+		}
+
+		err := collectionStatusHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		index := xx.DataStore.Get(req.GetCollectionName())
+		if _, ok := index.(fieldsIndex); ok {
+			c <- failFn(fmt.Sprintf(ErrRecommendFields, req.GetCollectionName()))
+			return
+		}
+		ctx, cancel := xx.queryContextHelper(ctx, req.GetCollectionName())
+		defer cancel()
+		candidates, err := recommendHelper(ctx, index, req)
+		if err != nil {
+			if cerr := contextErrorHelper(err); cerr != nil {
+				c <- reply{Error: cerr}
+				return
+			}
+			c <- failFn(err.Error())
+			return
+		}
+		// the candidates carry their final scores
+		scores := &searchScores{}
+		resultSet := make([]*coreproto.Candidates, 0, len(candidates))
+		for _, candidate := range candidates {
+			n, err := searchCandidateHelper(index, candidate, scores, nil)
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			resultSet = append(resultSet, n)
+		}
+		c <- reply{
+			Result: &coreproto.SearchResponse{
+				Status:     true,
+				Candidates: resultSet,
+			},
+		}
+	}()
+	res := <-c
+	return res.Result, res.Error
+}
+
+/* recommendExamplesHelper resolves the examples to vectors, ids are looked up
+ * in the collection and added to exclude. Cosine collections compare
+ * normalized vectors, raw vectors are normalized to match. */
+func recommendExamplesHelper(collectionName string, collection collectionIndex, ids []string,
+	vectors []*coreproto.FieldVector, exclude map[uint64]struct{}) ([]gomath.Vector, error) {
+	out := make([]gomath.Vector, 0, len(ids)+len(vectors))
+	for _, id := range ids {
+		getId := indexdb.indexes[collectionName].PureSearch(map[string]string{"_id": id})
+		if len(getId) == 0 {
+			return nil, fmt.Errorf(ErrRecommendRecord, id)
+		}
+		vector, err := collection.Get(getId[0])
+		if err != nil {
+			return nil, err
+		}
+		exclude[getId[0]] = struct{}{}
+		out = append(out, vector)
+	}
+	for _, raw := range vectors {
+		if uint32(len(raw.GetVector())) != collection.Dim() {
+			return nil, fmt.Errorf(ErrRecommendDim, len(raw.GetVector()), collection.Dim())
+		}
+		vector := gomath.Vector(raw.GetVector())
+		if collection.Distance() == COSINE {
+			vector = vectorindex.Normalize(vector)
+		}
+		out = append(out, vector)
+	}
+	return out, nil
+}
+
+func meanVectorHelper(vectors []gomath.Vector) gomath.Vector {
+	out := make(gomath.Vector, len(vectors[0]))
+	for _, vector := range vectors {
+		for i, v := range vector {
+			out[i] += v / float32(len(vectors))
+		}
+	}
+	return out
+}
+
+/* recommendHelper gives the topK results of a recommend search with their
+ * 0-100 scores, or below 0 for best score results closer to a negative
+ * example. */
+func recommendHelper(ctx context.Context, collection collectionIndex, req *coreproto.RecommendRequest,
+) (vectorindex.SearchResult, error) {
+	exclude := make(map[uint64]struct{})
+	positive, err := recommendExamplesHelper(req.GetCollectionName(), collection, req.GetPositive(), req.GetPositiveVectors(), exclude)
+	if err != nil {
+		return nil, err
+	}
+	if len(positive) == 0 {
+		return nil, errors.New(ErrRecommendPositive)
+	}
+	negative, err := recommendExamplesHelper(req.GetCollectionName(), collection, req.GetNegative(), req.GetNegativeVectors(), exclude)
+	if err != nil {
+		return nil, err
+	}
+	var allow map[uint64]struct{}
+	if len(req.GetFilter()) > 0 {
+		matched := indexdb.indexes[req.GetCollectionName()].PureSearch(req.GetFilter())
+		allow = make(map[uint64]struct{}, len(matched))
+		for _, id := range matched {
+			allow[id] = struct{}{}
+		}
+	}
+	keep := func(id uint64) bool {
+		if _, ok := exclude[id]; ok {
+			return false
+		}
+		if allow == nil {
+			return true
+		}
+		_, ok := allow[id]
+		return ok
+	}
+	k := uint(req.GetTopK())
+
+	if req.GetStrategy() == coreproto.RecommendStrategy_AverageVector {
+		query := meanVectorHelper(positive)
+		if len(negative) > 0 {
+			mean := meanVectorHelper(negative)
+			for i := range query {
+				query[i] += query[i] - mean[i]
+			}
+		}
+		candidates, err := recommendSearchHelper(ctx, collection, query, k, len(exclude), allow != nil, keep)
+		if err != nil {
+			return nil, err
+		}
+		for i := range candidates {
+			candidates[i].Score = scoreHelper(candidates[i].Score, collection.Distance(), collection.Dim())
+		}
+		return candidates, nil
+	}
+
+	space := reversesingleprotoDistHelper(collection.Distance())
+	bestScore := func(vector gomath.Vector, examples []gomath.Vector) float32 {
+		best := float32(0)
+		for _, example := range examples {
+			best = max(best, scoreHelper(space.Distance(vector, example), collection.Distance(), collection.Dim()))
+		}
+		return best
+	}
+	seen := make(map[uint64]struct{})
+	var candidates vectorindex.SearchResult
+	for _, example := range positive {
+		found, err := recommendSearchHelper(ctx, collection, example, k, len(exclude), allow != nil, keep)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range found {
+			if _, ok := seen[candidate.Id]; ok {
+				continue
+			}
+			seen[candidate.Id] = struct{}{}
+			vector, err := collection.Get(candidate.Id)
+			if err != nil {
+				return nil, err
+			}
+			candidate.Score = bestScore(vector, positive)
+			if len(negative) > 0 {
+				if neg := bestScore(vector, negative); neg > candidate.Score {
+					candidate.Score = -neg
+				}
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > int(k) {
+		candidates = candidates[:k]
+	}
+	return candidates, nil
+}
+
+// recommendSearchHelper gives k candidates that pass keep, a flat collection
+// scans with keep and the other kinds search wider and drop the rest.
+func recommendSearchHelper(ctx context.Context, collection collectionIndex, query gomath.Vector, k uint,
+	excluded int, filtered bool, keep func(id uint64) bool) (vectorindex.SearchResult, error) {
+	if scan, ok := collection.(flatIndex); ok {
+		return scan.SearchFilter(ctx, query, k, keep)
+	}
+	fetchK := k + uint(excluded)
+	if filtered {
+		fetchK *= recommendOversample
+	}
+	found, err := searchHelper(ctx, collection, query, fetchK, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := make(vectorindex.SearchResult, 0, k)
+	for _, candidate := range found {
+		if uint(len(out)) == k {
+			break
+		}
+		if keep(candidate.Id) {
+			out = append(out, candidate)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRecommendCore holds an hnsw collection of 30 records, record i lies at
+// (i, 0) and is tagged odd or even.
+func newRecommendCore(t *testing.T) *Core {
+	c := newTestCore(t)
+	testCollection(t, c, &coreproto.CollectionSpec{
+		CollectionName: "recommend", VectorDimension: 2, Distance: coreproto.Distance_Euclidean,
+		IndexType: coreproto.IndexType_Hnsw,
+	})
+	for i := 0; i < 30; i++ {
+		testInsert(t, c, "recommend", fmt.Sprint(i), []float32{float32(i), 0}, map[string]any{"odd": fmt.Sprint(i%2 == 1)})
+	}
+	return c
+}
+
+func recommend(t *testing.T, c *Core, req *coreproto.RecommendRequest) []*coreproto.Candidates {
+	req.CollectionName = "recommend"
+	res, err := c.Recommend(context.Background(), req)
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	return res.GetCandidates()
+}
+
+func recommendIds(candidates []*coreproto.Candidates) []string {
+	ids := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.GetId())
+	}
+	return ids
+}
+
+func TestRecommendAverageVector(t *testing.T) {
+	c := newRecommendCore(t)
+
+	// 2 * mean(positive) - mean(negative) = 2 * (4, 0) - (2, 0) = (6, 0)
+	found := recommend(t, c, &coreproto.RecommendRequest{
+		PositiveVectors: []*coreproto.FieldVector{{Vector: []float32{3, 0}}, {Vector: []float32{5, 0}}},
+		NegativeVectors: []*coreproto.FieldVector{{Vector: []float32{2, 0}}},
+		TopK:            1,
+	})
+	assert.Equal(t, []string{"6"}, recommendIds(found))
+	assert.InDelta(t, 100, found[0].GetScore(), 1e-4)
+
+	// the mean of the positives alone
+	found = recommend(t, c, &coreproto.RecommendRequest{
+		PositiveVectors: []*coreproto.FieldVector{{Vector: []float32{3, 0}}, {Vector: []float32{5, 0}}},
+		TopK:            1,
+	})
+	assert.Equal(t, []string{"4"}, recommendIds(found))
+}
+
+func TestRecommendBestScore(t *testing.T) {
+	c := newRecommendCore(t)
+
+	// every result is closer to the positive example than to the negative
+	// one until 5, the rest scores minus its negative match
+	found := recommend(t, c, &coreproto.RecommendRequest{
+		PositiveVectors: []*coreproto.FieldVector{{Vector: []float32{0, 0}}},
+		NegativeVectors: []*coreproto.FieldVector{{Vector: []float32{9, 0}}},
+		Strategy:        coreproto.RecommendStrategy_BestScore,
+		TopK:            10,
+	})
+	assert.Len(t, found, 10)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, recommendIds(found[:5]))
+	assert.InDelta(t, 100, found[0].GetScore(), 1e-4)
+	for i, candidate := range found {
+		if i < 5 {
+			assert.Greater(t, candidate.GetScore(), float32(0))
+			continue
+		}
+		assert.Less(t, candidate.GetScore(), float32(0))
+		assert.LessOrEqual(t, candidate.GetScore(), found[i-1].GetScore())
+	}
+	// 9 is the negative example itself
+	assert.Equal(t, "9", found[9].GetId())
+	assert.InDelta(t, -100, found[9].GetScore(), 1e-4)
+}
+
+func TestRecommendExcludesExamples(t *testing.T) {
+	c := newRecommendCore(t)
+	for _, strategy := range []coreproto.RecommendStrategy{coreproto.RecommendStrategy_AverageVector, coreproto.RecommendStrategy_BestScore} {
+		found := recommend(t, c, &coreproto.RecommendRequest{
+			Positive: []string{"10", "11"},
+			Negative: []string{"12"},
+			Strategy: strategy,
+			TopK:     5,
+		})
+		assert.Len(t, found, 5, strategy.String())
+		for _, id := range []string{"10", "11", "12"} {
+			assert.NotContains(t, recommendIds(found), id, strategy.String())
+		}
+	}
+}
+
+func TestRecommendFilterOversamples(t *testing.T) {
+	c := newRecommendCore(t)
+
+	// the hnsw search is widened for the filter, a search of topK plus the
+	// examples would reach 3 only and miss the third odd record
+	found := recommend(t, c, &coreproto.RecommendRequest{
+		Positive: []string{"0"},
+		TopK:     3,
+		Filter:   map[string]string{"odd": "true"},
+	})
+	assert.Equal(t, []string{"1", "3", "5"}, recommendIds(found))
+
+	found = recommend(t, c, &coreproto.RecommendRequest{
+		Positive: []string{"0"},
+		Strategy: coreproto.RecommendStrategy_BestScore,
+		TopK:     3,
+		Filter:   map[string]string{"odd": "false"},
+	})
+	assert.Equal(t, []string{"2", "4", "6"}, recommendIds(found))
+}
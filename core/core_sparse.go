@@ -0,0 +1,202 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sjy-dv/nnv/core/sparse"
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+)
+
+/* searchScores are the scores of a search that ranks on its own terms, field
+ * queries, sparse and text searches. The score of such a candidate is final, the
+ * handlers take it as it is instead of turning a distance into a score, and
+ * fill in the parts it was fused from. */
+type searchScores struct {
+	fields map[uint64]map[string]float32
+	sparse map[uint64]float32
+	dense  map[uint64]float32
+	text   map[uint64]float32
+}
+
+func (xx *searchScores) fill(n *coreproto.Candidates, candidate vectorindex.SearchResultItem) {
+	n.Score = candidate.Score
+	n.FieldScores = xx.fields[candidate.Id]
+	n.SparseScore = xx.sparse[candidate.Id]
+	n.DenseScore = xx.dense[candidate.Id]
+	n.TextScore = xx.text[candidate.Id]
+}
+
+func protoSparseHelper(vector *coreproto.SparseVector) (sparse.Vector, error) {
+	out := sparse.Vector{Indices: vector.GetIndices(), Values: vector.GetValues()}
+	return out, out.Validate()
+}
+
+// loadSparseHelper opens the sparse vectors of a collection, a collection
+// committed without any has no snapshot.
+func (xx *Core) loadSparseHelper(collectionName string) error {
+	store := sparse.NewIndex()
+	data, err := os.ReadFile(fmt.Sprintf(sparseRule, collectionName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := store.Load(bytes.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	xx.sparseStores.Set(collectionName, store)
+	return nil
+}
+
+func (xx *Core) commitSparseHelper(collectionName string) error {
+	store := xx.sparseStores.Get(collectionName)
+	if store == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := store.Commit(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf(sparseRule, collectionName), buf.Bytes(), 0644)
+}
+
+// candidatesHelper runs the dense part of a search, the field queries or the
+// plain vector, and the sparse part when the search has a sparse vector.
+func (xx *Core) candidatesHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest, k uint,
+) (vectorindex.SearchResult, *searchScores, error) {
+	if len(req.GetSparseVector().GetIndices()) > 0 {
+		return xx.sparseCandidatesHelper(ctx, collection, req, k)
+	}
+	return denseCandidatesHelper(ctx, collection, req, k)
+}
+
+func denseCandidatesHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest, k uint,
+) (vectorindex.SearchResult, *searchScores, error) {
+	if len(req.GetFieldQueries()) > 0 {
+		return fieldCandidatesHelper(ctx, collection, req, k)
+	}
+	candidates, err := searchHelper(ctx, collection, req.GetVector(), k, req.GetNprobe())
+	return candidates, nil, err
+}
+
+/* sparseCandidatesHelper ranks by the dot product with the sparse vector, or
+ * fuses it with the dense ranking when the search has a dense part as well.
+ * Each dense candidate gets its exact sparse score, a sparse candidate the
+ * dense search missed counts 0 dense. The weighted sum scales the sparse
+ * scores to 0-100 by the best sparse match. */
+func (xx *Core) sparseCandidatesHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest, k uint,
+) (vectorindex.SearchResult, *searchScores, error) {
+	query, err := protoSparseHelper(req.GetSparseVector())
+	if err != nil {
+		return nil, nil, err
+	}
+	store := xx.sparseStores.Get(req.GetCollectionName())
+	if store == nil {
+		return nil, nil, fmt.Errorf(ErrCollectionNotLoad, req.GetCollectionName())
+	}
+	hits, err := store.Search(ctx, query, int(k))
+	if err != nil {
+		return nil, nil, err
+	}
+	scores := &searchScores{sparse: make(map[uint64]float32, len(hits))}
+
+	if len(req.GetVector()) == 0 && len(req.GetFieldQueries()) == 0 {
+		candidates := make(vectorindex.SearchResult, 0, len(hits))
+		for _, hit := range hits {
+			metadata, err := collection.Metadata(hit.Id)
+			if err != nil {
+				continue
+			}
+			candidates = append(candidates, vectorindex.SearchResultItem{Id: hit.Id, Metadata: metadata, Score: hit.Score})
+			scores.sparse[hit.Id] = hit.Score
+		}
+		return candidates, scores, nil
+	}
+
+	denseWeight, sparseWeight := req.GetDenseWeight(), req.GetSparseWeight()
+	if denseWeight < 0 || sparseWeight < 0 {
+		return nil, nil, errors.New(ErrHybridWeight)
+	}
+	if denseWeight+sparseWeight == 0 {
+		denseWeight, sparseWeight = 1, 1
+	}
+	denseWeight, sparseWeight = denseWeight/(denseWeight+sparseWeight), sparseWeight/(denseWeight+sparseWeight)
+	rrfK := req.GetRrfK()
+	if rrfK == 0 {
+		rrfK = defaultRrfK
+	}
+
+	dense, denseScores, err := denseCandidatesHelper(ctx, collection, req, k)
+	if err != nil {
+		return nil, nil, err
+	}
+	if denseScores != nil {
+		scores.fields = denseScores.fields
+	}
+	scores.dense = make(map[uint64]float32, len(dense))
+	denseRanks := make(map[uint64]int, len(dense))
+	fused := make(vectorindex.SearchResult, 0, len(dense)+len(hits))
+	for rank, candidate := range dense {
+		if denseScores != nil {
+			scores.dense[candidate.Id] = candidate.Score
+		} else {
+			scores.dense[candidate.Id] = scoreHelper(candidate.Score, collection.Distance(), collection.Dim())
+		}
+		scores.sparse[candidate.Id] = store.Score(candidate.Id, query)
+		denseRanks[candidate.Id] = rank + 1
+		fused = append(fused, candidate)
+	}
+	sparseRanks := make(map[uint64]int, len(hits))
+	for rank, hit := range hits {
+		sparseRanks[hit.Id] = rank + 1
+		if _, ok := denseRanks[hit.Id]; ok {
+			continue
+		}
+		metadata, err := collection.Metadata(hit.Id)
+		if err != nil {
+			continue
+		}
+		scores.sparse[hit.Id] = hit.Score
+		fused = append(fused, vectorindex.SearchResultItem{Id: hit.Id, Metadata: metadata})
+	}
+
+	// the best sparse match is the first hit
+	var best float32
+	if len(hits) > 0 {
+		best = hits[0].Score
+	}
+	for i := range fused {
+		id := fused[i].Id
+		switch req.GetFusion() {
+		case coreproto.Fusion_Rrf:
+			var score float32
+			if rank, ok := denseRanks[id]; ok {
+				score += denseWeight / float32(int(rrfK)+rank)
+			}
+			if rank, ok := sparseRanks[id]; ok {
+				score += sparseWeight / float32(int(rrfK)+rank)
+			}
+			fused[i].Score = score
+		default:
+			var sparseScore float32
+			if best > 0 {
+				sparseScore = scores.sparse[id] / best * 100
+			}
+			fused[i].Score = denseWeight*scores.dense[id] + sparseWeight*sparseScore
+		}
+	}
+	return rankFusedHelper(fused, k), scores, nil
+}
+
+func diskSparseHelper(vector *coreproto.SparseVector) *diskproto.SparseVector {
+	if len(vector.GetIndices()) == 0 {
+		return nil
+	}
+	return &diskproto.SparseVector{Indices: vector.GetIndices(), Values: vector.GetValues()}
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestCore opens a core in a temporary directory, the data_dir of core is
+// relative to the working directory.
+func newTestCore(t *testing.T) *Core {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	NewStateManager()
+	NewIndexDB()
+	assert.Nil(t, NewIdGenerator())
+	c, err := NewCore()
+	assert.Nil(t, err)
+	t.Cleanup(func() { c.CommitLog.Close() })
+	return c
+}
+
+func testCollection(t *testing.T, c *Core, spec *coreproto.CollectionSpec) {
+	if spec.CollectionConfig == nil {
+		spec.CollectionConfig = &coreproto.HnswConfig{}
+	}
+	res, err := c.CreateCollection(context.Background(), spec)
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+}
+
+func testInsert(t *testing.T, c *Core, collectionName, id string, vector []float32, metadata map[string]any) {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadata["_id"] = id
+	md, err := structpb.NewStruct(metadata)
+	assert.Nil(t, err)
+	res, err := c.Insert(context.Background(), &coreproto.DatasetChange{
+		Id: id, CollectionName: collectionName, Vector: vector, Metadata: md,
+	})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+}
+
+func TestHybridSearchKeepsAnnOrder(t *testing.T) {
+	c := newTestCore(t)
+	testCollection(t, c, &coreproto.CollectionSpec{
+		CollectionName: "hybrid", VectorDimension: 2, Distance: coreproto.Distance_Euclidean,
+		IndexType: coreproto.IndexType_Flat,
+	})
+	// records are written farthest first, so the matching ids run against the
+	// distance order
+	for i := 0; i < 20; i++ {
+		testInsert(t, c, "hybrid", fmt.Sprint(i), []float32{float32(20 - i), 0}, map[string]any{"even": fmt.Sprint(i%2 == 0)})
+	}
+	res, err := c.HybridSearch(context.Background(), &coreproto.SearchRequest{
+		CollectionName: "hybrid", Vector: []float32{0, 0}, TopK: 5, Filter: map[string]string{"even": "true"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	ids := make([]string, 0, len(res.GetCandidates()))
+	for _, candidate := range res.GetCandidates() {
+		ids = append(ids, candidate.GetId())
+	}
+	assert.Equal(t, []string{"18", "16", "14", "12"}, ids)
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/nnv/pkg/index"
+)
+
+func protoAnalyzerHelper(analyzer coreproto.Analyzer) string {
+	switch analyzer {
+	case coreproto.Analyzer_Whitespace:
+		return index.AnalyzerWhitespace
+	case coreproto.Analyzer_English:
+		return index.AnalyzerEnglish
+	}
+	return index.AnalyzerStandard
+}
+
+func reverseAnalyzerHelper(analyzer string) coreproto.Analyzer {
+	switch analyzer {
+	case index.AnalyzerWhitespace:
+		return coreproto.Analyzer_Whitespace
+	case index.AnalyzerEnglish:
+		return coreproto.Analyzer_English
+	}
+	return coreproto.Analyzer_Standard
+}
+
+func diskTextFieldsHelper(fields []*coreproto.TextField) ([]*diskproto.TextField, error) {
+	out := make([]*diskproto.TextField, 0, len(fields))
+	seen := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if field.GetName() == "" {
+			return nil, errors.New(ErrTextField)
+		}
+		if _, ok := seen[field.GetName()]; ok {
+			return nil, fmt.Errorf(ErrDuplicateTextField, field.GetName())
+		}
+		seen[field.GetName()] = struct{}{}
+		out = append(out, &diskproto.TextField{
+			Name:     field.GetName(),
+			Analyzer: protoAnalyzerHelper(field.GetAnalyzer()),
+		})
+	}
+	return out, nil
+}
+
+// textFieldsHelper declares the text fields on the bitmap index of a collection,
+// fields loaded with their postings stay as they are.
+func textFieldsHelper(collectionName string, fields []*diskproto.TextField) {
+	indexdb.indexLock.RLock()
+	bitmap := indexdb.indexes[collectionName]
+	indexdb.indexLock.RUnlock()
+	for _, field := range fields {
+		bitmap.SetTextField(field.GetName(), index.LookupAnalyzer(field.GetAnalyzer()))
+	}
+}
+
+func textFieldsInfoHelper(collectionName string) []*coreproto.TextField {
+	indexdb.indexLock.RLock()
+	bitmap, ok := indexdb.indexes[collectionName]
+	indexdb.indexLock.RUnlock()
+	if !ok {
+		return nil
+	}
+	fields := bitmap.TextFields()
+	out := make([]*coreproto.TextField, 0, len(fields))
+	for name, analyzer := range fields {
+		out = append(out, &coreproto.TextField{Name: name, Analyzer: reverseAnalyzerHelper(analyzer)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GetName() < out[j].GetName() })
+	return out
+}
+
+/* textCandidatesHelper ranks the records matching the text query by bm25 within
+ * the filter and geo query. With a vector, field or sparse query as well, the
+ * vector ranking is narrowed down to the filter and both rankings are fused by
+ * rrf. The candidates are final, they need no further filtering. */
+func (xx *Core) textCandidatesHelper(ctx context.Context, collection collectionIndex, req *coreproto.SearchRequest, geo *index.GeoQuery, k uint,
+) (vectorindex.SearchResult, *searchScores, error) {
+	indexdb.indexLock.RLock()
+	bitmap := indexdb.indexes[req.GetCollectionName()]
+	indexdb.indexLock.RUnlock()
+	hits, err := bitmap.TextSearch(&index.TextQuery{
+		Field:    req.GetTextQuery().GetField(),
+		Query:    req.GetTextQuery().GetQuery(),
+		MatchAll: req.GetTextQuery().GetMatchAll(),
+	}, req.GetFilter(), geo, int(k))
+	if err != nil {
+		return nil, nil, err
+	}
+	scores := &searchScores{text: make(map[uint64]float32, len(hits))}
+	for _, hit := range hits {
+		scores.text[hit.Id] = hit.Score
+	}
+
+	if len(req.GetVector()) == 0 && len(req.GetFieldQueries()) == 0 && len(req.GetSparseVector().GetIndices()) == 0 {
+		candidates := make(vectorindex.SearchResult, 0, len(hits))
+		for _, hit := range hits {
+			metadata, err := collection.Metadata(hit.Id)
+			if err != nil {
+				continue
+			}
+			candidates = append(candidates, vectorindex.SearchResultItem{Id: hit.Id, Metadata: metadata, Score: hit.Score})
+		}
+		return candidates, scores, nil
+	}
+
+	vectors, vectorScores, err := xx.hybridCandidatesHelper(ctx, collection, req, geo, k)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vectorScores != nil {
+		scores.fields, scores.sparse = vectorScores.fields, vectorScores.sparse
+	}
+	var allow map[uint64]struct{}
+	if len(req.GetFilter()) > 0 || geo != nil {
+		matched := bitmap.PureGeoSearch(req.GetFilter(), geo)
+		allow = make(map[uint64]struct{}, len(matched))
+		for _, id := range matched {
+			allow[id] = struct{}{}
+		}
+	}
+	rrfK := req.GetRrfK()
+	if rrfK == 0 {
+		rrfK = defaultRrfK
+	}
+
+	scores.dense = make(map[uint64]float32, len(vectors))
+	fused := make(vectorindex.SearchResult, 0, len(vectors)+len(hits))
+	ranks := make(map[uint64]int, len(vectors))
+	for _, candidate := range vectors {
+		if _, ok := allow[candidate.Id]; allow != nil && !ok {
+			continue
+		}
+		if vectorScores != nil {
+			scores.dense[candidate.Id] = candidate.Score
+		} else {
+			scores.dense[candidate.Id] = scoreHelper(candidate.Score, collection.Distance(), collection.Dim())
+		}
+		ranks[candidate.Id] = len(fused)
+		candidate.Score = 1 / float32(int(rrfK)+len(fused)+1)
+		fused = append(fused, candidate)
+	}
+	for rank, hit := range hits {
+		score := 1 / float32(int(rrfK)+rank+1)
+		if pos, ok := ranks[hit.Id]; ok {
+			fused[pos].Score += score
+			continue
+		}
+		metadata, err := collection.Metadata(hit.Id)
+		if err != nil {
+			continue
+		}
+		fused = append(fused, vectorindex.SearchResultItem{Id: hit.Id, Metadata: metadata, Score: score})
+	}
+	return rankFusedHelper(fused, k), scores, nil
+}
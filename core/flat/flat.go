@@ -0,0 +1,328 @@
+package flat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/sjy-dv/nnv/pkg/sharding"
+)
+
+var ErrPromoting = errors.New("flat index is already being promoted")
+
+// a shard scan checks for a cancelled query every scanCheck vectors
+const scanCheck = 1024
+
+// Successor is the index a flat index is promoted to.
+type Successor interface {
+	Insert(id uint64, vector gomath.Vector, metadata vectorindex.Metadata) error
+	Remove(id uint64) error
+	Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error)
+}
+
+// BulkSuccessor takes the copied vectors of a promotion in one call.
+type BulkSuccessor interface {
+	Successor
+	InsertBulk(items []vectorindex.BuildItem) error
+}
+
+type shard struct {
+	lock     sync.RWMutex
+	ids      []uint64
+	codes    []byte
+	metadata []vectorindex.Metadata
+	pos      map[uint64]int
+}
+
+func (xx *shard) code(pos, size int) []byte {
+	return xx.codes[pos*size : (pos+1)*size : (pos+1)*size]
+}
+
+// removeAt moves the last entry into pos.
+func (xx *shard) removeAt(pos, size int) {
+	last := len(xx.ids) - 1
+	if pos != last {
+		xx.ids[pos] = xx.ids[last]
+		copy(xx.code(pos, size), xx.code(last, size))
+		xx.metadata[pos] = xx.metadata[last]
+		xx.pos[xx.ids[pos]] = pos
+	}
+	xx.metadata[last] = nil
+	xx.ids = xx.ids[:last]
+	xx.codes = xx.codes[:last*size]
+	xx.metadata = xx.metadata[:last]
+}
+
+type change struct {
+	remove   bool
+	id       uint64
+	vector   gomath.Vector
+	metadata vectorindex.Metadata
+}
+
+/* Flat is an exact index, a search computes the distance to every stored
+ * vector. The vectors are spread over shards by id and a search scans the
+ * shards in parallel, each keeping its own top k before they are merged.
+ *
+ * Vectors are kept lowered by the configured quantization and raised into a
+ * per shard buffer for the distance function.
+ *
+ * Promote hands the collection over to a graph index once it grew too large
+ * for a scan, see Promote. */
+type Flat struct {
+	dim       uint
+	distancer distance.Space
+	config    *flatConfig
+	codec     codec
+	shards    []*shard
+	count     atomic.Int64
+
+	// writes hold promotion for reading, the promotion takes it to start the
+	// journal and to hand over to the successor
+	promotion   sync.RWMutex
+	promoting   atomic.Bool
+	journalLock sync.Mutex
+	journal     []change
+	recording   bool
+	successor   Successor
+}
+
+func NewFlat(dim uint, distancer distance.Space, option ...FlatOption) (*Flat, error) {
+	config, err := newFlatConfig(option)
+	if err != nil {
+		return nil, err
+	}
+	xx := &Flat{
+		dim:       dim,
+		distancer: distancer,
+	}
+	xx.reset(config)
+	return xx, nil
+}
+
+func (xx *Flat) reset(config *flatConfig) {
+	xx.config = config
+	xx.codec, _ = newCodec(config.quantization)
+	xx.shards = make([]*shard, config.shards)
+	for i := range xx.shards {
+		xx.shards[i] = &shard{pos: make(map[uint64]int)}
+	}
+	xx.count.Store(0)
+}
+
+func (xx *Flat) shardOf(id uint64) *shard {
+	return xx.shards[sharding.ShardVertex(id, uint64(len(xx.shards)))]
+}
+
+func (xx *Flat) Info() string {
+	return fmt.Sprintf("Flat(%s)", xx.config)
+}
+
+func (xx *Flat) Dim() uint32 {
+	return uint32(xx.dim)
+}
+
+func (xx *Flat) Len() int {
+	return int(xx.count.Load())
+}
+
+func (xx *Flat) Config() ProtoConfig {
+	return ProtoConfig{
+		Shards:           xx.config.shards,
+		Quantization:     xx.config.quantization,
+		PromoteThreshold: xx.config.promoteThreshold,
+	}
+}
+
+func (xx *Flat) Distance() string {
+	return xx.distancer.Type()
+}
+
+func (xx *Flat) BytesSize() uint64 {
+	var size uint64
+	for _, shard := range xx.shards {
+		shard.lock.RLock()
+		size += uint64(len(shard.ids))*8 + uint64(len(shard.codes))
+		shard.lock.RUnlock()
+	}
+	return size
+}
+
+func (xx *Flat) Metadata(id uint64) (vectorindex.Metadata, error) {
+	shard := xx.shardOf(id)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	pos, ok := shard.pos[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	return shard.metadata[pos], nil
+}
+
+// Get returns the stored vector, raised back from its quantization.
+func (xx *Flat) Get(id uint64) (gomath.Vector, error) {
+	shard := xx.shardOf(id)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	pos, ok := shard.pos[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	vector := make(gomath.Vector, xx.dim)
+	xx.codec.decode(vector, shard.code(pos, xx.codec.size(int(xx.dim))))
+	return vector, nil
+}
+
+func (xx *Flat) Insert(id uint64, value gomath.Vector, metadata vectorindex.Metadata) error {
+	if len(value) != int(xx.dim) {
+		return fmt.Errorf("vector dimension %d, expected %d", len(value), xx.dim)
+	}
+	if xx.distancer.Type() == "cosine-dot" {
+		value = vectorindex.Normalize(value)
+	}
+	xx.promotion.RLock()
+	defer xx.promotion.RUnlock()
+	if xx.successor != nil {
+		return xx.successor.Insert(id, value, metadata)
+	}
+	if err := xx.insert(id, value, metadata); err != nil {
+		return err
+	}
+	xx.record(change{id: id, vector: value, metadata: metadata})
+	return nil
+}
+
+func (xx *Flat) insert(id uint64, value gomath.Vector, metadata vectorindex.Metadata) error {
+	size := xx.codec.size(int(xx.dim))
+	shard := xx.shardOf(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	if _, ok := shard.pos[id]; ok {
+		return vectorindex.ItemAlreadyExistsError
+	}
+	shard.pos[id] = len(shard.ids)
+	shard.ids = append(shard.ids, id)
+	shard.codes = append(shard.codes, make([]byte, size)...)
+	xx.codec.encode(shard.code(len(shard.ids)-1, size), value)
+	shard.metadata = append(shard.metadata, metadata)
+	xx.count.Add(1)
+	return nil
+}
+
+func (xx *Flat) Remove(id uint64) error {
+	xx.promotion.RLock()
+	defer xx.promotion.RUnlock()
+	if xx.successor != nil {
+		return xx.successor.Remove(id)
+	}
+	shard := xx.shardOf(id)
+	shard.lock.Lock()
+	pos, ok := shard.pos[id]
+	if !ok {
+		shard.lock.Unlock()
+		return vectorindex.ItemNotFoundError
+	}
+	delete(shard.pos, id)
+	shard.removeAt(pos, xx.codec.size(int(xx.dim)))
+	shard.lock.Unlock()
+	xx.count.Add(-1)
+	xx.record(change{remove: true, id: id})
+	return nil
+}
+
+func (xx *Flat) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	return xx.SearchFilter(ctx, query, k, nil)
+}
+
+// SearchFilter is an exact search over the vectors allow accepts, a nil
+// allow accepts every vector.
+func (xx *Flat) SearchFilter(ctx context.Context, query gomath.Vector, k uint, allow func(id uint64) bool) (vectorindex.SearchResult, error) {
+	if xx.distancer.Type() == "cosine-dot" {
+		query = vectorindex.Normalize(query)
+	}
+	xx.promotion.RLock()
+	defer xx.promotion.RUnlock()
+	if xx.successor != nil {
+		return xx.successorSearch(ctx, query, k, allow)
+	}
+
+	results := make([]vectorindex.SearchResult, len(xx.shards))
+	errs := make([]error, len(xx.shards))
+	var wg sync.WaitGroup
+	for i := range xx.shards {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = xx.scan(ctx, xx.shards[i], query, int(k), allow)
+		}(i)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	merged := make(vectorindex.SearchResult, 0, len(xx.shards)*int(k))
+	for _, result := range results {
+		merged = append(merged, result...)
+	}
+	sort.Stable(merged)
+	if len(merged) > int(k) {
+		merged = merged[:k]
+	}
+	return merged, nil
+}
+
+// scan keeps the k closest vectors of one shard sorted by distance.
+func (xx *Flat) scan(ctx context.Context, shard *shard, query []float32, k int, allow func(id uint64) bool) (vectorindex.SearchResult, error) {
+	size := xx.codec.size(int(xx.dim))
+	buf := make([]float32, xx.dim)
+	result := make(vectorindex.SearchResult, 0, k+1)
+
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	for pos, id := range shard.ids {
+		if pos%scanCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if allow != nil && !allow(id) {
+			continue
+		}
+		xx.codec.decode(buf, shard.code(pos, size))
+		dist := xx.distancer.Distance(query, buf)
+		if len(result) == k && dist >= result[len(result)-1].Score {
+			continue
+		}
+		at := sort.Search(len(result), func(i int) bool {
+			return result[i].Score > dist
+		})
+		result = append(result, vectorindex.SearchResultItem{})
+		copy(result[at+1:], result[at:])
+		result[at] = vectorindex.SearchResultItem{Id: id, Metadata: shard.metadata[pos], Score: dist}
+		if len(result) > k {
+			result = result[:k]
+		}
+	}
+	return result, nil
+}
+
+func (xx *Flat) successorSearch(ctx context.Context, query gomath.Vector, k uint, allow func(id uint64) bool) (vectorindex.SearchResult, error) {
+	result, err := xx.successor.Search(ctx, query, k)
+	if err != nil || allow == nil {
+		return result, err
+	}
+	filtered := result[:0]
+	for _, item := range result {
+		if allow(item.Id) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
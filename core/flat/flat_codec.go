@@ -0,0 +1,99 @@
+package flat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/sjy-dv/nnv/pkg/compresshelper"
+)
+
+type Quantization int32
+
+const (
+	None Quantization = iota
+	F16
+	BF16
+)
+
+func (q Quantization) String() string {
+	switch q {
+	case None:
+		return "none"
+	case F16:
+		return "f16"
+	case BF16:
+		return "bf16"
+	}
+	return fmt.Sprintf("quantization(%d)", int32(q))
+}
+
+// f8 is left out, compresshelper.Float8 does not round trip unit range values
+
+// codec lowers a vector into the bytes kept in a shard and raises it back
+// for the distance function.
+type codec interface {
+	size(dim int) int
+	encode(dst []byte, v []float32)
+	decode(dst []float32, src []byte)
+}
+
+func newCodec(q Quantization) (codec, error) {
+	switch q {
+	case None:
+		return noneCodec{}, nil
+	case F16:
+		return f16Codec{}, nil
+	case BF16:
+		return bf16Codec{}, nil
+	}
+	return nil, fmt.Errorf("flat index does not support %s", q)
+}
+
+type noneCodec struct{}
+
+func (noneCodec) size(dim int) int { return 4 * dim }
+
+func (noneCodec) encode(dst []byte, v []float32) {
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(dst[i*4:], math.Float32bits(x))
+	}
+}
+
+func (noneCodec) decode(dst []float32, src []byte) {
+	for i := range dst {
+		dst[i] = math.Float32frombits(binary.LittleEndian.Uint32(src[i*4:]))
+	}
+}
+
+type f16Codec struct{}
+
+func (f16Codec) size(dim int) int { return 2 * dim }
+
+func (f16Codec) encode(dst []byte, v []float32) {
+	for i, x := range v {
+		binary.LittleEndian.PutUint16(dst[i*2:], compresshelper.Fromfloat32(x).Bits())
+	}
+}
+
+func (f16Codec) decode(dst []float32, src []byte) {
+	for i := range dst {
+		dst[i] = compresshelper.Frombits(binary.LittleEndian.Uint16(src[i*2:])).Float32()
+	}
+}
+
+type bf16Codec struct{}
+
+func (bf16Codec) size(dim int) int { return 2 * dim }
+
+func (bf16Codec) encode(dst []byte, v []float32) {
+	for i, x := range v {
+		binary.LittleEndian.PutUint16(dst[i*2:], compresshelper.BF16Fromfloat32(x).Bits())
+	}
+}
+
+func (bf16Codec) decode(dst []float32, src []byte) {
+	for i := range dst {
+		dst[i] = compresshelper.BF16Frombits(binary.LittleEndian.Uint16(src[i*2:])).Float32()
+	}
+}
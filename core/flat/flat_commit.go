@@ -0,0 +1,121 @@
+package flat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Commit writes the config and every vector as stored, quantized vectors are
+// not raised.
+func (xx *Flat) Commit(w io.Writer) error {
+	if err := xx.config.save(w); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(xx.dim)); err != nil {
+		return err
+	}
+	size := xx.codec.size(int(xx.dim))
+	for _, shard := range xx.shards {
+		if err := xx.commitShard(w, shard, size); err != nil {
+			return err
+		}
+	}
+	// an empty shard closes the stream
+	return binary.Write(w, binary.BigEndian, uint32(0))
+}
+
+func (xx *Flat) commitShard(w io.Writer, shard *shard, size int) error {
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	if len(shard.ids) == 0 {
+		return nil
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(shard.ids))); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, shard.ids); err != nil {
+		return err
+	}
+	if _, err := w.Write(shard.codes[:len(shard.ids)*size]); err != nil {
+		return err
+	}
+	for _, metadata := range shard.metadata {
+		raw, err := msgpack.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, uint32(len(raw))); err != nil {
+			return err
+		}
+		if _, err := w.Write(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx *Flat) Load(r io.Reader) error {
+	config := &flatConfig{}
+	if err := config.load(r); err != nil {
+		return err
+	}
+	if _, err := newCodec(config.quantization); err != nil {
+		return err
+	}
+	var dim uint32
+	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+		return err
+	}
+	if dim != uint32(xx.dim) {
+		return fmt.Errorf("flat dimension %d, expected %d", dim, xx.dim)
+	}
+
+	xx.promotion.Lock()
+	defer xx.promotion.Unlock()
+	xx.reset(config)
+	size := xx.codec.size(int(xx.dim))
+	for {
+		var count uint32
+		if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+		ids := make([]uint64, count)
+		if err := binary.Read(r, binary.BigEndian, ids); err != nil {
+			return err
+		}
+		codes := make([]byte, int(count)*size)
+		if _, err := io.ReadFull(r, codes); err != nil {
+			return err
+		}
+		for i, id := range ids {
+			var length uint32
+			if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+				return err
+			}
+			raw := make([]byte, length)
+			if _, err := io.ReadFull(r, raw); err != nil {
+				return err
+			}
+			var metadata vectorindex.Metadata
+			if err := msgpack.Unmarshal(raw, &metadata); err != nil {
+				return err
+			}
+			shard := xx.shardOf(id)
+			if _, ok := shard.pos[id]; ok {
+				return fmt.Errorf("flat commit holds id %d twice", id)
+			}
+			shard.pos[id] = len(shard.ids)
+			shard.ids = append(shard.ids, id)
+			shard.codes = append(shard.codes, codes[i*size:(i+1)*size]...)
+			shard.metadata = append(shard.metadata, metadata)
+			xx.count.Add(1)
+		}
+	}
+}
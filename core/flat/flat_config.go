@@ -0,0 +1,97 @@
+package flat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Options
+type FlatOption interface {
+	apply(*flatConfig)
+}
+
+type flatOption struct {
+	applyFunc func(*flatConfig)
+}
+
+func (opt *flatOption) apply(config *flatConfig) {
+	opt.applyFunc(config)
+}
+
+// FlatShards is the number of shards, a search scans them in parallel.
+func FlatShards(value int) FlatOption {
+	return &flatOption{func(config *flatConfig) {
+		config.shards = value
+	}}
+}
+
+// FlatQuantization stores the vectors lowered to f16 or bf16.
+func FlatQuantization(value Quantization) FlatOption {
+	return &flatOption{func(config *flatConfig) {
+		config.quantization = value
+	}}
+}
+
+// FlatPromoteThreshold is the size at which the collection asks to be
+// promoted to a graph index, 0 keeps it flat.
+func FlatPromoteThreshold(value int) FlatOption {
+	return &flatOption{func(config *flatConfig) {
+		config.promoteThreshold = value
+	}}
+}
+
+type flatConfig struct {
+	shards           int
+	quantization     Quantization
+	promoteThreshold int
+}
+
+type ProtoConfig struct {
+	Shards           int
+	Quantization     Quantization
+	PromoteThreshold int
+}
+
+func newFlatConfig(options []FlatOption) (*flatConfig, error) {
+	config := &flatConfig{
+		shards: 16,
+	}
+	for _, option := range options {
+		option.apply(config)
+	}
+	if config.shards < 1 {
+		config.shards = 1
+	}
+	if config.promoteThreshold < 0 {
+		config.promoteThreshold = 0
+	}
+	if _, err := newCodec(config.quantization); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (this *flatConfig) String() string {
+	return fmt.Sprintf("shards: %d, quantization: %s, promoteThreshold: %d",
+		this.shards, this.quantization, this.promoteThreshold)
+}
+
+func (this *flatConfig) save(w io.Writer) error {
+	return binary.Write(w, binary.BigEndian, []int32{
+		int32(this.shards),
+		int32(this.quantization),
+		int32(this.promoteThreshold),
+	})
+}
+
+func (this *flatConfig) load(r io.Reader) error {
+	values := make([]int32, 3)
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return err
+	}
+	this.shards = int(values[0])
+	this.quantization = Quantization(values[1])
+	this.promoteThreshold = int(values[2])
+	return nil
+}
@@ -0,0 +1,118 @@
+package flat
+
+import (
+	"errors"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+// PromoteDue reports whether the collection crossed the promote threshold
+// and no promotion is running yet.
+func (xx *Flat) PromoteDue() bool {
+	return xx.config.promoteThreshold > 0 &&
+		xx.Len() >= xx.config.promoteThreshold &&
+		!xx.promoting.Load()
+}
+
+func (xx *Flat) record(c change) {
+	xx.journalLock.Lock()
+	if xx.recording {
+		xx.journal = append(xx.journal, c)
+	}
+	xx.journalLock.Unlock()
+}
+
+/* Promote copies every vector into next and makes next the successor of the
+ * flat index, later writes and searches on the flat index go to next.
+ *
+ * The copy does not block the collection. Writes made while next is built are
+ * journaled and replayed before the hand over. Quantized vectors are copied
+ * raised from their quantization. On error nothing is handed over and the
+ * flat index keeps serving. */
+func (xx *Flat) Promote(next Successor) error {
+	if !xx.promoting.CompareAndSwap(false, true) {
+		return ErrPromoting
+	}
+
+	xx.promotion.Lock()
+	ids, vectors, metadata := xx.entries()
+	xx.journalLock.Lock()
+	xx.recording = true
+	xx.journalLock.Unlock()
+	xx.promotion.Unlock()
+
+	stop := func() {
+		xx.stopRecording()
+		xx.promoting.Store(false)
+	}
+	if err := copyEntries(next, ids, vectors, metadata); err != nil {
+		stop()
+		return err
+	}
+
+	xx.promotion.Lock()
+	defer xx.promotion.Unlock()
+	xx.journalLock.Lock()
+	journal := xx.journal
+	xx.journalLock.Unlock()
+	for _, c := range journal {
+		var err error
+		if c.remove {
+			err = next.Remove(c.id)
+		} else {
+			err = next.Insert(c.id, c.vector, c.metadata)
+		}
+		if err != nil && !errors.Is(err, vectorindex.ItemNotFoundError) && !errors.Is(err, vectorindex.ItemAlreadyExistsError) {
+			stop()
+			return err
+		}
+	}
+	xx.stopRecording()
+	xx.successor = next
+	return nil
+}
+
+func copyEntries(next Successor, ids []uint64, vectors []gomath.Vector, metadata []vectorindex.Metadata) error {
+	if bulk, ok := next.(BulkSuccessor); ok {
+		items := make([]vectorindex.BuildItem, len(ids))
+		for i, id := range ids {
+			items[i] = vectorindex.BuildItem{Id: id, Vector: vectors[i], Metadata: metadata[i]}
+		}
+		return bulk.InsertBulk(items)
+	}
+	for i, id := range ids {
+		if err := next.Insert(id, vectors[i], metadata[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx *Flat) stopRecording() {
+	xx.journalLock.Lock()
+	xx.recording = false
+	xx.journal = nil
+	xx.journalLock.Unlock()
+}
+
+// entries copies every stored vector, raised from its quantization.
+func (xx *Flat) entries() ([]uint64, []gomath.Vector, []vectorindex.Metadata) {
+	size := xx.codec.size(int(xx.dim))
+	n := xx.Len()
+	ids := make([]uint64, 0, n)
+	vectors := make([]gomath.Vector, 0, n)
+	metadata := make([]vectorindex.Metadata, 0, n)
+	for _, shard := range xx.shards {
+		shard.lock.RLock()
+		for pos, id := range shard.ids {
+			vector := make(gomath.Vector, xx.dim)
+			xx.codec.decode(vector, shard.code(pos, size))
+			ids = append(ids, id)
+			vectors = append(vectors, vector)
+			metadata = append(metadata, shard.metadata[pos])
+		}
+		shard.lock.RUnlock()
+	}
+	return ids, vectors, metadata
+}
@@ -0,0 +1,161 @@
+package flat
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomVector(dim int) []float32 {
+	v := make([]float32, dim)
+	for i := range v {
+		v[i] = rand.Float32()
+	}
+	return v
+}
+
+func TestFlatExactSearchFilterAndLoad(t *testing.T) {
+	const dim = 16
+	dist := distance.NewEuclidean()
+	index, err := NewFlat(dim, dist, FlatShards(4))
+	assert.Nil(t, err)
+
+	vectors := make([][]float32, 500)
+	for i := range vectors {
+		vectors[i] = randomVector(dim)
+		assert.Nil(t, index.Insert(uint64(i), vectors[i], vectorindex.Metadata{"n": i}))
+	}
+	assert.Equal(t, vectorindex.ItemAlreadyExistsError, index.Insert(3, vectors[3], nil))
+
+	// the parallel scan matches a sorted brute force
+	query := randomVector(dim)
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return dist.Distance(query, vectors[ids[i]]) < dist.Distance(query, vectors[ids[j]])
+	})
+	result, err := index.Search(context.Background(), query, 10)
+	assert.Nil(t, err)
+	assert.Len(t, result, 10)
+	for i, item := range result {
+		assert.Equal(t, uint64(ids[i]), item.Id)
+	}
+
+	even := func(id uint64) bool { return id%2 == 0 }
+	result, err = index.SearchFilter(context.Background(), vectors[7], 5, even)
+	assert.Nil(t, err)
+	assert.Len(t, result, 5)
+	for _, item := range result {
+		assert.True(t, even(item.Id))
+	}
+
+	for i := 0; i < 500; i += 5 {
+		assert.Nil(t, index.Remove(uint64(i)))
+	}
+	assert.Equal(t, 400, index.Len())
+	assert.Equal(t, vectorindex.ItemNotFoundError, index.Remove(0))
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	loaded, err := NewFlat(dim, dist)
+	assert.Nil(t, err)
+	assert.Nil(t, loaded.Load(&buf))
+	assert.Equal(t, 400, loaded.Len())
+	assert.Equal(t, 4, loaded.Config().Shards)
+	stored, err := loaded.Get(42)
+	assert.Nil(t, err)
+	assert.Equal(t, vectors[42], []float32(stored))
+	metadata, err := loaded.Metadata(42)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 42, metadata["n"])
+	_, err = loaded.Get(40)
+	assert.Equal(t, vectorindex.ItemNotFoundError, err)
+}
+
+func TestFlatQuantization(t *testing.T) {
+	const dim = 32
+	for _, q := range []Quantization{F16, BF16} {
+		index, err := NewFlat(dim, distance.NewCosine(), FlatQuantization(q))
+		assert.Nil(t, err)
+		vectors := make([][]float32, 200)
+		for i := range vectors {
+			vectors[i] = randomVector(dim)
+			assert.Nil(t, index.Insert(uint64(i), vectors[i], nil))
+		}
+		assert.Equal(t, uint64(200*(8+index.codec.size(dim))), index.BytesSize())
+		hits := 0
+		for i := 0; i < 50; i++ {
+			result, err := index.Search(context.Background(), vectors[i], 1)
+			assert.Nil(t, err)
+			if result[0].Id == uint64(i) {
+				hits++
+			}
+		}
+		assert.Greater(t, hits, 45, q.String())
+	}
+	_, err := NewFlat(dim, distance.NewCosine(), FlatQuantization(Quantization(9)))
+	assert.NotNil(t, err)
+}
+
+func TestFlatPromote(t *testing.T) {
+	const dim = 8
+	index, err := NewFlat(dim, distance.NewEuclidean(), FlatPromoteThreshold(100))
+	assert.Nil(t, err)
+	for i := 0; i < 99; i++ {
+		assert.Nil(t, index.Insert(uint64(i), randomVector(dim), nil))
+	}
+	assert.False(t, index.PromoteDue())
+	assert.Nil(t, index.Insert(99, randomVector(dim), nil))
+	assert.True(t, index.PromoteDue())
+
+	next, err := NewFlat(dim, distance.NewEuclidean())
+	assert.Nil(t, err)
+	assert.Nil(t, index.Promote(next))
+	assert.Equal(t, ErrPromoting, index.Promote(next))
+	assert.False(t, index.PromoteDue())
+	assert.Equal(t, 100, next.Len())
+
+	// writes after the hand over land in the successor
+	assert.Nil(t, index.Insert(100, randomVector(dim), nil))
+	assert.Nil(t, index.Remove(0))
+	assert.Equal(t, 100, next.Len())
+	_, err = next.Get(100)
+	assert.Nil(t, err)
+}
+
+type bulkGraph struct {
+	*vectorindex.Hnsw
+	bulk int
+}
+
+func (xx *bulkGraph) Insert(id uint64, vector gomath.Vector, metadata vectorindex.Metadata) error {
+	return xx.Hnsw.Insert(id, vector, metadata, xx.RandomLevel())
+}
+
+func (xx *bulkGraph) InsertBulk(items []vectorindex.BuildItem) error {
+	xx.bulk = len(items)
+	_, err := xx.BuildSlice(context.Background(), items, vectorindex.BuildWorkers(4))
+	return err
+}
+
+func TestFlatPromoteBulk(t *testing.T) {
+	const dim = 8
+	index, err := NewFlat(dim, distance.NewEuclidean(), FlatPromoteThreshold(300))
+	assert.Nil(t, err)
+	for i := 0; i < 300; i++ {
+		assert.Nil(t, index.Insert(uint64(i), randomVector(dim), nil))
+	}
+	next := &bulkGraph{Hnsw: vectorindex.NewHnsw(dim, distance.NewEuclidean())}
+	assert.Nil(t, index.Promote(next))
+	assert.Equal(t, 300, next.bulk)
+	assert.Equal(t, 300, next.Len())
+}
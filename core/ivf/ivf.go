@@ -0,0 +1,277 @@
+package ivf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/sjy-dv/nnv/pkg/hnswpq"
+)
+
+const kmeansMaxIter = 20
+
+// postingList keeps the vectors of one list in a single flat slice.
+type postingList struct {
+	ids      []uint64
+	vectors  []float32
+	metadata []vectorindex.Metadata
+}
+
+func (xx *postingList) add(id uint64, vector []float32, metadata vectorindex.Metadata) int {
+	xx.ids = append(xx.ids, id)
+	xx.vectors = append(xx.vectors, vector...)
+	xx.metadata = append(xx.metadata, metadata)
+	return len(xx.ids) - 1
+}
+
+func (xx *postingList) vector(pos, dim int) []float32 {
+	return xx.vectors[pos*dim : (pos+1)*dim : (pos+1)*dim]
+}
+
+// removeAt moves the last entry into pos and returns the id that moved.
+func (xx *postingList) removeAt(pos, dim int) (uint64, bool) {
+	last := len(xx.ids) - 1
+	if pos != last {
+		xx.ids[pos] = xx.ids[last]
+		copy(xx.vector(pos, dim), xx.vector(last, dim))
+		xx.metadata[pos] = xx.metadata[last]
+	}
+	xx.metadata[last] = nil
+	xx.ids = xx.ids[:last]
+	xx.vectors = xx.vectors[:last*dim]
+	xx.metadata = xx.metadata[:last]
+	if pos == last {
+		return 0, false
+	}
+	return xx.ids[pos], true
+}
+
+type location struct {
+	list int32
+	pos  int32
+}
+
+/* Ivf is an IVF-Flat index. A k-means coarse quantizer splits the vectors
+ * into nlist posting lists and a search only scans the nprobe lists whose
+ * centroids are closest to the query. Building it is a single k-means run,
+ * inserts and removes afterwards only touch one list.
+ *
+ * Until trainSize vectors arrived there are no centroids, the vectors sit in
+ * a single list and searches scan all of it. */
+type Ivf struct {
+	dim       uint
+	distancer distance.Space
+	config    *ivfConfig
+
+	lock      sync.RWMutex
+	centroids [][]float32
+	lists     []postingList
+	locations map[uint64]location
+}
+
+func NewIvf(dim uint, distancer distance.Space, option ...IvfOption) (*Ivf, error) {
+	config, err := newIvfConfig(option)
+	if err != nil {
+		return nil, err
+	}
+	return &Ivf{
+		dim:       dim,
+		distancer: distancer,
+		config:    config,
+		lists:     make([]postingList, 1),
+		locations: make(map[uint64]location),
+	}, nil
+}
+
+func (xx *Ivf) Info() string {
+	return fmt.Sprintf("IVF(dim: %d, distancer: %s, config={%s})", xx.dim, xx.distancer.Type(), xx.config)
+}
+
+func (xx *Ivf) Dim() uint32 {
+	return uint32(xx.dim)
+}
+
+func (xx *Ivf) Len() int {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	return len(xx.locations)
+}
+
+func (xx *Ivf) Config() ProtoConfig {
+	return ProtoConfig{
+		Nlist:     xx.config.nlist,
+		Nprobe:    xx.config.nprobe,
+		TrainSize: xx.config.trainSize,
+	}
+}
+
+func (xx *Ivf) Distance() string {
+	return xx.distancer.Type()
+}
+
+// Trained reports whether the coarse quantizer has centroids yet.
+func (xx *Ivf) Trained() bool {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	return xx.centroids != nil
+}
+
+func (xx *Ivf) BytesSize() uint64 {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	size := uint64(len(xx.centroids)) * uint64(xx.dim) * 4
+	for _, list := range xx.lists {
+		size += uint64(len(list.ids))*8 + uint64(len(list.vectors))*4
+	}
+	return size
+}
+
+func (xx *Ivf) Metadata(id uint64) (vectorindex.Metadata, error) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	loc, ok := xx.locations[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	return xx.lists[loc.list].metadata[loc.pos], nil
+}
+
+func (xx *Ivf) Get(id uint64) (gomath.Vector, error) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	loc, ok := xx.locations[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	return append(gomath.Vector(nil), xx.lists[loc.list].vector(int(loc.pos), int(xx.dim))...), nil
+}
+
+func (xx *Ivf) Insert(id uint64, value gomath.Vector, metadata vectorindex.Metadata) error {
+	if len(value) != int(xx.dim) {
+		return fmt.Errorf("vector dimension %d, expected %d", len(value), xx.dim)
+	}
+	if xx.distancer.Type() == "cosine-dot" {
+		value = vectorindex.Normalize(value)
+	}
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	if _, ok := xx.locations[id]; ok {
+		return vectorindex.ItemAlreadyExistsError
+	}
+	list := xx.assign(value)
+	pos := xx.lists[list].add(id, value, metadata)
+	xx.locations[id] = location{list: int32(list), pos: int32(pos)}
+	if xx.centroids == nil && len(xx.locations) >= xx.config.trainSize {
+		xx.train()
+	}
+	return nil
+}
+
+func (xx *Ivf) Remove(id uint64) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	loc, ok := xx.locations[id]
+	if !ok {
+		return vectorindex.ItemNotFoundError
+	}
+	delete(xx.locations, id)
+	if moved, ok := xx.lists[loc.list].removeAt(int(loc.pos), int(xx.dim)); ok {
+		xx.locations[moved] = loc
+	}
+	return nil
+}
+
+func (xx *Ivf) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	return xx.SearchProbe(ctx, query, k, 0)
+}
+
+// SearchProbe scans the nprobe closest lists, 0 uses the configured nprobe.
+func (xx *Ivf) SearchProbe(ctx context.Context, query gomath.Vector, k uint, nprobe uint) (vectorindex.SearchResult, error) {
+	if xx.distancer.Type() == "cosine-dot" {
+		query = vectorindex.Normalize(query)
+	}
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	if nprobe == 0 {
+		nprobe = uint(xx.config.nprobe)
+	}
+
+	dim := int(xx.dim)
+	result := make(vectorindex.SearchResult, 0, k+1)
+	for _, list := range xx.probe(query, int(nprobe)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		posting := &xx.lists[list]
+		for pos, id := range posting.ids {
+			dist := xx.distancer.Distance(query, posting.vector(pos, dim))
+			if len(result) == int(k) && dist >= result[len(result)-1].Score {
+				continue
+			}
+			at := sort.Search(len(result), func(i int) bool {
+				return result[i].Score > dist
+			})
+			result = append(result, vectorindex.SearchResultItem{})
+			copy(result[at+1:], result[at:])
+			result[at] = vectorindex.SearchResultItem{Id: id, Metadata: posting.metadata[pos], Score: dist}
+			if len(result) > int(k) {
+				result = result[:k]
+			}
+		}
+	}
+	return result, nil
+}
+
+// probe returns the nprobe lists closest to the query, every list before training.
+func (xx *Ivf) probe(query []float32, nprobe int) []int {
+	if xx.centroids == nil {
+		return []int{0}
+	}
+	lists := make([]int, len(xx.centroids))
+	dists := make([]float32, len(xx.centroids))
+	for i, centroid := range xx.centroids {
+		lists[i] = i
+		dists[i] = xx.distancer.Distance(query, centroid)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return dists[lists[i]] < dists[lists[j]]
+	})
+	return lists[:min(nprobe, len(lists))]
+}
+
+// assign returns the list of the closest centroid.
+func (xx *Ivf) assign(vector []float32) int {
+	best, bestDist := 0, float32(0)
+	for i, centroid := range xx.centroids {
+		dist := xx.distancer.Distance(vector, centroid)
+		if i == 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// train fits the centroids on the buffered vectors and spreads them over the lists.
+func (xx *Ivf) train() {
+	dim := int(xx.dim)
+	buffered := xx.lists[0]
+	samples := make([][]float32, len(buffered.ids))
+	for pos := range buffered.ids {
+		samples[pos] = buffered.vector(pos, dim)
+	}
+	km := hnswpq.KMeans{K: xx.config.nlist, MaxIter: kmeansMaxIter, Offset: 0, VectorLen: dim}
+	km.Fit(samples)
+
+	xx.centroids = km.Centroids
+	xx.lists = make([]postingList, len(xx.centroids))
+	for pos, id := range buffered.ids {
+		vector := buffered.vector(pos, dim)
+		list := xx.assign(vector)
+		at := xx.lists[list].add(id, vector, buffered.metadata[pos])
+		xx.locations[id] = location{list: int32(list), pos: int32(at)}
+	}
+}
@@ -0,0 +1,134 @@
+package ivf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Commit writes the config, the centroids and every posting list.
+func (xx *Ivf) Commit(w io.Writer) error {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	if err := xx.config.save(w); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(xx.dim)); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(xx.centroids))); err != nil {
+		return err
+	}
+	for _, centroid := range xx.centroids {
+		if err := binary.Write(w, binary.BigEndian, centroid); err != nil {
+			return err
+		}
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(xx.lists))); err != nil {
+		return err
+	}
+	for _, list := range xx.lists {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(list.ids))); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, list.ids); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, list.vectors); err != nil {
+			return err
+		}
+		for _, metadata := range list.metadata {
+			raw, err := msgpack.Marshal(metadata)
+			if err != nil {
+				return err
+			}
+			if err := binary.Write(w, binary.BigEndian, uint32(len(raw))); err != nil {
+				return err
+			}
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (xx *Ivf) Load(r io.Reader) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+
+	config := &ivfConfig{}
+	if err := config.load(r); err != nil {
+		return err
+	}
+	var dim, count uint32
+	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+		return err
+	}
+	if dim != uint32(xx.dim) {
+		return fmt.Errorf("ivf dimension %d, expected %d", dim, xx.dim)
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+	var centroids [][]float32
+	if count > 0 {
+		centroids = make([][]float32, count)
+		for i := range centroids {
+			centroids[i] = make([]float32, dim)
+			if err := binary.Read(r, binary.BigEndian, centroids[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+	lists := make([]postingList, count)
+	locations := make(map[uint64]location)
+	for l := range lists {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		list := postingList{
+			ids:      make([]uint64, size),
+			vectors:  make([]float32, int(size)*int(dim)),
+			metadata: make([]vectorindex.Metadata, size),
+		}
+		if err := binary.Read(r, binary.BigEndian, list.ids); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.BigEndian, list.vectors); err != nil {
+			return err
+		}
+		for pos := range list.metadata {
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return err
+			}
+			raw := make([]byte, size)
+			if _, err := io.ReadFull(r, raw); err != nil {
+				return err
+			}
+			if err := msgpack.Unmarshal(raw, &list.metadata[pos]); err != nil {
+				return err
+			}
+			locations[list.ids[pos]] = location{list: int32(l), pos: int32(pos)}
+		}
+		lists[l] = list
+	}
+	if (centroids == nil && len(lists) != 1) || (centroids != nil && len(lists) != len(centroids)) {
+		return fmt.Errorf("ivf holds %d lists for %d centroids", len(lists), len(centroids))
+	}
+
+	xx.config = config
+	xx.centroids = centroids
+	xx.lists = lists
+	xx.locations = locations
+	return nil
+}
@@ -0,0 +1,106 @@
+package ivf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// the coarse quantizer reuses hnswpq.KMeans whose labels are a byte
+const MaxNlist = 256
+
+// Options
+type IvfOption interface {
+	apply(*ivfConfig)
+}
+
+type ivfOption struct {
+	applyFunc func(*ivfConfig)
+}
+
+func (opt *ivfOption) apply(config *ivfConfig) {
+	opt.applyFunc(config)
+}
+
+// IvfNlist is the number of centroids and posting lists.
+func IvfNlist(value int) IvfOption {
+	return &ivfOption{func(config *ivfConfig) {
+		config.nlist = value
+	}}
+}
+
+// IvfNprobe is the number of lists scanned when a query does not ask for its own.
+func IvfNprobe(value int) IvfOption {
+	return &ivfOption{func(config *ivfConfig) {
+		config.nprobe = value
+	}}
+}
+
+// IvfTrainSize is the number of vectors needed before the centroids are trained,
+// 0 picks 39 x nlist. Until then every search is a flat scan.
+func IvfTrainSize(value int) IvfOption {
+	return &ivfOption{func(config *ivfConfig) {
+		config.trainSize = value
+	}}
+}
+
+type ivfConfig struct {
+	nlist     int
+	nprobe    int
+	trainSize int
+}
+
+type ProtoConfig struct {
+	Nlist     int
+	Nprobe    int
+	TrainSize int
+}
+
+func newIvfConfig(options []IvfOption) (*ivfConfig, error) {
+	config := &ivfConfig{
+		nlist:  64,
+		nprobe: 8,
+	}
+	for _, option := range options {
+		option.apply(config)
+	}
+	if config.nlist < 1 || config.nlist > MaxNlist {
+		return nil, fmt.Errorf("ivf nlist %d is out of range [1, %d]", config.nlist, MaxNlist)
+	}
+	if config.nprobe < 1 {
+		config.nprobe = 1
+	}
+	if config.nprobe > config.nlist {
+		config.nprobe = config.nlist
+	}
+	if config.trainSize <= 0 {
+		config.trainSize = 39 * config.nlist
+	}
+	if config.trainSize < config.nlist {
+		config.trainSize = config.nlist
+	}
+	return config, nil
+}
+
+func (this *ivfConfig) String() string {
+	return fmt.Sprintf("nlist: %d, nprobe: %d, trainSize: %d", this.nlist, this.nprobe, this.trainSize)
+}
+
+func (this *ivfConfig) save(w io.Writer) error {
+	return binary.Write(w, binary.BigEndian, []int32{
+		int32(this.nlist),
+		int32(this.nprobe),
+		int32(this.trainSize),
+	})
+}
+
+func (this *ivfConfig) load(r io.Reader) error {
+	values := make([]int32, 3)
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return err
+	}
+	this.nlist = int(values[0])
+	this.nprobe = int(values[1])
+	this.trainSize = int(values[2])
+	return nil
+}
@@ -0,0 +1,80 @@
+package ivf
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomVector(dim int) []float32 {
+	v := make([]float32, dim)
+	for i := range v {
+		v[i] = rand.Float32()
+	}
+	return v
+}
+
+func TestIvfTrainSearchAndRemove(t *testing.T) {
+	const dim = 16
+	index, err := NewIvf(dim, distance.NewEuclidean(), IvfNlist(16), IvfNprobe(4), IvfTrainSize(400))
+	assert.Nil(t, err)
+
+	vectors := make([][]float32, 1000)
+	for i := range vectors {
+		vectors[i] = randomVector(dim)
+		assert.Nil(t, index.Insert(uint64(i), vectors[i], vectorindex.Metadata{"n": i}))
+		if i == 398 {
+			assert.False(t, index.Trained())
+		}
+	}
+	assert.True(t, index.Trained())
+	assert.Equal(t, vectorindex.ItemAlreadyExistsError, index.Insert(3, vectors[3], nil))
+
+	result, err := index.Search(context.Background(), vectors[42], 5)
+	assert.Nil(t, err)
+	assert.Len(t, result, 5)
+	assert.Equal(t, uint64(42), result[0].Id)
+	for i := 1; i < len(result); i++ {
+		assert.LessOrEqual(t, result[i-1].Score, result[i].Score)
+	}
+
+	// probing every list is an exact scan
+	exact, err := index.SearchProbe(context.Background(), vectors[7], 10, 16)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), exact[0].Id)
+
+	for i := 0; i < 1000; i += 2 {
+		assert.Nil(t, index.Remove(uint64(i)))
+	}
+	assert.Equal(t, 500, index.Len())
+	assert.Equal(t, vectorindex.ItemNotFoundError, index.Remove(0))
+	for i := 1; i < 1000; i += 2 {
+		stored, err := index.Get(uint64(i))
+		assert.Nil(t, err)
+		assert.Equal(t, vectors[i], []float32(stored))
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	loaded, err := NewIvf(dim, distance.NewEuclidean())
+	assert.Nil(t, err)
+	assert.Nil(t, loaded.Load(&buf))
+	assert.Equal(t, 500, loaded.Len())
+	assert.Equal(t, 16, loaded.Config().Nlist)
+	result, err = loaded.Search(context.Background(), vectors[43], 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(43), result[0].Id)
+	metadata, err := loaded.Metadata(43)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 43, metadata["n"])
+}
+
+func TestIvfNlistLimit(t *testing.T) {
+	_, err := NewIvf(8, distance.NewEuclidean(), IvfNlist(MaxNlist+1))
+	assert.NotNil(t, err)
+}
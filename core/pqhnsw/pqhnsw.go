@@ -0,0 +1,265 @@
+package pqhnsw
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/edge"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/sjy-dv/nnv/pkg/hnswpq"
+	"github.com/sjy-dv/nnv/pkg/models"
+	"github.com/sjy-dv/nnv/pkg/queue"
+)
+
+// graphName is the single collection held by the wrapped hnswpq.HnswPQs.
+const graphName = "pqhnsw"
+
+type entry struct {
+	id   uint64
+	live bool
+}
+
+/* PQHnsw puts the product quantized graph of pkg/hnswpq behind the core index
+ * interface. The graph addresses nodes by a dense node id and keeps no
+ * metadata, so PQHnsw maps collection ids to node ids and holds the metadata.
+ *
+ * The codebook is pretrained on random vectors so the graph can encode from
+ * the first insert, it is refit on the collection once trainSize vectors
+ * arrived. Removed nodes stay in the graph as tombstones and are skipped by
+ * searches, Load rebuilds the graph from the live vectors only.
+ *
+ * The hnswpq search keeps m candidates on every layer, a query returns at
+ * most m results. */
+type PQHnsw struct {
+	dim       uint
+	distancer distance.Space
+	config    *pqHnswConfig
+
+	lock      sync.RWMutex
+	graph     *hnswpq.HnswPQs
+	nodes     map[uint64]uint64
+	entries   []entry
+	metadata  map[uint64]vectorindex.Metadata
+	retrained bool
+}
+
+func NewPQHnsw(dim uint, distancer distance.Space, option ...PQHnswOption) (*PQHnsw, error) {
+	config, err := newPQHnswConfig(int(dim), option)
+	if err != nil {
+		return nil, err
+	}
+	xx := &PQHnsw{
+		dim:       dim,
+		distancer: distancer,
+		config:    config,
+	}
+	if err := xx.reset(); err != nil {
+		return nil, err
+	}
+	return xx, nil
+}
+
+// reset replaces the graph with an empty one holding only the genesis node.
+func (xx *PQHnsw) reset() error {
+	graphConfig := models.HnswConfig{
+		Efconstruction: xx.config.efConstruction,
+		M:              xx.config.m,
+		Mmax:           xx.config.m,
+		Mmax0:          2 * xx.config.m,
+		Ml:             xx.config.levelMultiplier(),
+		Dim:            uint32(xx.dim),
+		DistanceType:   graphDistanceHelper(xx.distancer),
+		// the heuristic selection of hnswpq can spin on pruned candidates
+		Heuristic: false,
+	}
+	params := models.ProductQuantizerParameters{
+		NumCentroids:      xx.config.centroids,
+		NumSubVectors:     xx.config.subvectors,
+		TriggerThreshold:  xx.config.trainSize,
+		OptimizedRotation: xx.config.rotation,
+	}
+	graph := hnswpq.NewProductQuantizationHnsw()
+	if err := graph.CreateCollection(graphName, graphConfig, params); err != nil {
+		return err
+	}
+	pq := graph.Collections[graphName].PQ
+	if err := pq.PreTrainProductQuantizer(graphName, int(xx.dim), xx.config.trainSize); err != nil {
+		return err
+	}
+	// the pretrain points share the node ids of the first inserts
+	pretrained := make([]uint64, xx.config.trainSize)
+	for i := range pretrained {
+		pretrained[i] = uint64(i + 1)
+	}
+	pq.Delete(pretrained...)
+	graph.Genesis(graphName, graphConfig)
+
+	xx.graph = graph
+	xx.nodes = make(map[uint64]uint64)
+	xx.entries = make([]entry, 1)
+	xx.metadata = make(map[uint64]vectorindex.Metadata)
+	xx.retrained = false
+	return nil
+}
+
+func graphDistanceHelper(distancer distance.Space) string {
+	if distancer.Type() == "cosine-dot" {
+		return edge.COSINE
+	}
+	return distancer.Type()
+}
+
+func (xx *PQHnsw) Info() string {
+	return fmt.Sprintf("PQHnsw(%s)", xx.config)
+}
+
+func (xx *PQHnsw) Dim() uint32 {
+	return uint32(xx.dim)
+}
+
+func (xx *PQHnsw) Len() int {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	return len(xx.nodes)
+}
+
+func (xx *PQHnsw) Config() ProtoConfig {
+	return ProtoConfig{
+		M:              xx.config.m,
+		EfConstruction: xx.config.efConstruction,
+		Ef:             xx.config.ef,
+		Centroids:      xx.config.centroids,
+		Subvectors:     xx.config.subvectors,
+		TrainSize:      xx.config.trainSize,
+		Rotation:       xx.config.rotation,
+	}
+}
+
+func (xx *PQHnsw) Distance() string {
+	return xx.distancer.Type()
+}
+
+// BytesSize counts the vectors, codes and links of every node in the graph,
+// tombstones included.
+func (xx *PQHnsw) BytesSize() uint64 {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	var size uint64
+	for _, node := range xx.graph.Collections[graphName].NodeList.Nodes {
+		size += uint64(len(node.Vectors))*4 + uint64(len(node.Centroids))
+		for _, links := range node.LinkNodes {
+			size += uint64(len(links)) * 8
+		}
+	}
+	return size
+}
+
+func (xx *PQHnsw) Metadata(id uint64) (vectorindex.Metadata, error) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	if _, ok := xx.nodes[id]; !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	return xx.metadata[id], nil
+}
+
+func (xx *PQHnsw) Get(id uint64) (gomath.Vector, error) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	node, ok := xx.nodes[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	return append(gomath.Vector(nil), xx.graph.Collections[graphName].NodeList.Nodes[node].Vectors...), nil
+}
+
+func (xx *PQHnsw) Insert(id uint64, value gomath.Vector, metadata vectorindex.Metadata) error {
+	if len(value) != int(xx.dim) {
+		return fmt.Errorf("vector dimension %d, expected %d", len(value), xx.dim)
+	}
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	return xx.insert(id, value, metadata)
+}
+
+func (xx *PQHnsw) insert(id uint64, value gomath.Vector, metadata vectorindex.Metadata) error {
+	if _, ok := xx.nodes[id]; ok {
+		return vectorindex.ItemAlreadyExistsError
+	}
+	// node ids are never reused, the next one is the next slot of the node list
+	node := uint64(len(xx.entries))
+	// the graph keeps the slice it is given
+	if err := xx.graph.Insert(graphName, node, append(gomath.Vector(nil), value...)); err != nil {
+		return err
+	}
+	xx.entries = append(xx.entries, entry{id: id, live: true})
+	xx.nodes[id] = node
+	xx.metadata[id] = metadata
+
+	if !xx.retrained && len(xx.nodes) >= xx.config.trainSize {
+		xx.retrained = true
+		if err := <-xx.graph.RetrainCodebook(graphName, xx.config.trainSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx *PQHnsw) Remove(id uint64) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	node, ok := xx.nodes[id]
+	if !ok {
+		return vectorindex.ItemNotFoundError
+	}
+	delete(xx.nodes, id)
+	delete(xx.metadata, id)
+	xx.entries[node].live = false
+	// keep the tombstone out of codebook retraining samples
+	xx.graph.Collections[graphName].PQ.Delete(node)
+	return nil
+}
+
+// Search walks the graph on PQ distances and reranks the candidates on the
+// full vectors.
+func (xx *PQHnsw) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	if xx.distancer.Type() == "cosine-dot" {
+		query = vectorindex.Normalize(query)
+	}
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	candidates := &queue.PriorityQueue{Order: false, Items: []*queue.Item{}}
+	heap.Init(candidates)
+	ef := max(xx.config.ef, int(k))
+	if err := xx.graph.Search(graphName, query, candidates, ef, ef); err != nil {
+		return nil, err
+	}
+
+	nodes := xx.graph.Collections[graphName].NodeList.Nodes
+	result := make(vectorindex.SearchResult, 0, len(candidates.Items))
+	for _, item := range candidates.Items {
+		if item.NodeID == 0 || item.NodeID >= uint64(len(xx.entries)) || !xx.entries[item.NodeID].live {
+			continue
+		}
+		id := xx.entries[item.NodeID].id
+		result = append(result, vectorindex.SearchResultItem{
+			Id:       id,
+			Metadata: xx.metadata[id],
+			Score:    xx.distancer.Distance(query, nodes[item.NodeID].Vectors),
+		})
+	}
+	sort.Sort(result)
+	if len(result) > int(k) {
+		result = result[:k]
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package pqhnsw
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Commit writes the config, the retrained codebook and the live vectors with
+// their metadata, the graph itself is not persisted.
+func (xx *PQHnsw) Commit(w io.Writer) error {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	if err := xx.config.save(w); err != nil {
+		return err
+	}
+	retrained := uint32(0)
+	if xx.retrained {
+		retrained = 1
+	}
+	if err := binary.Write(w, binary.BigEndian, []uint32{uint32(xx.dim), uint32(len(xx.nodes)), retrained}); err != nil {
+		return err
+	}
+	// the pretrained codebook is random, only a retrained one is kept
+	if xx.retrained {
+		if err := xx.graph.CommitCodebook(graphName, w); err != nil {
+			return err
+		}
+	}
+	nodes := xx.graph.Collections[graphName].NodeList.Nodes
+	for node, entry := range xx.entries {
+		if !entry.live {
+			continue
+		}
+		if err := binary.Write(w, binary.BigEndian, entry.id); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, nodes[node].Vectors); err != nil {
+			return err
+		}
+		raw, err := msgpack.Marshal(xx.metadata[entry.id])
+		if err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, uint32(len(raw))); err != nil {
+			return err
+		}
+		if _, err := w.Write(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load rebuilds the graph from a commit, inserting the vectors in commit order.
+// They are encoded with the committed codebook, a retrained one is not fit again.
+func (xx *PQHnsw) Load(r io.Reader) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+
+	config := &pqHnswConfig{}
+	if err := config.load(r); err != nil {
+		return err
+	}
+	header := make([]uint32, 3)
+	if err := binary.Read(r, binary.BigEndian, header); err != nil {
+		return err
+	}
+	if header[0] != uint32(xx.dim) {
+		return fmt.Errorf("pq hnsw dimension %d, expected %d", header[0], xx.dim)
+	}
+
+	xx.config = config
+	if err := xx.reset(); err != nil {
+		return err
+	}
+	if header[2] == 1 {
+		if err := xx.graph.LoadCodebook(graphName, r); err != nil {
+			return err
+		}
+		xx.retrained = true
+	}
+	for i := uint32(0); i < header[1]; i++ {
+		var id uint64
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			return err
+		}
+		vector := make(gomath.Vector, xx.dim)
+		if err := binary.Read(r, binary.BigEndian, vector); err != nil {
+			return err
+		}
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return err
+		}
+		var metadata vectorindex.Metadata
+		if err := msgpack.Unmarshal(raw, &metadata); err != nil {
+			return err
+		}
+		if err := xx.insert(id, vector, metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package pqhnsw
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Options
+type PQHnswOption interface {
+	apply(*pqHnswConfig)
+}
+
+type pqHnswOption struct {
+	applyFunc func(*pqHnswConfig)
+}
+
+func (opt *pqHnswOption) apply(config *pqHnswConfig) {
+	opt.applyFunc(config)
+}
+
+// PQHnswM is the number of links of a node, layer zero keeps twice as many.
+func PQHnswM(value int) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.m = value
+	}}
+}
+
+func PQHnswEfConstruction(value int) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.efConstruction = value
+	}}
+}
+
+func PQHnswEf(value int) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.ef = value
+	}}
+}
+
+// PQHnswCentroids is the number of centroids of every subquantizer, at most 256.
+func PQHnswCentroids(value int) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.centroids = value
+	}}
+}
+
+// PQHnswSubvectors is rounded down to a divisor of the dimension, 0 picks dim / 4.
+func PQHnswSubvectors(value int) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.subvectors = value
+	}}
+}
+
+// PQHnswTrainSize is the number of vectors after which the pretrained codebook
+// is refit on the collection.
+func PQHnswTrainSize(value int) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.trainSize = value
+	}}
+}
+
+// PQHnswRotation learns an OPQ rotation with the codebook.
+func PQHnswRotation(value bool) PQHnswOption {
+	return &pqHnswOption{func(config *pqHnswConfig) {
+		config.rotation = value
+	}}
+}
+
+type pqHnswConfig struct {
+	m              int
+	efConstruction int
+	ef             int
+	centroids      int
+	subvectors     int
+	trainSize      int
+	rotation       bool
+}
+
+type ProtoConfig struct {
+	M              int
+	EfConstruction int
+	Ef             int
+	Centroids      int
+	Subvectors     int
+	TrainSize      int
+	Rotation       bool
+}
+
+func newPQHnswConfig(dim int, options []PQHnswOption) (*pqHnswConfig, error) {
+	config := &pqHnswConfig{
+		m:              16,
+		efConstruction: 200,
+		ef:             64,
+		centroids:      256,
+		trainSize:      1000,
+	}
+	for _, option := range options {
+		option.apply(config)
+	}
+	if config.centroids < 2 || config.centroids > 256 {
+		return nil, fmt.Errorf("pq centroids %d is out of range [2, 256]", config.centroids)
+	}
+	if config.m < 2 {
+		config.m = 2
+	}
+	if config.efConstruction < config.m {
+		config.efConstruction = config.m
+	}
+	if config.ef < 1 {
+		config.ef = 1
+	}
+	if config.trainSize < config.centroids {
+		config.trainSize = config.centroids
+	}
+	config.subvectors = subvectors(dim, config.subvectors)
+	return config, nil
+}
+
+// subvectors returns the largest divisor of dim not above want.
+func subvectors(dim, want int) int {
+	if want <= 0 {
+		want = dim / 4
+	}
+	if want > dim {
+		want = dim
+	}
+	for m := want; m > 1; m-- {
+		if dim%m == 0 {
+			return m
+		}
+	}
+	return 1
+}
+
+func (this *pqHnswConfig) levelMultiplier() float64 {
+	return 1 / math.Log(float64(this.m))
+}
+
+func (this *pqHnswConfig) String() string {
+	return fmt.Sprintf(
+		"m: %d, efConstruction: %d, ef: %d, centroids: %d, subvectors: %d, trainSize: %d, rotation: %v",
+		this.m,
+		this.efConstruction,
+		this.ef,
+		this.centroids,
+		this.subvectors,
+		this.trainSize,
+		this.rotation,
+	)
+}
+
+func (this *pqHnswConfig) save(w io.Writer) error {
+	rotation := int32(0)
+	if this.rotation {
+		rotation = 1
+	}
+	return binary.Write(w, binary.BigEndian, []int32{
+		int32(this.m),
+		int32(this.efConstruction),
+		int32(this.ef),
+		int32(this.centroids),
+		int32(this.subvectors),
+		int32(this.trainSize),
+		rotation,
+	})
+}
+
+func (this *pqHnswConfig) load(r io.Reader) error {
+	values := make([]int32, 7)
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return err
+	}
+	this.m = int(values[0])
+	this.efConstruction = int(values[1])
+	this.ef = int(values[2])
+	this.centroids = int(values[3])
+	this.subvectors = int(values[4])
+	this.trainSize = int(values[5])
+	this.rotation = values[6] == 1
+	return nil
+}
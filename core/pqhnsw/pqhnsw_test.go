@@ -0,0 +1,106 @@
+package pqhnsw
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomVector(dim int) []float32 {
+	v := make([]float32, dim)
+	for i := range v {
+		v[i] = rand.Float32()
+	}
+	return v
+}
+
+func TestPQHnswSearchRemoveAndLoad(t *testing.T) {
+	const dim = 16
+	index, err := NewPQHnsw(dim, distance.NewEuclidean(),
+		PQHnswCentroids(16), PQHnswSubvectors(4), PQHnswTrainSize(200), PQHnswEf(64))
+	assert.Nil(t, err)
+
+	vectors := make([][]float32, 600)
+	for i := range vectors {
+		vectors[i] = randomVector(dim)
+		// ids are sparse, the graph still gets dense node ids
+		assert.Nil(t, index.Insert(uint64(i)*1000+7, vectors[i], vectorindex.Metadata{"n": i}))
+	}
+	assert.Equal(t, vectorindex.ItemAlreadyExistsError, index.Insert(7, vectors[0], nil))
+	assert.Equal(t, 600, index.Len())
+
+	hits := 0
+	for i := 0; i < 50; i++ {
+		result, err := index.Search(context.Background(), vectors[i], 5)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(result), 5)
+		if len(result) > 0 && result[0].Id == uint64(i)*1000+7 {
+			hits++
+		}
+	}
+	assert.Greater(t, hits, 40)
+
+	for i := 0; i < 600; i += 2 {
+		assert.Nil(t, index.Remove(uint64(i)*1000+7))
+	}
+	assert.Equal(t, vectorindex.ItemNotFoundError, index.Remove(7))
+	result, err := index.Search(context.Background(), vectors[10], 10)
+	assert.Nil(t, err)
+	for _, item := range result {
+		// only the odd inserts are left
+		assert.Equal(t, uint64(1), (item.Id-7)/1000%2)
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	loaded, err := NewPQHnsw(dim, distance.NewEuclidean())
+	assert.Nil(t, err)
+	assert.Nil(t, loaded.Load(&buf))
+	assert.Equal(t, 300, loaded.Len())
+	assert.Equal(t, 16, loaded.Config().Centroids)
+	result, err = loaded.Search(context.Background(), vectors[11], 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(11007), result[0].Id)
+	metadata, err := loaded.Metadata(11007)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 11, metadata["n"])
+	stored, err := loaded.Get(11007)
+	assert.Nil(t, err)
+	assert.Equal(t, vectors[11], []float32(stored))
+}
+
+func TestPQHnswLoadKeepsCodebook(t *testing.T) {
+	const dim = 8
+	index, err := NewPQHnsw(dim, distance.NewEuclidean(),
+		PQHnswCentroids(8), PQHnswSubvectors(2), PQHnswTrainSize(100), PQHnswRotation(true))
+	assert.Nil(t, err)
+	for i := 0; i < 150; i++ {
+		assert.Nil(t, index.Insert(uint64(i), randomVector(dim), nil))
+	}
+	// fewer vectors than trainSize are left, a load could not fit again
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, index.Remove(uint64(i)))
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	committed := bytes.Clone(buf.Bytes())
+	loaded, err := NewPQHnsw(dim, distance.NewEuclidean())
+	assert.Nil(t, err)
+	assert.Nil(t, loaded.Load(&buf))
+
+	// the rotation and the codebook come back as they were committed
+	var again bytes.Buffer
+	assert.Nil(t, loaded.Commit(&again))
+	assert.Equal(t, committed, again.Bytes())
+}
+
+func TestPQHnswCentroidLimit(t *testing.T) {
+	_, err := NewPQHnsw(8, distance.NewEuclidean(), PQHnswCentroids(257))
+	assert.NotNil(t, err)
+}
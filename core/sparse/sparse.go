@@ -0,0 +1,330 @@
+package sparse
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrMalformed     = errors.New("sparse vector: indices and values differ in length")
+	ErrDuplicate     = errors.New("sparse vector: index appears twice")
+	ErrNegative      = errors.New("sparse vector: values must not be negative")
+	ErrAlreadyExists = errors.New("sparse vector: id already exists")
+	ErrNotFound      = errors.New("sparse vector: id not found")
+)
+
+// a search checks for a cancelled query every searchCheck documents
+const searchCheck = 1024
+
+// Vector is a sparse vector, the value of each dimension in Indices.
+type Vector struct {
+	Indices []uint32
+	Values  []float32
+}
+
+// Validate checks the vector can be stored or searched, scores are dot
+// products and the search bounds need non negative values.
+func (xx Vector) Validate() error {
+	if len(xx.Indices) != len(xx.Values) {
+		return ErrMalformed
+	}
+	seen := make(map[uint32]struct{}, len(xx.Indices))
+	for i, index := range xx.Indices {
+		if _, ok := seen[index]; ok {
+			return ErrDuplicate
+		}
+		seen[index] = struct{}{}
+		if xx.Values[i] < 0 {
+			return ErrNegative
+		}
+	}
+	return nil
+}
+
+func (xx Vector) Len() int {
+	return len(xx.Indices)
+}
+
+type posting struct {
+	id    uint64
+	value float32
+}
+
+// postingList holds the postings of a term by id. maxValue bounds the values,
+// a remove leaves it as it is, it stays an upper bound.
+type postingList struct {
+	postings []posting
+	maxValue float32
+}
+
+func (xx *postingList) find(id uint64) int {
+	return sort.Search(len(xx.postings), func(i int) bool {
+		return xx.postings[i].id >= id
+	})
+}
+
+func (xx *postingList) add(id uint64, value float32) {
+	if value > xx.maxValue {
+		xx.maxValue = value
+	}
+	// ids mostly come in increasing order
+	if n := len(xx.postings); n == 0 || xx.postings[n-1].id < id {
+		xx.postings = append(xx.postings, posting{id, value})
+		return
+	}
+	pos := xx.find(id)
+	xx.postings = append(xx.postings, posting{})
+	copy(xx.postings[pos+1:], xx.postings[pos:])
+	xx.postings[pos] = posting{id, value}
+}
+
+func (xx *postingList) remove(id uint64) {
+	pos := xx.find(id)
+	if pos < len(xx.postings) && xx.postings[pos].id == id {
+		xx.postings = append(xx.postings[:pos], xx.postings[pos+1:]...)
+	}
+}
+
+type Result struct {
+	Id    uint64
+	Score float32
+}
+
+/* Index is an inverted index over sparse vectors, every dimension keeps the
+ * postings of the vectors that have it. A search scores by dot product and
+ * walks the postings of the query dimensions document at a time with
+ * MaxScore: the dimensions whose bounds together can not lift a document
+ * into the top k are only probed for documents the others already found. */
+type Index struct {
+	lock  sync.RWMutex
+	terms map[uint32]*postingList
+	docs  map[uint64]Vector
+}
+
+func NewIndex() *Index {
+	return &Index{
+		terms: make(map[uint32]*postingList),
+		docs:  make(map[uint64]Vector),
+	}
+}
+
+func (xx *Index) Insert(id uint64, vector Vector) error {
+	if err := vector.Validate(); err != nil {
+		return err
+	}
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	if _, ok := xx.docs[id]; ok {
+		return ErrAlreadyExists
+	}
+	for i, index := range vector.Indices {
+		list, ok := xx.terms[index]
+		if !ok {
+			list = &postingList{}
+			xx.terms[index] = list
+		}
+		list.add(id, vector.Values[i])
+	}
+	xx.docs[id] = vector
+	return nil
+}
+
+func (xx *Index) Remove(id uint64) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	vector, ok := xx.docs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	for _, index := range vector.Indices {
+		list := xx.terms[index]
+		list.remove(id)
+		if len(list.postings) == 0 {
+			delete(xx.terms, index)
+		}
+	}
+	delete(xx.docs, id)
+	return nil
+}
+
+func (xx *Index) Get(id uint64) (Vector, bool) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	vector, ok := xx.docs[id]
+	return vector, ok
+}
+
+// Score is the dot product of the query with the stored vector of id, 0 when there is none.
+func (xx *Index) Score(id uint64, query Vector) float32 {
+	vector, ok := xx.Get(id)
+	if !ok {
+		return 0
+	}
+	values := make(map[uint32]float32, vector.Len())
+	for i, index := range vector.Indices {
+		values[index] = vector.Values[i]
+	}
+	var score float32
+	for i, index := range query.Indices {
+		score += query.Values[i] * values[index]
+	}
+	return score
+}
+
+func (xx *Index) Len() int {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	return len(xx.docs)
+}
+
+func (xx *Index) BytesSize() uint64 {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	var size uint64
+	for _, vector := range xx.docs {
+		// the vector and its postings
+		size += uint64(vector.Len()) * (4 + 4 + 8 + 4)
+	}
+	return size
+}
+
+type cursor struct {
+	postings []posting
+	pos      int
+	weight   float32
+	bound    float32
+}
+
+func (xx *cursor) done() bool {
+	return xx.pos >= len(xx.postings)
+}
+
+func (xx *cursor) id() uint64 {
+	return xx.postings[xx.pos].id
+}
+
+// seek moves to the first posting at or after id.
+func (xx *cursor) seek(id uint64) {
+	rest := xx.postings[xx.pos:]
+	xx.pos += sort.Search(len(rest), func(i int) bool {
+		return rest[i].id >= id
+	})
+}
+
+type resultHeap []Result
+
+func (xx resultHeap) Len() int { return len(xx) }
+func (xx resultHeap) Less(i, j int) bool {
+	if xx[i].Score != xx[j].Score {
+		return xx[i].Score < xx[j].Score
+	}
+	return xx[i].Id > xx[j].Id
+}
+func (xx resultHeap) Swap(i, j int) { xx[i], xx[j] = xx[j], xx[i] }
+func (xx *resultHeap) Push(v any)   { *xx = append(*xx, v.(Result)) }
+func (xx *resultHeap) Pop() any {
+	old := *xx
+	v := old[len(old)-1]
+	*xx = old[:len(old)-1]
+	return v
+}
+
+// Search gives the k vectors with the largest dot product with query, best first.
+func (xx *Index) Search(ctx context.Context, query Vector, k int) ([]Result, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return []Result{}, nil
+	}
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+
+	cursors := make([]*cursor, 0, query.Len())
+	for i, index := range query.Indices {
+		list, ok := xx.terms[index]
+		if !ok || query.Values[i] == 0 {
+			continue
+		}
+		cursors = append(cursors, &cursor{
+			postings: list.postings,
+			weight:   query.Values[i],
+			bound:    query.Values[i] * list.maxValue,
+		})
+	}
+	// bounds[i] is the most cursors[:i+1] add to a score together
+	sort.Slice(cursors, func(i, j int) bool {
+		return cursors[i].bound < cursors[j].bound
+	})
+	bounds := make([]float32, len(cursors))
+	var sum float32
+	for i, c := range cursors {
+		sum += c.bound
+		bounds[i] = sum
+	}
+
+	top := make(resultHeap, 0, k)
+	// scores at or under the threshold can not enter a full top k
+	threshold := func() float32 {
+		if len(top) < k {
+			return -1
+		}
+		return top[0].Score
+	}
+	// cursors[essential:] drive the walk, the others are probed
+	essential := 0
+	for steps := 0; ; steps++ {
+		if steps%searchCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		found := false
+		var doc uint64
+		for _, c := range cursors[essential:] {
+			if !c.done() && (!found || c.id() < doc) {
+				doc = c.id()
+				found = true
+			}
+		}
+		if !found {
+			break
+		}
+		var score float32
+		for _, c := range cursors[essential:] {
+			if !c.done() && c.id() == doc {
+				score += c.weight * c.postings[c.pos].value
+				c.pos++
+			}
+		}
+		for i := essential - 1; i >= 0; i-- {
+			if score+bounds[i] <= threshold() {
+				break
+			}
+			c := cursors[i]
+			c.seek(doc)
+			if !c.done() && c.id() == doc {
+				score += c.weight * c.postings[c.pos].value
+			}
+		}
+		if score <= threshold() {
+			continue
+		}
+		if len(top) == k {
+			heap.Pop(&top)
+		}
+		heap.Push(&top, Result{Id: doc, Score: score})
+		for essential < len(cursors) && bounds[essential] <= threshold() {
+			essential++
+		}
+	}
+
+	results := make([]Result, len(top))
+	for i := len(results) - 1; i >= 0; i-- {
+		results[i] = heap.Pop(&top).(Result)
+	}
+	return results, nil
+}
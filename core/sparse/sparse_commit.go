@@ -0,0 +1,71 @@
+package sparse
+
+import (
+	"encoding/binary"
+	"io"
+	"sort"
+)
+
+// Commit writes the vectors by id, the postings are rebuilt from them on Load.
+func (xx *Index) Commit(w io.Writer) error {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	ids := make([]uint64, 0, len(xx.docs))
+	for id := range xx.docs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if err := binary.Write(w, binary.BigEndian, uint64(len(ids))); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		vector := xx.docs[id]
+		if err := binary.Write(w, binary.BigEndian, id); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, uint32(vector.Len())); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, vector.Indices); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, vector.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx *Index) Load(r io.Reader) error {
+	var count uint64
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+	for i := uint64(0); i < count; i++ {
+		var (
+			id   uint64
+			size uint32
+		)
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		vector := Vector{
+			Indices: make([]uint32, size),
+			Values:  make([]float32, size),
+		}
+		if err := binary.Read(r, binary.BigEndian, vector.Indices); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.BigEndian, vector.Values); err != nil {
+			return err
+		}
+		if err := xx.Insert(id, vector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
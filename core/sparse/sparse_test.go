@@ -0,0 +1,82 @@
+package sparse
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomSparse(vocab, terms int) Vector {
+	vector := Vector{}
+	seen := make(map[uint32]struct{}, terms)
+	for len(vector.Indices) < terms {
+		index := uint32(rand.IntN(vocab))
+		if _, ok := seen[index]; ok {
+			continue
+		}
+		seen[index] = struct{}{}
+		vector.Indices = append(vector.Indices, index)
+		vector.Values = append(vector.Values, rand.Float32())
+	}
+	return vector
+}
+
+func bruteForce(index *Index, query Vector, k int) []Result {
+	results := make([]Result, 0, index.Len())
+	for id := range index.docs {
+		results = append(results, Result{Id: id, Score: index.Score(id, query)})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results[:k]
+}
+
+func TestSparseMaxScoreMatchesBruteForce(t *testing.T) {
+	index := NewIndex()
+	// a shuffled insert order checks the sorted postings
+	ids := rand.Perm(2000)
+	for _, id := range ids {
+		assert.Nil(t, index.Insert(uint64(id), randomSparse(300, 20)))
+	}
+	assert.Equal(t, ErrAlreadyExists, index.Insert(5, randomSparse(300, 3)))
+	for i := 0; i < 200; i++ {
+		assert.Nil(t, index.Remove(uint64(i)))
+	}
+	assert.Equal(t, ErrNotFound, index.Remove(3))
+
+	for q := 0; q < 20; q++ {
+		query := randomSparse(300, 8)
+		results, err := index.Search(context.Background(), query, 10)
+		assert.Nil(t, err)
+		truth := bruteForce(index, query, 10)
+		assert.Len(t, results, 10)
+		for i := range truth {
+			assert.InDelta(t, truth[i].Score, results[i].Score, 1e-4)
+			assert.GreaterOrEqual(t, results[i].Id, uint64(200))
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	loaded := NewIndex()
+	assert.Nil(t, loaded.Load(&buf))
+	assert.Equal(t, index.Len(), loaded.Len())
+	query := randomSparse(300, 8)
+	a, _ := index.Search(context.Background(), query, 5)
+	b, _ := loaded.Search(context.Background(), query, 5)
+	assert.Equal(t, a, b)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := index.Search(ctx, query, 5)
+	assert.Equal(t, context.Canceled, err)
+
+	assert.Equal(t, ErrDuplicate, Vector{Indices: []uint32{1, 1}, Values: []float32{1, 2}}.Validate())
+	assert.Equal(t, ErrNegative, Vector{Indices: []uint32{1}, Values: []float32{-1}}.Validate())
+	assert.Equal(t, ErrMalformed, Vector{Indices: []uint32{1}}.Validate())
+}
@@ -0,0 +1,573 @@
+package vamana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/fs"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+const noEntrypoint int64 = -1
+
+// tombstones are folded into the graph once they outnumber the live nodes
+const consolidateMin = 256
+
+type nodeState uint8
+
+const (
+	nodeLive nodeState = iota
+	// removed, still navigable until the next consolidation
+	nodeDeleted
+	// consolidated, nothing links to it and the slot can be reused
+	nodeFree
+)
+
+type node struct {
+	id       uint64
+	state    nodeState
+	metadata vectorindex.Metadata
+}
+
+/* Vamana is a DiskANN style graph index for collections that do not fit in
+ * memory. Full vectors and neighbour lists live in fixed-size blocks of the
+ * block file, memory only holds ids, metadata and the PQ codes used to steer
+ * the search. A search walks the graph with a beam of blocks per round, ranks
+ * unread neighbours by their PQ distance and reranks every block it read with
+ * the full precision vector.
+ *
+ * Inserts follow FreshDiskANN: a greedy search from the entrypoint, a robust
+ * prune of the visited nodes and back edges pruned the same way. Removes leave
+ * a tombstone which is unlinked by consolidate.
+ * Mutations are serialized, searches run concurrently between them. */
+type Vamana struct {
+	dim       uint
+	distancer distance.Space
+	kind      distanceKind
+	config    *vamanaConfig
+
+	lock       sync.RWMutex
+	blocks     *blockFile
+	nodes      []node
+	ids        map[uint64]uint32
+	free       []uint32
+	deleted    int
+	entrypoint int64
+
+	// nil until the collection holds pqTrainSize vectors
+	pq    *codebook
+	codes []byte
+}
+
+func NewVamana(file fs.File, dim uint, distancer distance.Space, option ...VamanaOption) *Vamana {
+	config := newVamanaConfig(int(dim), option)
+	return &Vamana{
+		dim:        dim,
+		distancer:  distancer,
+		kind:       distanceKindOf(distancer.Type()),
+		config:     config,
+		blocks:     newBlockFile(file, int(dim), config.maxDegree),
+		ids:        make(map[uint64]uint32),
+		entrypoint: noEntrypoint,
+	}
+}
+
+func (xx *Vamana) Info() string {
+	return fmt.Sprintf("Vamana(dim: %d, distancer: %s, config={%s})", xx.dim, xx.distancer.Type(), xx.config)
+}
+
+func (xx *Vamana) Dim() uint32 {
+	return uint32(xx.dim)
+}
+
+func (xx *Vamana) Len() int {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	return len(xx.ids)
+}
+
+func (xx *Vamana) Config() ProtoConfig {
+	return ProtoConfig{
+		MaxDegree:      xx.config.maxDegree,
+		BuildListSize:  xx.config.buildListSize,
+		SearchListSize: xx.config.searchListSize,
+		Alpha:          xx.config.alpha,
+		BeamWidth:      xx.config.beamWidth,
+		PQSubvectors:   xx.config.pqSubvectors,
+	}
+}
+
+func (xx *Vamana) Distance() string {
+	return xx.distancer.Type()
+}
+
+// BytesSize is the size of the block file plus the PQ codes held in memory.
+func (xx *Vamana) BytesSize() uint64 {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	return uint64(len(xx.nodes))*uint64(xx.blocks.size) + uint64(len(xx.codes))
+}
+
+func (xx *Vamana) Close() error {
+	return xx.blocks.file.Close()
+}
+
+func (xx *Vamana) Metadata(id uint64) (vectorindex.Metadata, error) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	slot, ok := xx.ids[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	return xx.nodes[slot].metadata, nil
+}
+
+// Get reads the stored vector of an id from its block.
+func (xx *Vamana) Get(id uint64) (gomath.Vector, error) {
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	slot, ok := xx.ids[id]
+	if !ok {
+		return nil, vectorindex.ItemNotFoundError
+	}
+	b := xx.blocks.newBlock()
+	if err := xx.blocks.read(slot, b, make([]byte, xx.blocks.size)); err != nil {
+		return nil, err
+	}
+	return b.vector, nil
+}
+
+func (xx *Vamana) Insert(id uint64, value gomath.Vector, metadata vectorindex.Metadata) error {
+	if len(value) != int(xx.dim) {
+		return fmt.Errorf("vector dimension %d, expected %d", len(value), xx.dim)
+	}
+	if xx.distancer.Type() == "cosine-dot" {
+		value = vectorindex.Normalize(value)
+	}
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	if _, ok := xx.ids[id]; ok {
+		return vectorindex.ItemAlreadyExistsError
+	}
+
+	buf := make([]byte, xx.blocks.size)
+	b := &block{id: id, vector: value}
+	if xx.entrypoint == noEntrypoint || len(xx.ids) == 0 {
+		// tombstones left behind stay unreachable until consolidated
+		slot := xx.allocSlot(id, metadata, value)
+		if err := xx.blocks.write(slot, b, buf); err != nil {
+			xx.releaseSlot(slot)
+			return err
+		}
+		xx.entrypoint = int64(slot)
+		return nil
+	}
+
+	visited, err := xx.beamSearch(context.Background(), value, xx.config.buildListSize, true)
+	if err != nil {
+		return err
+	}
+	slot := xx.allocSlot(id, metadata, value)
+	b.neighbors = xx.robustPrune(slot, visited)
+	if err := xx.blocks.write(slot, b, buf); err != nil {
+		xx.releaseSlot(slot)
+		return err
+	}
+	for _, neighbor := range b.neighbors {
+		if err := xx.addBackEdge(neighbor, slot, value, buf); err != nil {
+			return err
+		}
+	}
+
+	if xx.pq == nil && len(xx.ids) >= xx.config.pqTrainSize {
+		return xx.trainCodebook()
+	}
+	return nil
+}
+
+func (xx *Vamana) Remove(id uint64) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	slot, ok := xx.ids[id]
+	if !ok {
+		return vectorindex.ItemNotFoundError
+	}
+	delete(xx.ids, id)
+	xx.nodes[slot].state = nodeDeleted
+	xx.nodes[slot].metadata = nil
+	xx.deleted++
+	if xx.deleted >= consolidateMin && xx.deleted > len(xx.ids) {
+		return xx.consolidate()
+	}
+	return nil
+}
+
+func (xx *Vamana) Search(ctx context.Context, query gomath.Vector, k uint) (vectorindex.SearchResult, error) {
+	if xx.distancer.Type() == "cosine-dot" {
+		query = vectorindex.Normalize(query)
+	}
+	xx.lock.RLock()
+	defer xx.lock.RUnlock()
+	if xx.entrypoint == noEntrypoint || len(xx.ids) == 0 {
+		return make(vectorindex.SearchResult, 0), nil
+	}
+
+	listSize := gomath.MaxInt(xx.config.searchListSize, int(k))
+	visited, err := xx.beamSearch(ctx, query, listSize, false)
+	if err != nil {
+		return nil, err
+	}
+	// every read block already carries its full vector, rerank by it
+	sort.Slice(visited, func(i, j int) bool {
+		return visited[i].dist < visited[j].dist
+	})
+	result := make(vectorindex.SearchResult, 0, k)
+	for _, v := range visited {
+		if len(result) == int(k) {
+			break
+		}
+		node := xx.nodes[v.slot]
+		if node.state != nodeLive {
+			continue
+		}
+		result = append(result, vectorindex.SearchResultItem{
+			Id:       node.id,
+			Metadata: node.metadata,
+			Score:    v.dist,
+		})
+	}
+	return result, nil
+}
+
+// allocSlot takes a consolidated slot or appends one, the caller holds the write lock.
+func (xx *Vamana) allocSlot(id uint64, metadata vectorindex.Metadata, vector []float32) uint32 {
+	var slot uint32
+	if n := len(xx.free); n > 0 {
+		slot = xx.free[n-1]
+		xx.free = xx.free[:n-1]
+	} else {
+		slot = uint32(len(xx.nodes))
+		xx.nodes = append(xx.nodes, node{})
+		if xx.pq != nil {
+			xx.codes = append(xx.codes, make([]byte, xx.pq.subvectors)...)
+		}
+	}
+	xx.nodes[slot] = node{id: id, state: nodeLive, metadata: metadata}
+	xx.ids[id] = slot
+	if xx.pq != nil {
+		xx.pq.encode(vector, xx.code(slot))
+	}
+	return slot
+}
+
+// releaseSlot undoes allocSlot when the block could not be written.
+func (xx *Vamana) releaseSlot(slot uint32) {
+	delete(xx.ids, xx.nodes[slot].id)
+	xx.nodes[slot] = node{state: nodeFree}
+	xx.free = append(xx.free, slot)
+}
+
+func (xx *Vamana) code(slot uint32) []byte {
+	m := xx.pq.subvectors
+	return xx.codes[int(slot)*m : int(slot+1)*m]
+}
+
+// visitedNode is a node whose block was read, dist is the full precision distance.
+type visitedNode struct {
+	slot   uint32
+	dist   float32
+	vector []float32
+}
+
+type candidate struct {
+	slot     uint32
+	dist     float32
+	expanded bool
+}
+
+/* beamSearch keeps the listSize closest candidates found so far and reads the
+ * blocks of up to beamWidth unexpanded ones per round until every candidate
+ * has been expanded. Candidates are ranked by PQ distance once the codebook is
+ * trained, by the full vector read from disk before that. It returns every
+ * node it read, including tombstones, with vectors when keepVectors is set.
+ * The caller holds the lock. */
+func (xx *Vamana) beamSearch(ctx context.Context, query []float32, listSize int, keepVectors bool) ([]visitedNode, error) {
+	buf := make([]byte, xx.blocks.size)
+	b := xx.blocks.newBlock()
+	approx := func(slot uint32) (float32, error) {
+		if err := xx.blocks.read(slot, b, buf); err != nil {
+			return 0, err
+		}
+		return xx.distancer.Distance(query, b.vector), nil
+	}
+	if xx.pq != nil {
+		table := xx.pq.table(query, xx.kind)
+		approx = func(slot uint32) (float32, error) {
+			return scoreCode(table, xx.code(slot)), nil
+		}
+	}
+
+	seen := make([]uint64, (len(xx.nodes)+63)/64)
+	visit := func(slot uint32) bool {
+		word, bit := slot/64, uint64(1)<<(slot%64)
+		if seen[word]&bit != 0 {
+			return true
+		}
+		seen[word] |= bit
+		return false
+	}
+
+	entrypoint := uint32(xx.entrypoint)
+	visit(entrypoint)
+	dist, err := approx(entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]candidate, 1, listSize+1)
+	candidates[0] = candidate{slot: entrypoint, dist: dist}
+
+	visited := make([]visitedNode, 0, listSize*2)
+	beam := make([]uint32, 0, xx.config.beamWidth)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		beam = beam[:0]
+		for i := range candidates {
+			if !candidates[i].expanded {
+				candidates[i].expanded = true
+				beam = append(beam, candidates[i].slot)
+				if len(beam) == xx.config.beamWidth {
+					break
+				}
+			}
+		}
+		if len(beam) == 0 {
+			break
+		}
+		for _, slot := range beam {
+			if err := xx.blocks.read(slot, b, buf); err != nil {
+				return nil, err
+			}
+			v := visitedNode{slot: slot, dist: xx.distancer.Distance(query, b.vector)}
+			if keepVectors {
+				v.vector = append([]float32(nil), b.vector...)
+			}
+			visited = append(visited, v)
+			// b is reused by approx before pq is trained
+			neighbors := append([]uint32(nil), b.neighbors...)
+			for _, neighbor := range neighbors {
+				// a block written after the last commit may point past a reloaded graph
+				if int(neighbor) >= len(xx.nodes) || xx.nodes[neighbor].state == nodeFree || visit(neighbor) {
+					continue
+				}
+				dist, err := approx(neighbor)
+				if err != nil {
+					return nil, err
+				}
+				if len(candidates) == listSize && dist >= candidates[len(candidates)-1].dist {
+					continue
+				}
+				pos := sort.Search(len(candidates), func(i int) bool {
+					return candidates[i].dist > dist
+				})
+				candidates = append(candidates, candidate{})
+				copy(candidates[pos+1:], candidates[pos:])
+				candidates[pos] = candidate{slot: neighbor, dist: dist}
+				if len(candidates) > listSize {
+					candidates = candidates[:listSize]
+				}
+			}
+		}
+	}
+	return visited, nil
+}
+
+/* robustPrune picks at most maxDegree live neighbours for self out of pool,
+ * whose distances are to self. A candidate is dropped when an already picked
+ * neighbour is alpha times closer to it than self is, which keeps the long
+ * edges the greedy search needs. */
+func (xx *Vamana) robustPrune(self uint32, pool []visitedNode) []uint32 {
+	sort.Slice(pool, func(i, j int) bool {
+		return pool[i].dist < pool[j].dist
+	})
+	alpha := xx.config.alpha
+	if xx.kind == kindDot && xx.distancer.Type() != "cosine-dot" {
+		// the slack assumes non-negative distances
+		alpha = 1
+	}
+	pruned := make([]bool, len(pool))
+	neighbors := make([]uint32, 0, xx.config.maxDegree)
+	for i := range pool {
+		if pruned[i] || pool[i].slot == self || xx.nodes[pool[i].slot].state != nodeLive {
+			continue
+		}
+		neighbors = append(neighbors, pool[i].slot)
+		if len(neighbors) == xx.config.maxDegree {
+			break
+		}
+		for j := i + 1; j < len(pool); j++ {
+			if !pruned[j] && alpha*xx.distancer.Distance(pool[i].vector, pool[j].vector) <= pool[j].dist {
+				pruned[j] = true
+			}
+		}
+	}
+	return neighbors
+}
+
+// addBackEdge links neighbor to slot, pruning the neighbour list when it is full.
+func (xx *Vamana) addBackEdge(neighbor, slot uint32, vector []float32, buf []byte) error {
+	nb := xx.blocks.newBlock()
+	if err := xx.blocks.read(neighbor, nb, buf); err != nil {
+		return err
+	}
+	for _, n := range nb.neighbors {
+		if n == slot {
+			return nil
+		}
+	}
+	if len(nb.neighbors) < xx.config.maxDegree {
+		nb.neighbors = append(nb.neighbors, slot)
+		return xx.blocks.write(neighbor, nb, buf)
+	}
+
+	pool, err := xx.readPool(nb.vector, nb.neighbors, buf)
+	if err != nil {
+		return err
+	}
+	pool = append(pool, visitedNode{slot: slot, dist: xx.distancer.Distance(nb.vector, vector), vector: vector})
+	nb.neighbors = append(nb.neighbors[:0], xx.robustPrune(neighbor, pool)...)
+	return xx.blocks.write(neighbor, nb, buf)
+}
+
+// readPool reads the blocks of slots with their distances to center.
+func (xx *Vamana) readPool(center []float32, slots []uint32, buf []byte) ([]visitedNode, error) {
+	pool := make([]visitedNode, 0, len(slots)+1)
+	b := xx.blocks.newBlock()
+	for _, slot := range slots {
+		if int(slot) >= len(xx.nodes) || xx.nodes[slot].state != nodeLive {
+			continue
+		}
+		if err := xx.blocks.read(slot, b, buf); err != nil {
+			return nil, err
+		}
+		vector := append([]float32(nil), b.vector...)
+		pool = append(pool, visitedNode{slot: slot, dist: xx.distancer.Distance(center, vector), vector: vector})
+	}
+	return pool, nil
+}
+
+// trainCodebook fits the PQ codebook on the live vectors and encodes every slot.
+func (xx *Vamana) trainCodebook() error {
+	buf := make([]byte, xx.blocks.size)
+	samples := make([][]float32, 0, xx.config.pqTrainSize)
+	for slot := range xx.nodes {
+		if xx.nodes[slot].state != nodeLive {
+			continue
+		}
+		b := xx.blocks.newBlock()
+		if err := xx.blocks.read(uint32(slot), b, buf); err != nil {
+			return err
+		}
+		samples = append(samples, b.vector)
+		if len(samples) == xx.config.pqTrainSize {
+			break
+		}
+	}
+	pq := trainCodebook(samples, xx.config.pqSubvectors)
+	codes := make([]byte, len(xx.nodes)*pq.subvectors)
+	b := xx.blocks.newBlock()
+	for slot := range xx.nodes {
+		if xx.nodes[slot].state == nodeFree {
+			continue
+		}
+		if err := xx.blocks.read(uint32(slot), b, buf); err != nil {
+			return err
+		}
+		pq.encode(b.vector, codes[slot*pq.subvectors:(slot+1)*pq.subvectors])
+	}
+	xx.pq, xx.codes = pq, codes
+	return nil
+}
+
+/* consolidate unlinks the tombstones. A live node pointing at a deleted one
+ * inherits that node's live neighbours and is pruned back to maxDegree, the
+ * deleted slots are then free for reuse. It reads every live block once. */
+func (xx *Vamana) consolidate() error {
+	if xx.deleted == 0 {
+		return nil
+	}
+	buf := make([]byte, xx.blocks.size)
+	b := xx.blocks.newBlock()
+	deletedBlock := xx.blocks.newBlock()
+	entrypoint := noEntrypoint
+	for slot := range xx.nodes {
+		if xx.nodes[slot].state != nodeLive {
+			continue
+		}
+		if entrypoint == noEntrypoint {
+			entrypoint = int64(slot)
+		}
+		if err := xx.blocks.read(uint32(slot), b, buf); err != nil {
+			return err
+		}
+		changed := false
+		merged := make([]uint32, 0, len(b.neighbors))
+		seen := map[uint32]struct{}{uint32(slot): {}}
+		for _, n := range b.neighbors {
+			if int(n) < len(xx.nodes) && xx.nodes[n].state == nodeLive {
+				if _, ok := seen[n]; !ok {
+					seen[n] = struct{}{}
+					merged = append(merged, n)
+				}
+				continue
+			}
+			changed = true
+			if int(n) >= len(xx.nodes) || xx.nodes[n].state != nodeDeleted {
+				continue
+			}
+			if err := xx.blocks.read(n, deletedBlock, buf); err != nil {
+				return err
+			}
+			for _, nn := range deletedBlock.neighbors {
+				if int(nn) >= len(xx.nodes) || xx.nodes[nn].state != nodeLive {
+					continue
+				}
+				if _, ok := seen[nn]; !ok {
+					seen[nn] = struct{}{}
+					merged = append(merged, nn)
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+		if len(merged) > xx.config.maxDegree {
+			pool, err := xx.readPool(b.vector, merged, buf)
+			if err != nil {
+				return err
+			}
+			merged = xx.robustPrune(uint32(slot), pool)
+		}
+		b.neighbors = append(b.neighbors[:0], merged...)
+		if err := xx.blocks.write(uint32(slot), b, buf); err != nil {
+			return err
+		}
+	}
+
+	for slot := range xx.nodes {
+		if xx.nodes[slot].state == nodeDeleted {
+			xx.nodes[slot] = node{state: nodeFree}
+			xx.free = append(xx.free, uint32(slot))
+		}
+	}
+	xx.deleted = 0
+	if xx.entrypoint == noEntrypoint || xx.nodes[xx.entrypoint].state != nodeLive {
+		xx.entrypoint = entrypoint
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package vamana
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/sjy-dv/nnv/pkg/fs"
+)
+
+/* Every node owns one fixed-size block in the block file, addressed by its
+ * slot, so a node is a single ReadAt away:
+ *
+ *	| id uint64 | vector dim x float32 | degree uint32 | maxDegree x uint32 neighbour slots |
+ *
+ * Reading a block to follow its neighbours also brings the full vector, the
+ * search keeps those for the final rerank. */
+type blockFile struct {
+	file      fs.File
+	dim       int
+	maxDegree int
+	size      int
+}
+
+func newBlockFile(file fs.File, dim, maxDegree int) *blockFile {
+	return &blockFile{
+		file:      file,
+		dim:       dim,
+		maxDegree: maxDegree,
+		size:      8 + 4*dim + 4 + 4*maxDegree,
+	}
+}
+
+// block is a decoded node block, the neighbours slice has maxDegree capacity.
+type block struct {
+	id        uint64
+	vector    []float32
+	neighbors []uint32
+}
+
+func (xx *blockFile) newBlock() *block {
+	return &block{
+		vector:    make([]float32, xx.dim),
+		neighbors: make([]uint32, 0, xx.maxDegree),
+	}
+}
+
+func (xx *blockFile) offset(slot uint32) int64 {
+	return int64(slot) * int64(xx.size)
+}
+
+// read decodes the block of a slot into dst, buf must hold one block.
+func (xx *blockFile) read(slot uint32, dst *block, buf []byte) error {
+	if _, err := xx.file.ReadAt(buf[:xx.size], xx.offset(slot)); err != nil {
+		return err
+	}
+	dst.id = binary.LittleEndian.Uint64(buf)
+	pos := 8
+	for i := range dst.vector {
+		dst.vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[pos:]))
+		pos += 4
+	}
+	degree := int(binary.LittleEndian.Uint32(buf[pos:]))
+	pos += 4
+	if degree > xx.maxDegree {
+		degree = xx.maxDegree
+	}
+	dst.neighbors = dst.neighbors[:degree]
+	for i := range dst.neighbors {
+		dst.neighbors[i] = binary.LittleEndian.Uint32(buf[pos:])
+		pos += 4
+	}
+	return nil
+}
+
+// write encodes src into the block of a slot, the unused neighbour tail is zeroed.
+func (xx *blockFile) write(slot uint32, src *block, buf []byte) error {
+	buf = buf[:xx.size]
+	binary.LittleEndian.PutUint64(buf, src.id)
+	pos := 8
+	for _, v := range src.vector {
+		binary.LittleEndian.PutUint32(buf[pos:], math.Float32bits(v))
+		pos += 4
+	}
+	binary.LittleEndian.PutUint32(buf[pos:], uint32(len(src.neighbors)))
+	pos += 4
+	for _, n := range src.neighbors {
+		binary.LittleEndian.PutUint32(buf[pos:], n)
+		pos += 4
+	}
+	clear(buf[pos:])
+	_, err := xx.file.WriteAt(buf, xx.offset(slot))
+	return err
+}
@@ -0,0 +1,159 @@
+package vamana
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+/* Commit folds the tombstones into the graph, syncs the block file and writes
+ * the in-memory part of the index: config, entrypoint, one record per slot
+ * and the PQ codebook with its codes. The blocks themselves are never copied,
+ * Load has to be given the same block file. */
+func (xx *Vamana) Commit(w io.Writer) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	if err := xx.consolidate(); err != nil {
+		return err
+	}
+	if err := xx.blocks.file.Sync(); err != nil {
+		return err
+	}
+
+	if err := xx.config.save(w); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(xx.dim)); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, xx.entrypoint); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(xx.nodes))); err != nil {
+		return err
+	}
+	for _, node := range xx.nodes {
+		if err := binary.Write(w, binary.BigEndian, node.state); err != nil {
+			return err
+		}
+		if node.state == nodeFree {
+			continue
+		}
+		if err := binary.Write(w, binary.BigEndian, node.id); err != nil {
+			return err
+		}
+		metadata, err := msgpack.Marshal(node.metadata)
+		if err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, uint32(len(metadata))); err != nil {
+			return err
+		}
+		if _, err := w.Write(metadata); err != nil {
+			return err
+		}
+	}
+
+	if xx.pq == nil {
+		return binary.Write(w, binary.BigEndian, uint8(0))
+	}
+	if err := binary.Write(w, binary.BigEndian, uint8(1)); err != nil {
+		return err
+	}
+	if err := xx.pq.save(w); err != nil {
+		return err
+	}
+	_, err := w.Write(xx.codes)
+	return err
+}
+
+func (xx *Vamana) Load(r io.Reader) error {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+
+	config := &vamanaConfig{}
+	if err := config.load(r); err != nil {
+		return err
+	}
+	var dim, slots uint32
+	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+		return err
+	}
+	if dim != uint32(xx.dim) {
+		return fmt.Errorf("vamana dimension %d, expected %d", dim, xx.dim)
+	}
+	var entrypoint int64
+	if err := binary.Read(r, binary.BigEndian, &entrypoint); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.BigEndian, &slots); err != nil {
+		return err
+	}
+
+	nodes := make([]node, slots)
+	ids := make(map[uint64]uint32, slots)
+	free := make([]uint32, 0)
+	deleted := 0
+	for slot := range nodes {
+		if err := binary.Read(r, binary.BigEndian, &nodes[slot].state); err != nil {
+			return err
+		}
+		switch nodes[slot].state {
+		case nodeFree:
+			free = append(free, uint32(slot))
+			continue
+		case nodeDeleted:
+			deleted++
+		}
+		if err := binary.Read(r, binary.BigEndian, &nodes[slot].id); err != nil {
+			return err
+		}
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return err
+		}
+		metadata := vectorindex.Metadata{}
+		if err := msgpack.Unmarshal(raw, &metadata); err != nil {
+			return err
+		}
+		nodes[slot].metadata = metadata
+		if nodes[slot].state == nodeLive {
+			ids[nodes[slot].id] = uint32(slot)
+		}
+	}
+
+	var trained uint8
+	if err := binary.Read(r, binary.BigEndian, &trained); err != nil {
+		return err
+	}
+	var pq *codebook
+	var codes []byte
+	if trained == 1 {
+		pq = &codebook{}
+		if err := pq.load(r); err != nil {
+			return err
+		}
+		codes = make([]byte, int(slots)*pq.subvectors)
+		if _, err := io.ReadFull(r, codes); err != nil {
+			return err
+		}
+	}
+
+	blocks := newBlockFile(xx.blocks.file, int(dim), config.maxDegree)
+	if need := int64(slots) * int64(blocks.size); blocks.file.Size() < need {
+		return fmt.Errorf("vamana block file holds %d bytes, expected at least %d", blocks.file.Size(), need)
+	}
+	xx.config = config
+	xx.blocks = blocks
+	xx.nodes, xx.ids, xx.free, xx.deleted = nodes, ids, free, deleted
+	xx.entrypoint = entrypoint
+	xx.pq, xx.codes = pq, codes
+	return nil
+}
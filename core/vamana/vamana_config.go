@@ -0,0 +1,166 @@
+package vamana
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Options
+type VamanaOption interface {
+	apply(*vamanaConfig)
+}
+
+type vamanaOption struct {
+	applyFunc func(*vamanaConfig)
+}
+
+func (opt *vamanaOption) apply(config *vamanaConfig) {
+	opt.applyFunc(config)
+}
+
+// VamanaMaxDegree is the out degree R of every node, it fixes the block size.
+func VamanaMaxDegree(value int) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.maxDegree = value
+	}}
+}
+
+// VamanaBuildListSize is the candidate list L used while inserting.
+func VamanaBuildListSize(value int) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.buildListSize = value
+	}}
+}
+
+// VamanaSearchListSize is the candidate list L used while searching, raised to k when smaller.
+func VamanaSearchListSize(value int) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.searchListSize = value
+	}}
+}
+
+// VamanaAlpha is the robust prune slack, values above 1 keep longer edges.
+func VamanaAlpha(value float32) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.alpha = value
+	}}
+}
+
+// VamanaBeamWidth is the number of blocks read per search round.
+func VamanaBeamWidth(value int) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.beamWidth = value
+	}}
+}
+
+// VamanaPQSubvectors is the number of PQ bytes kept in memory per vector,
+// 0 picks dim/4 rounded down to a divisor of dim.
+func VamanaPQSubvectors(value int) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.pqSubvectors = value
+	}}
+}
+
+// VamanaPQTrainSize is the number of vectors needed before the codebook is trained,
+// smaller collections navigate with full vectors read from disk.
+func VamanaPQTrainSize(value int) VamanaOption {
+	return &vamanaOption{func(config *vamanaConfig) {
+		config.pqTrainSize = value
+	}}
+}
+
+type vamanaConfig struct {
+	maxDegree      int
+	buildListSize  int
+	searchListSize int
+	alpha          float32
+	beamWidth      int
+	pqSubvectors   int
+	pqTrainSize    int
+}
+
+type ProtoConfig struct {
+	MaxDegree      int
+	BuildListSize  int
+	SearchListSize int
+	Alpha          float32
+	BeamWidth      int
+	PQSubvectors   int
+}
+
+func newVamanaConfig(dim int, options []VamanaOption) *vamanaConfig {
+	config := &vamanaConfig{
+		maxDegree:      64,
+		buildListSize:  100,
+		searchListSize: 64,
+		alpha:          1.2,
+		beamWidth:      4,
+		pqSubvectors:   0,
+		pqTrainSize:    2048,
+	}
+	for _, option := range options {
+		option.apply(config)
+	}
+	config.pqSubvectors = pqSubvectors(dim, config.pqSubvectors)
+	return config
+}
+
+// pqSubvectors returns the largest divisor of dim not above want.
+func pqSubvectors(dim, want int) int {
+	if want <= 0 {
+		want = dim / 4
+	}
+	if want > dim {
+		want = dim
+	}
+	for m := want; m > 1; m-- {
+		if dim%m == 0 {
+			return m
+		}
+	}
+	return 1
+}
+
+func (this *vamanaConfig) String() string {
+	return fmt.Sprintf(
+		"maxDegree: %d, buildListSize: %d, searchListSize: %d, alpha: %.2f, beamWidth: %d, pqSubvectors: %d, pqTrainSize: %d",
+		this.maxDegree,
+		this.buildListSize,
+		this.searchListSize,
+		this.alpha,
+		this.beamWidth,
+		this.pqSubvectors,
+		this.pqTrainSize,
+	)
+}
+
+func (this *vamanaConfig) save(w io.Writer) error {
+	for _, v := range []int32{
+		int32(this.maxDegree),
+		int32(this.buildListSize),
+		int32(this.searchListSize),
+		int32(this.beamWidth),
+		int32(this.pqSubvectors),
+		int32(this.pqTrainSize),
+	} {
+		if err := binary.Write(w, binary.BigEndian, v); err != nil {
+			return err
+		}
+	}
+	return binary.Write(w, binary.BigEndian, this.alpha)
+}
+
+func (this *vamanaConfig) load(r io.Reader) error {
+	values := make([]int32, 6)
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return err
+	}
+	this.maxDegree = int(values[0])
+	this.buildListSize = int(values[1])
+	this.searchListSize = int(values[2])
+	this.beamWidth = int(values[3])
+	this.pqSubvectors = int(values[4])
+	this.pqTrainSize = int(values[5])
+	return binary.Read(r, binary.BigEndian, &this.alpha)
+}
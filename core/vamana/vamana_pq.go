@@ -0,0 +1,171 @@
+package vamana
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/sjy-dv/nnv/pkg/hnswpq"
+)
+
+const (
+	pqCentroids = 256
+	pqMaxIter   = 16
+)
+
+/* codebook is the in-memory product quantizer, each vector is kept as one
+ * centroid byte per subvector. Search only needs the codes to rank
+ * candidates, the full vectors stay on disk until the rerank. */
+type codebook struct {
+	subvectors int
+	subLen     int
+	// subvector x centroid x subLen
+	centroids [][][]float32
+}
+
+func trainCodebook(samples [][]float32, subvectors int) *codebook {
+	dim := len(samples[0])
+	cb := &codebook{
+		subvectors: subvectors,
+		subLen:     dim / subvectors,
+		centroids:  make([][][]float32, subvectors),
+	}
+	k := min(pqCentroids, len(samples))
+	for i := 0; i < subvectors; i++ {
+		km := hnswpq.KMeans{K: k, MaxIter: pqMaxIter, Offset: i * cb.subLen, VectorLen: cb.subLen}
+		km.Fit(samples)
+		cb.centroids[i] = km.Centroids
+	}
+	return cb
+}
+
+// encode writes the closest centroid of every subvector into code.
+func (xx *codebook) encode(vector []float32, code []byte) {
+	for i, centroids := range xx.centroids {
+		sub := vector[i*xx.subLen : (i+1)*xx.subLen]
+		best, bestDist := 0, float32(0)
+		for c, centroid := range centroids {
+			d := squaredL2(sub, centroid)
+			if c == 0 || d < bestDist {
+				best, bestDist = c, d
+			}
+		}
+		code[i] = byte(best)
+	}
+}
+
+// table precomputes the query to centroid partials, a code is then scored by
+// summing one entry per subvector.
+func (xx *codebook) table(query []float32, kind distanceKind) []float32 {
+	table := make([]float32, xx.subvectors*pqCentroids)
+	for i, centroids := range xx.centroids {
+		sub := query[i*xx.subLen : (i+1)*xx.subLen]
+		for c, centroid := range centroids {
+			table[i*pqCentroids+c] = kind.partial(sub, centroid)
+		}
+	}
+	return table
+}
+
+func scoreCode(table []float32, code []byte) float32 {
+	var score float32
+	for i, c := range code {
+		score += table[i*pqCentroids+int(c)]
+	}
+	return score
+}
+
+func (xx *codebook) save(w io.Writer) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(xx.subvectors)); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(xx.subLen)); err != nil {
+		return err
+	}
+	for _, centroids := range xx.centroids {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(centroids))); err != nil {
+			return err
+		}
+		for _, centroid := range centroids {
+			if err := binary.Write(w, binary.BigEndian, centroid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (xx *codebook) load(r io.Reader) error {
+	var subvectors, subLen, k uint32
+	if err := binary.Read(r, binary.BigEndian, &subvectors); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.BigEndian, &subLen); err != nil {
+		return err
+	}
+	xx.subvectors, xx.subLen = int(subvectors), int(subLen)
+	xx.centroids = make([][][]float32, subvectors)
+	for i := range xx.centroids {
+		if err := binary.Read(r, binary.BigEndian, &k); err != nil {
+			return err
+		}
+		xx.centroids[i] = make([][]float32, k)
+		for c := range xx.centroids[i] {
+			xx.centroids[i][c] = make([]float32, subLen)
+			if err := binary.Read(r, binary.BigEndian, xx.centroids[i][c]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// distanceKind says how subvector partials add up to something ordered like
+// the collection distance.
+type distanceKind uint8
+
+const (
+	kindL2 distanceKind = iota
+	kindL1
+	kindDot
+)
+
+func distanceKindOf(distanceType string) distanceKind {
+	switch distanceType {
+	case "manhattan":
+		return kindL1
+	case "cosine-dot", "inner-product":
+		// cosine vectors are normalized, both rank by the negated dot product
+		return kindDot
+	}
+	return kindL2
+}
+
+func (kind distanceKind) partial(a, b []float32) float32 {
+	var sum float32
+	switch kind {
+	case kindL1:
+		for i := range a {
+			d := a[i] - b[i]
+			if d < 0 {
+				d = -d
+			}
+			sum += d
+		}
+	case kindDot:
+		for i := range a {
+			sum -= a[i] * b[i]
+		}
+	default:
+		sum = squaredL2(a, b)
+	}
+	return sum
+}
+
+func squaredL2(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
@@ -0,0 +1,111 @@
+package vamana
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func bruteForce(vectors map[uint64][]float32, query []float32, k int) []uint64 {
+	dist := distance.NewEuclidean()
+	ids := make([]uint64, 0, len(vectors))
+	for id := range vectors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return dist.Distance(query, vectors[ids[i]]) < dist.Distance(query, vectors[ids[j]])
+	})
+	return ids[:k]
+}
+
+func recall(t *testing.T, index *Vamana, vectors map[uint64][]float32, queries [][]float32, k int) float64 {
+	hits := 0
+	for _, q := range queries {
+		result, err := index.Search(context.Background(), q, uint(k))
+		assert.Nil(t, err)
+		found := make(map[uint64]bool, len(result))
+		for _, item := range result {
+			found[item.Id] = true
+		}
+		for _, id := range bruteForce(vectors, q, k) {
+			if found[id] {
+				hits++
+			}
+		}
+	}
+	return float64(hits) / float64(len(queries)*k)
+}
+
+func TestVamanaSearchCommitAndLoad(t *testing.T) {
+	const dim = 16
+	filename := filepath.Join(t.TempDir(), "blocks")
+	file, err := fs.Open(filename, fs.OSFileSystem)
+	assert.Nil(t, err)
+	options := []VamanaOption{VamanaMaxDegree(24), VamanaBuildListSize(48), VamanaPQTrainSize(500)}
+	index := NewVamana(file, dim, distance.NewEuclidean(), options...)
+
+	vectors := make(map[uint64][]float32)
+	for i := uint64(0); i < 2000; i++ {
+		v := make([]float32, dim)
+		for j := range v {
+			v[j] = rand.Float32()
+		}
+		vectors[i] = v
+		assert.Nil(t, index.Insert(i, v, vectorindex.Metadata{"_id": i}))
+	}
+	assert.NotNil(t, index.pq)
+	assert.Equal(t, vectorindex.ItemAlreadyExistsError, index.Insert(1, vectors[1], nil))
+
+	queries := make([][]float32, 50)
+	for i := range queries {
+		queries[i] = make([]float32, dim)
+		for j := range queries[i] {
+			queries[i][j] = rand.Float32()
+		}
+	}
+	assert.Greater(t, recall(t, index, vectors, queries, 10), 0.9)
+
+	for i := uint64(0); i < 2000; i += 4 {
+		assert.Nil(t, index.Remove(i))
+		delete(vectors, i)
+	}
+	assert.Equal(t, 1500, index.Len())
+	result, err := index.Search(context.Background(), vectors[5], 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), result[0].Id)
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf))
+	assert.Len(t, index.free, 500)
+	assert.Nil(t, index.Close())
+
+	file, err = fs.Open(filename, fs.OSFileSystem)
+	assert.Nil(t, err)
+	loaded := NewVamana(file, dim, distance.NewEuclidean())
+	assert.Nil(t, loaded.Load(&buf))
+	defer loaded.Close()
+	assert.Equal(t, 1500, loaded.Len())
+	assert.Equal(t, 24, loaded.Config().MaxDegree)
+	assert.Greater(t, recall(t, loaded, vectors, queries, 10), 0.9)
+
+	metadata, err := loaded.Metadata(7)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 7, metadata["_id"])
+	_, err = loaded.Get(8)
+	assert.Equal(t, vectorindex.ItemNotFoundError, err)
+
+	// freed slots are reused by new inserts
+	assert.Nil(t, loaded.Insert(5000, vectors[5], nil))
+	assert.Len(t, loaded.free, 499)
+	stored, err := loaded.Get(5000)
+	assert.Nil(t, err)
+	assert.Equal(t, vectors[5], []float32(stored))
+}
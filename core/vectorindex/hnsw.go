@@ -8,9 +8,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-	"unsafe"
 
-	"github.com/sjy-dv/nnv/edge"
 	"github.com/sjy-dv/nnv/pkg/distance"
 	"github.com/sjy-dv/nnv/pkg/gomath"
 	"github.com/sjy-dv/nnv/pkg/sharding"
@@ -18,6 +16,9 @@ import (
 
 const VERTICES_MAP_SHARD_COUNT int = 16
 
+// candidates popped by searchLevel between two looks at the query ctx
+const searchCheckEvery = 64
+
 var (
 	ItemNotFoundError      error = errors.New("Item not found")
 	ItemAlreadyExistsError error = errors.New("Item already exists")
@@ -30,10 +31,17 @@ type Hnsw struct {
 	config    *hnswConfig
 
 	len        uint64
-	vertices   [VERTICES_MAP_SHARD_COUNT]map[uint64]*hnswVertex
+	vertices   [VERTICES_MAP_SHARD_COUNT]map[uint64]uint32
 	verticesMu [VERTICES_MAP_SHARD_COUNT]*sync.RWMutex
-
-	entrypoint unsafe.Pointer
+	arena      *hnswArena
+	visited    sync.Pool
+
+	// slot of the entrypoint, noEntrypoint while the index is empty
+	entrypoint int64
+	// search list size, SetEf changes it while the graph serves searches
+	ef atomic.Int64
+	// set when the config is seeded
+	levels *levelSource
 }
 
 func NewHnsw(dim uint, distancer distance.Space, option ...HnswOption) *Hnsw {
@@ -43,13 +51,18 @@ func NewHnsw(dim uint, distancer distance.Space, option ...HnswOption) *Hnsw {
 		config:    newHnswConfig(option),
 
 		len:        0,
-		entrypoint: nil,
+		entrypoint: noEntrypoint,
 	}
 
 	for i := 0; i < VERTICES_MAP_SHARD_COUNT; i++ {
-		index.vertices[i] = make(map[uint64]*hnswVertex)
+		index.vertices[i] = make(map[uint64]uint32)
 		index.verticesMu[i] = &sync.RWMutex{}
 	}
+	index.arena = newHnswArena(int(dim), index.config.mMax, index.config.mMax0)
+	index.ef.Store(int64(index.config.ef))
+	if index.config.seeded {
+		index.levels = newLevelSource(index.config.seed, 0)
+	}
 
 	return index
 }
@@ -70,177 +83,175 @@ func (xx *Hnsw) Config() ProtoConfig {
 	return ProtoConfig{
 		SearchAlgorithm:           strings.ToLower(xx.config.searchAlgorithm.String()),
 		LevelMultiplier:           xx.config.levelMultiplier,
-		Ef:                        xx.config.ef,
+		Ef:                        int(xx.ef.Load()),
 		EfConstruction:            xx.config.efConstruction,
 		M:                         xx.config.m,
 		MMax:                      xx.config.mMax,
 		MMax0:                     xx.config.mMax0,
 		HeuristicExtendCandidates: xx.config.heuristicExtendCandidates,
 		HeuristicKeepPruned:       xx.config.heuristicKeepPruned,
+		Seed:                      xx.config.seed,
+		Seeded:                    xx.config.seeded,
 	}
 }
 
+// SetEf changes the search list size of the searches that start after it.
+func (xx *Hnsw) SetEf(ef int) {
+	xx.ef.Store(int64(ef))
+}
+
 func (xx *Hnsw) Distance() string {
 	return xx.distancer.Type()
 }
 
-func (xx *Hnsw) Insert(id uint64, value edge.Vector, metadata Metadata, vertexLevel int) error {
+func (xx *Hnsw) Insert(id uint64, value gomath.Vector, metadata Metadata, vertexLevel int) error {
 	if xx.distancer.Type() == "cosine-dot" {
 		value = Normalize(value)
 	}
-	var vertex *hnswVertex
-	if (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint)) == nil {
-		vertex = newHnswVertex(id, value, metadata, 0)
-		if err := xx.storeVertex(vertex); err != nil {
-			return err
-		}
-		if atomic.CompareAndSwapPointer(&xx.entrypoint, nil, unsafe.Pointer(vertex)) {
-			return nil
-		} else {
-			vertex.setLevel(vertexLevel)
-		}
-	} else {
-		vertex = newHnswVertex(id, value, metadata, vertexLevel)
-		if err := xx.storeVertex(vertex); err != nil {
-			return err
-		}
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
+	slot, err := xx.storeVertex(id, value, metadata, vertexLevel)
+	if err != nil {
+		return err
+	}
+	if atomic.CompareAndSwapInt64(&xx.entrypoint, noEntrypoint, int64(slot)) {
+		return nil
 	}
 
-	entrypoint := (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint))
-	minDistance := xx.distancer.Distance(vertex.vector, entrypoint.vector)
-	for l := entrypoint.level; l > vertex.level; l-- {
-		entrypoint, minDistance = xx.greedyClosestNeighbor(vertex.vector, entrypoint, minDistance, l)
+	vector := xx.arena.vector(slot)
+	entrypoint := uint32(atomic.LoadInt64(&xx.entrypoint))
+	minDistance := xx.distancer.Distance(vector, xx.arena.vector(entrypoint))
+	for l := xx.arena.level(entrypoint); l > vertexLevel; l-- {
+		entrypoint, minDistance = xx.greedyClosestNeighbor(vector, entrypoint, minDistance, l)
 	}
 
-	for l := gomath.MinInt(entrypoint.level, vertex.level); l >= 0; l-- {
-		neighbors := xx.searchLevel(vertex.vector, entrypoint, xx.config.efConstruction, l)
+	for l := gomath.MinInt(xx.arena.level(entrypoint), vertexLevel); l >= 0; l-- {
+		neighbors, _ := xx.searchLevel(context.Background(), vector, entrypoint, xx.config.efConstruction, l)
 
 		switch xx.config.searchAlgorithm {
 		case HnswSearchSimple:
 			neighbors = xx.selectNeighbors(neighbors, xx.config.m)
 		case HnswSearchHeuristic:
-			neighbors = xx.selectNeighborsHeuristic(vertex.vector, neighbors, xx.config.m, l, xx.config.heuristicExtendCandidates, xx.config.heuristicKeepPruned)
-		}
-
-		mMax := xx.config.mMax
-		if l == 0 {
-			mMax = xx.config.mMax0
+			neighbors = xx.selectNeighborsHeuristic(vector, neighbors, xx.config.m, l, xx.config.heuristicExtendCandidates, xx.config.heuristicKeepPruned)
 		}
 
 		for neighbors.Len() > 0 {
 			item := neighbors.Pop()
-			neighbor := item.Value().(*hnswVertex)
+			neighbor := item.Value().(uint32)
 			entrypoint = neighbor
 
-			vertex.addEdge(l, neighbor, item.Priority())
-			neighbor.addEdge(l, vertex, item.Priority())
-
-			if neighbor.edgesCount(l) > mMax {
-				xx.pruneNeighbors(neighbor, mMax, l)
-			}
+			xx.connect(slot, l, neighbor, item.Priority())
+			xx.connect(neighbor, l, slot, item.Priority())
 		}
 	}
 
-	entrypoint = (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint))
-	if entrypoint != nil && vertex.level > entrypoint.level {
-		atomic.CompareAndSwapPointer(&xx.entrypoint, xx.entrypoint, unsafe.Pointer(vertex))
+	current := atomic.LoadInt64(&xx.entrypoint)
+	if current != noEntrypoint && vertexLevel > xx.arena.level(uint32(current)) {
+		atomic.CompareAndSwapInt64(&xx.entrypoint, current, int64(slot))
 	}
 
 	return nil
 }
 
-func (xx *Hnsw) Get(id uint64) (edge.Vector, error) {
-	m, mu := xx.getVerticesShard(id)
-	mu.RLock()
-	defer mu.RUnlock()
+// Get returns a copy of the vector, the slot may be reused after a remove.
+func (xx *Hnsw) Get(id uint64) (gomath.Vector, error) {
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
+	slot, exists := xx.lookupSlot(id)
+	if !exists {
+		return nil, ItemNotFoundError
+	}
+	return append(gomath.Vector(nil), xx.arena.vector(slot)...), nil
+}
 
-	if vertex, exists := m[id]; exists {
-		return vertex.vector, nil
+// Ids lists the ids of the live vertices.
+func (xx *Hnsw) Ids() []uint64 {
+	ids := make([]uint64, 0, xx.Len())
+	for i, shard := range xx.vertices {
+		xx.verticesMu[i].RLock()
+		for id := range shard {
+			ids = append(ids, id)
+		}
+		xx.verticesMu[i].RUnlock()
 	}
-	return nil, ItemNotFoundError
+	return ids
 }
 
 func (xx *Hnsw) GetVertex(id uint64) (*hnswVertex, error) {
-	m, mu := xx.getVerticesShard(id)
-	mu.RLock()
-	defer mu.RUnlock()
-
-	if vertex, exists := m[id]; exists {
-		return vertex, nil
-	}
-	return nil, ItemNotFoundError
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
+	slot, exists := xx.lookupSlot(id)
+	if !exists {
+		return nil, ItemNotFoundError
+	}
+	vertex := xx.vertexAt(slot)
+	vertex.vector = append(gomath.Vector(nil), vertex.vector...)
+	return vertex, nil
 }
 
 func (xx *Hnsw) Remove(id uint64) error {
-	vertex, err := xx.removeVertex(id)
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
+	slot, err := xx.removeVertex(id)
 	if err != nil {
 		return err
 	}
+	level := xx.arena.level(slot)
 
-	currEntrypoint := atomic.LoadPointer(&xx.entrypoint)
-	if (*hnswVertex)(currEntrypoint) == vertex {
+	if atomic.LoadInt64(&xx.entrypoint) == int64(slot) {
 		minDistance := gomath.MaxFloat
-		var closestNeighbor *hnswVertex = nil
-
-		for l := vertex.level; l >= 0; l-- {
-			vertex.edgeMutexes[l].RLock()
-			for neighbor, distance := range vertex.edges[l] {
-				if distance < minDistance {
-					minDistance = distance
-					closestNeighbor = neighbor
+		closestNeighbor := noEntrypoint
+
+		for l := level; l >= 0; l-- {
+			neighbors, distances := xx.arena.distances(slot, l)
+			for i, neighbor := range neighbors {
+				if xx.arena.isDeleted(neighbor, l) {
+					continue
+				}
+				if distances[i] < minDistance {
+					minDistance = distances[i]
+					closestNeighbor = int64(neighbor)
 				}
 			}
-			vertex.edgeMutexes[l].RUnlock()
 
-			if closestNeighbor != nil {
+			if closestNeighbor != noEntrypoint {
 				break
 			}
 		}
-		atomic.CompareAndSwapPointer(&xx.entrypoint, currEntrypoint, unsafe.Pointer(closestNeighbor))
+		atomic.CompareAndSwapInt64(&xx.entrypoint, int64(slot), closestNeighbor)
 	}
 
-	for l := vertex.level; l >= 0; l-- {
-		mMax := xx.config.mMax
-		if l == 0 {
-			mMax = xx.config.mMax0
-		}
-
-		vertex.edgeMutexes[l].RLock()
-		neighbors := make([]*hnswVertex, len(vertex.edges[l]))
-		i := 0
-		for neighbor, _ := range vertex.edges[l] {
-			neighbors[i] = neighbor
-			i++
-		}
-		vertex.edgeMutexes[l].RUnlock()
-
-		for _, neighbor := range neighbors {
-			neighbor.removeEdge(l, vertex)
-			xx.pruneNeighbors(neighbor, mMax, l)
+	for l := level; l >= 0; l-- {
+		for _, neighbor := range xx.arena.neighbors(slot, l, nil) {
+			xx.disconnect(neighbor, l, slot)
 		}
 	}
+	xx.arena.retire(slot)
 
 	return nil
 }
 
-func (xx *Hnsw) Search(ctx context.Context, query edge.Vector, k uint) (SearchResult, error) {
+func (xx *Hnsw) Search(ctx context.Context, query gomath.Vector, k uint) (SearchResult, error) {
 	if xx.distancer.Type() == "cosine-dot" {
 		query = Normalize(query)
 	}
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
 
-	entrypoint := (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint))
-	if entrypoint == nil {
+	entrypoint, ok, err := xx.descend(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		return make(SearchResult, 0), nil
 	}
 
-	minDistance := xx.distancer.Distance(query, entrypoint.vector)
-	for l := entrypoint.level; l > 0; l-- {
-		entrypoint, minDistance = xx.greedyClosestNeighbor(query, entrypoint, minDistance, l)
+	ef := gomath.MaxInt(int(xx.ef.Load()), int(k))
+	neighbors, err := xx.searchLevel(ctx, query, entrypoint, ef, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	ef := gomath.MaxInt(xx.config.ef, int(k))
-	neighbors := xx.searchLevel(query, entrypoint, ef, 0)
-
 	switch xx.config.searchAlgorithm {
 	case HnswSearchSimple:
 		neighbors = xx.selectNeighbors(neighbors, int(k))
@@ -252,71 +263,194 @@ func (xx *Hnsw) Search(ctx context.Context, query edge.Vector, k uint) (SearchRe
 	result := make(SearchResult, n)
 	for i := n - 1; i >= 0; i-- {
 		item := neighbors.Pop()
-		result[i].Id = item.Value().(*hnswVertex).Id()
-		result[i].Metadata = item.Value().(*hnswVertex).Metadata()
+		node := xx.arena.node(item.Value().(uint32))
+		result[i].Id = node.id
+		result[i].Metadata = node.metadata
 		result[i].Score = item.Priority()
 	}
 
 	return result, nil
 }
 
+// descend walks the upper levels greedily to the entrypoint of level 0, false
+// when the graph is empty.
+func (xx *Hnsw) descend(ctx context.Context, query gomath.Vector) (uint32, bool, error) {
+	current := atomic.LoadInt64(&xx.entrypoint)
+	if current == noEntrypoint {
+		return 0, false, nil
+	}
+	entrypoint := uint32(current)
+
+	minDistance := xx.distancer.Distance(query, xx.arena.vector(entrypoint))
+	for l := xx.arena.level(entrypoint); l > 0; l-- {
+		if err := ctx.Err(); err != nil {
+			return 0, false, err
+		}
+		entrypoint, minDistance = xx.greedyClosestNeighbor(query, entrypoint, minDistance, l)
+	}
+	return entrypoint, true, nil
+}
+
 func (xx *Hnsw) RandomLevel() int {
+	if xx.levels != nil {
+		return xx.levels.level(xx.config.levelMultiplier)
+	}
 	return gomath.Floor(gomath.RandomExponential(xx.config.levelMultiplier))
 }
 
-func (xx *Hnsw) getVerticesShard(id uint64) (map[uint64]*hnswVertex, *sync.RWMutex) {
+func (xx *Hnsw) getVerticesShard(id uint64) (map[uint64]uint32, *sync.RWMutex) {
 	shardIdx := sharding.ShardVertex(id, uint64(VERTICES_MAP_SHARD_COUNT))
 	return xx.vertices[shardIdx], xx.verticesMu[shardIdx]
 }
 
-func (xx *Hnsw) storeVertex(vertex *hnswVertex) error {
-	m, mu := xx.getVerticesShard(vertex.id)
+func (xx *Hnsw) lookupSlot(id uint64) (uint32, bool) {
+	m, mu := xx.getVerticesShard(id)
+	mu.RLock()
+	defer mu.RUnlock()
+	slot, exists := m[id]
+	return slot, exists
+}
+
+func (xx *Hnsw) vertexAt(slot uint32) *hnswVertex {
+	node := xx.arena.node(slot)
+	return &hnswVertex{
+		id:       node.id,
+		vector:   xx.arena.vector(slot),
+		level:    int(node.level),
+		metadata: node.metadata,
+	}
+}
+
+func (xx *Hnsw) storeVertex(id uint64, vector gomath.Vector, metadata Metadata, level int) (uint32, error) {
+	m, mu := xx.getVerticesShard(id)
 	defer mu.Unlock()
 	mu.Lock()
 
-	if _, exists := m[vertex.id]; exists {
-		return ItemAlreadyExistsError
+	if _, exists := m[id]; exists {
+		return 0, ItemAlreadyExistsError
 	}
 
-	m[vertex.id] = vertex
+	slot := xx.arena.alloc()
+	xx.arena.init(slot, id, vector, metadata, level)
+	m[id] = slot
 	atomic.AddUint64(&xx.len, 1)
 	atomic.AddUint64(&xx.bytesSize, 0)
-	return nil
+	return slot, nil
 }
 
-func (xx *Hnsw) removeVertex(id uint64) (*hnswVertex, error) {
+func (xx *Hnsw) removeVertex(id uint64) (uint32, error) {
 	m, mu := xx.getVerticesShard(id)
 	defer mu.Unlock()
 	mu.Lock()
 
-	if vertex, exists := m[id]; exists {
+	if slot, exists := m[id]; exists {
 		delete(m, id)
 		atomic.AddUint64(&xx.len, ^uint64(0))
-		// atomic.AddUint64(&xx.bytesSize, ^uint64(vertex.bytesSize()-1))
-		vertex.setDeleted()
-		return vertex, nil
+		xx.arena.setDeleted(slot)
+		return slot, nil
 	}
 
-	return nil, ItemNotFoundError
+	return 0, ItemNotFoundError
 }
 
-func (xx *Hnsw) greedyClosestNeighbor(query edge.Vector, entrypoint *hnswVertex, minDistance float32, level int) (*hnswVertex, float32) {
-	for {
-		var closestNeighbor *hnswVertex
+// connect adds (or updates) the edge slot -> neighbor. A full neighbour list
+// keeps the closest of its neighbours and the new one.
+func (xx *Hnsw) connect(slot uint32, level int, neighbor uint32, distance float32) {
+	mu := xx.arena.stripe(slot)
+	mu.Lock()
+	defer mu.Unlock()
+
+	count, ids, distances := xx.arena.edges(slot, level)
+	n := int(*count)
+	for i := 0; i < n; i++ {
+		if ids[i] == neighbor {
+			distances[i] = distance
+			return
+		}
+	}
+	if n < len(ids) {
+		ids[n] = neighbor
+		distances[n] = distance
+		*count = uint32(n + 1)
+		return
+	}
+
+	neighborsQueue := NewMaxPriorityQueue(NewPriorityQueueItem(distance, neighbor))
+	for i := 0; i < n; i++ {
+		if xx.arena.isDeleted(ids[i], level) {
+			continue
+		}
+		neighborsQueue.Push(NewPriorityQueueItem(distances[i], ids[i]))
+	}
+
+	switch xx.config.searchAlgorithm {
+	case HnswSearchSimple:
+		neighborsQueue = xx.selectNeighbors(neighborsQueue, len(ids))
+	case HnswSearchHeuristic:
+		// candidates are not extended, that would lock other neighbour lists
+		neighborsQueue = xx.selectNeighborsHeuristic(xx.arena.vector(slot), neighborsQueue, len(ids), level, false, xx.config.heuristicKeepPruned)
+	}
+
+	n = 0
+	for _, item := range neighborsQueue.ToSlice() {
+		if n == len(ids) {
+			break
+		}
+		ids[n] = item.Value().(uint32)
+		distances[n] = item.Priority()
+		n++
+	}
+	*count = uint32(n)
+}
 
-		entrypoint.edgeMutexes[level].RLock()
-		for neighbor, _ := range entrypoint.edges[level] {
-			if neighbor.isDeleted() {
+// disconnect drops the edge slot -> neighbor along with edges to removed vertices.
+func (xx *Hnsw) disconnect(slot uint32, level int, neighbor uint32) {
+	mu := xx.arena.stripe(slot)
+	mu.Lock()
+	defer mu.Unlock()
+	// a removed slot may be written by its reuse, its links are dropped anyway
+	if xx.arena.isDeleted(slot, level) {
+		return
+	}
+
+	count, ids, distances := xx.arena.edges(slot, level)
+	n := 0
+	for i := 0; i < int(*count); i++ {
+		if ids[i] == neighbor || xx.arena.isDeleted(ids[i], level) {
+			continue
+		}
+		ids[n] = ids[i]
+		distances[n] = distances[i]
+		n++
+	}
+	*count = uint32(n)
+}
+
+func (xx *Hnsw) getVisited() *visitedList {
+	visited, ok := xx.visited.Get().(*visitedList)
+	if !ok {
+		visited = &visitedList{}
+	}
+	visited.reset(xx.arena.slots())
+	return visited
+}
+
+func (xx *Hnsw) greedyClosestNeighbor(query gomath.Vector, entrypoint uint32, minDistance float32, level int) (uint32, float32) {
+	neighbors := make([]uint32, 0, xx.config.mMax0)
+	for {
+		closestNeighbor := entrypoint
+		neighbors = xx.arena.neighbors(entrypoint, level, neighbors[:0])
+		for _, neighbor := range neighbors {
+			if xx.arena.isDeleted(neighbor, level) {
 				continue
 			}
-			if distance := xx.distancer.Distance(query, neighbor.vector); distance < minDistance {
+			if distance := xx.distancer.Distance(query, xx.arena.vector(neighbor)); distance < minDistance {
 				minDistance = distance
 				closestNeighbor = neighbor
 			}
 		}
-		entrypoint.edgeMutexes[level].RUnlock()
 
-		if closestNeighbor == nil {
+		if closestNeighbor == entrypoint {
 			break
 		}
 		entrypoint = closestNeighbor
@@ -325,35 +459,41 @@ func (xx *Hnsw) greedyClosestNeighbor(query edge.Vector, entrypoint *hnswVertex,
 	return entrypoint, minDistance
 }
 
-func (xx *Hnsw) searchLevel(query edge.Vector, entrypoint *hnswVertex, ef, level int) PriorityQueue {
-	entrypointDistance := xx.distancer.Distance(query, entrypoint.vector)
+func (xx *Hnsw) searchLevel(ctx context.Context, query gomath.Vector, entrypoint uint32, ef, level int) (PriorityQueue, error) {
+	entrypointDistance := xx.distancer.Distance(query, xx.arena.vector(entrypoint))
 	pqItem := NewPriorityQueueItem(entrypointDistance, entrypoint)
 	candidateVertices := NewMinPriorityQueue(pqItem)
 	resultVertices := NewMaxPriorityQueue(pqItem)
 
-	visitedVertices := make(map[*hnswVertex]struct{}, ef*xx.config.mMax0)
-	visitedVertices[entrypoint] = struct{}{}
+	visitedVertices := xx.getVisited()
+	defer xx.visited.Put(visitedVertices)
+	visitedVertices.visit(entrypoint)
 
-	for candidateVertices.Len() > 0 {
+	neighbors := make([]uint32, 0, xx.config.mMax0)
+	for pops := 1; candidateVertices.Len() > 0; pops++ {
+		if pops%searchCheckEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
 		candidateItem := candidateVertices.Pop()
-		candidate := candidateItem.Value().(*hnswVertex)
+		candidate := candidateItem.Value().(uint32)
 		lowerBound := resultVertices.Peek().Priority()
 
 		if candidateItem.Priority() > lowerBound {
 			break
 		}
 
-		candidate.edgeMutexes[level].RLock()
-		for neighbor, _ := range candidate.edges[level] {
-			if neighbor.isDeleted() {
+		neighbors = xx.arena.neighbors(candidate, level, neighbors[:0])
+		for _, neighbor := range neighbors {
+			if xx.arena.isDeleted(neighbor, level) {
 				continue
 			}
-			if _, exists := visitedVertices[neighbor]; exists {
+			if visitedVertices.visit(neighbor) {
 				continue
 			}
-			visitedVertices[neighbor] = struct{}{}
 
-			distance := xx.distancer.Distance(query, neighbor.vector)
+			distance := xx.distancer.Distance(query, xx.arena.vector(neighbor))
 			if (distance < lowerBound) || (resultVertices.Len() < ef) {
 				pqItem := NewPriorityQueueItem(distance, neighbor)
 				candidateVertices.Push(pqItem)
@@ -364,11 +504,10 @@ func (xx *Hnsw) searchLevel(query edge.Vector, entrypoint *hnswVertex, ef, level
 				}
 			}
 		}
-		candidate.edgeMutexes[level].RUnlock()
 	}
 
 	// MaxPriorityQueue
-	return resultVertices
+	return resultVertices, nil
 }
 
 func (xx *Hnsw) selectNeighbors(neighbors PriorityQueue, k int) PriorityQueue {
@@ -379,25 +518,26 @@ func (xx *Hnsw) selectNeighbors(neighbors PriorityQueue, k int) PriorityQueue {
 	return neighbors
 }
 
-func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQueue, k, level int, extendCandidates, keepPruned bool) PriorityQueue {
+func (xx *Hnsw) selectNeighborsHeuristic(query gomath.Vector, neighbors PriorityQueue, k, level int, extendCandidates, keepPruned bool) PriorityQueue {
 	candidateVertices := neighbors.Reverse() // MinPriorityQueue
 
 	existingCandidatesSize := neighbors.Len()
 	if extendCandidates {
 		existingCandidatesSize += neighbors.Len() * xx.config.mMax0
 	}
-	existingCandidates := make(map[*hnswVertex]struct{}, existingCandidatesSize)
+	existingCandidates := make(map[uint32]struct{}, existingCandidatesSize)
 	for _, neighbor := range neighbors.ToSlice() {
-		existingCandidates[neighbor.Value().(*hnswVertex)] = struct{}{}
+		existingCandidates[neighbor.Value().(uint32)] = struct{}{}
 	}
 
 	if extendCandidates {
+		var edges []uint32
 		for neighbors.Len() > 0 {
-			candidate := neighbors.Pop().Value().(*hnswVertex)
+			candidate := neighbors.Pop().Value().(uint32)
 
-			candidate.edgeMutexes[level].RLock()
-			for neighbor, _ := range candidate.edges[level] {
-				if neighbor.isDeleted() {
+			edges = xx.arena.neighbors(candidate, level, edges[:0])
+			for _, neighbor := range edges {
+				if xx.arena.isDeleted(neighbor, level) {
 					continue
 				}
 				if _, exists := existingCandidates[neighbor]; exists {
@@ -405,10 +545,9 @@ func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQu
 				}
 				existingCandidates[neighbor] = struct{}{}
 
-				distance := xx.distancer.Distance(query, neighbor.vector)
+				distance := xx.distancer.Distance(query, xx.arena.vector(neighbor))
 				candidateVertices.Push(NewPriorityQueueItem(distance, neighbor))
 			}
-			candidate.edgeMutexes[level].RUnlock()
 		}
 	}
 
@@ -429,45 +568,18 @@ func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQu
 	return result
 }
 
-func (xx *Hnsw) pruneNeighbors(vertex *hnswVertex, k, level int) {
-	neighborsQueue := NewMaxPriorityQueue()
-
-	vertex.edgeMutexes[level].RLock()
-	for neighbor, distance := range vertex.edges[level] {
-		if neighbor.isDeleted() {
-			continue
-		}
-		neighborsQueue.Push(NewPriorityQueueItem(distance, neighbor))
-	}
-	vertex.edgeMutexes[level].RUnlock()
-
-	switch xx.config.searchAlgorithm {
-	case HnswSearchSimple:
-		neighborsQueue = xx.selectNeighbors(neighborsQueue, k)
-	case HnswSearchHeuristic:
-		neighborsQueue = xx.selectNeighborsHeuristic(vertex.vector, neighborsQueue, k, level, xx.config.heuristicExtendCandidates, xx.config.heuristicKeepPruned)
-	}
-
-	newNeighbors := make(hnswEdgeSet, neighborsQueue.Len())
-	for _, item := range neighborsQueue.ToSlice() {
-		newNeighbors[item.Value().(*hnswVertex)] = item.Priority()
-	}
-
-	vertex.setEdges(level, newNeighbors)
-}
-
 func (xx *Hnsw) BytesSize() uint64 {
 	maxLevel := 10
-	if entrypoint := (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint)); entrypoint != nil {
-		maxLevel = entrypoint.level
+	if entrypoint := atomic.LoadInt64(&xx.entrypoint); entrypoint != noEntrypoint {
+		maxLevel = xx.arena.level(uint32(entrypoint))
 	}
 
-	mutb := float64(HNSW_VERTEX_MUTEX_BYTES)
-	var pointersSize float64 = float64(xx.config.mMax0*HNSW_VERTEX_EDGE_BYTES) + mutb
+	// fixed-capacity neighbour lists, one count cell per level
+	var linksSize float64 = float64(xx.config.mMax0*HNSW_VERTEX_EDGE_BYTES + 4)
 	for i := 1; i < maxLevel; i++ {
-		pointersSize += (float64(xx.config.mMax*HNSW_VERTEX_EDGE_BYTES) + mutb) * math.Exp(float64(i)/-float64(xx.config.levelMultiplier))
+		linksSize += float64(xx.config.mMax*HNSW_VERTEX_EDGE_BYTES+4) * math.Exp(float64(i)/-float64(xx.config.levelMultiplier))
 	}
 
 	verticesDataSize := atomic.LoadUint64(&xx.bytesSize)
-	return uint64(math.Floor(float64(xx.Len())*pointersSize)) + verticesDataSize
+	return uint64(math.Floor(float64(xx.Len())*linksSize)) + verticesDataSize
 }
@@ -0,0 +1,201 @@
+package vectorindex
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+type BuildItem struct {
+	Id       uint64
+	Vector   gomath.Vector
+	Metadata Metadata
+}
+
+// Options
+type BuildOption interface {
+	apply(*buildConfig)
+}
+
+type buildOption struct {
+	applyFunc func(*buildConfig)
+}
+
+func (opt *buildOption) apply(config *buildConfig) {
+	opt.applyFunc(config)
+}
+
+// BuildWorkers is the number of goroutines inserting, GOMAXPROCS by default.
+func BuildWorkers(value int) BuildOption {
+	return &buildOption{func(config *buildConfig) {
+		config.workers = value
+	}}
+}
+
+// BuildProgress is called with the number of inserted items every
+// BuildProgressEvery items and once the build ends. total is 0 when the
+// size of the input is not known. Workers may call it concurrently.
+func BuildProgress(value func(done, total int)) BuildOption {
+	return &buildOption{func(config *buildConfig) {
+		config.progress = value
+	}}
+}
+
+func BuildProgressEvery(value int) BuildOption {
+	return &buildOption{func(config *buildConfig) {
+		config.every = value
+	}}
+}
+
+// BuildDeterministic draws the vertex levels from the seed and inserts in
+// input order on a single worker, the same input builds the same graph.
+func BuildDeterministic(seed int64) BuildOption {
+	return &buildOption{func(config *buildConfig) {
+		config.deterministic = true
+		config.seed = seed
+	}}
+}
+
+type buildConfig struct {
+	workers       int
+	progress      func(done, total int)
+	every         int
+	deterministic bool
+	seed          int64
+	total         int
+}
+
+func newBuildConfig(options []BuildOption) *buildConfig {
+	config := &buildConfig{
+		workers: runtime.GOMAXPROCS(0),
+		every:   1000,
+	}
+	for _, option := range options {
+		option.apply(config)
+	}
+	if config.workers < 1 || config.deterministic {
+		config.workers = 1
+	}
+	if config.every < 1 {
+		config.every = 1
+	}
+	return config
+}
+
+type buildJob struct {
+	item  BuildItem
+	level int
+}
+
+/* Build inserts every item read from items until the channel is closed.
+ * Inserts run on the configured number of workers, they only contend on the
+ * vertex map shard and neighbour list stripes they touch, the same locks a
+ * concurrent Insert takes, so the index stays searchable while it is built.
+ *
+ * The first failed insert or a cancelled ctx stops the build, items already
+ * inserted stay in the index. Build returns the number of inserted items. */
+func (xx *Hnsw) Build(ctx context.Context, items <-chan BuildItem, options ...BuildOption) (int, error) {
+	return xx.build(ctx, items, newBuildConfig(options))
+}
+
+// BuildSlice is Build over a slice, progress reports the slice length as total.
+func (xx *Hnsw) BuildSlice(ctx context.Context, items []BuildItem, options ...BuildOption) (int, error) {
+	config := newBuildConfig(options)
+	config.total = len(items)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	feed := make(chan BuildItem, config.workers)
+	go func() {
+		defer close(feed)
+		for _, item := range items {
+			select {
+			case feed <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return xx.build(ctx, feed, config)
+}
+
+func (xx *Hnsw) build(ctx context.Context, items <-chan BuildItem, config *buildConfig) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	levelFn := xx.RandomLevel
+	if config.deterministic {
+		source := newLevelSource(config.seed, 0)
+		levelFn = func() int {
+			return source.level(xx.config.levelMultiplier)
+		}
+	}
+
+	var (
+		done     atomic.Int64
+		failOnce sync.Once
+		failure  error
+	)
+	fail := func(err error) {
+		failOnce.Do(func() {
+			failure = err
+			cancel()
+		})
+	}
+	report := func(n int64) {
+		if config.progress != nil && n%int64(config.every) == 0 {
+			config.progress(int(n), config.total)
+		}
+	}
+
+	jobs := make(chan buildJob, config.workers)
+	wg := sync.WaitGroup{}
+	for i := 0; i < config.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := xx.Insert(job.item.Id, job.item.Vector, job.item.Metadata, job.level); err != nil {
+					fail(err)
+					continue
+				}
+				report(done.Add(1))
+			}
+		}()
+	}
+
+	// levels are drawn here so a seeded build sees them in input order
+feed:
+	for {
+		select {
+		case <-ctx.Done():
+			break feed
+		case item, ok := <-items:
+			if !ok {
+				break feed
+			}
+			select {
+			case jobs <- buildJob{item: item, level: levelFn()}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	n := int(done.Load())
+	if config.progress != nil {
+		config.progress(n, config.total)
+	}
+	if failure != nil {
+		return n, failure
+	}
+	return n, ctx.Err()
+}
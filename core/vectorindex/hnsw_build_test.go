@@ -0,0 +1,116 @@
+package vectorindex
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomBuildItems(dim, size int) []BuildItem {
+	items := make([]BuildItem, size)
+	for i := range items {
+		items[i] = BuildItem{
+			Id:       uint64(i),
+			Vector:   gomath.RandomUniformVector(dim),
+			Metadata: Metadata{"id": i},
+		}
+	}
+	return items
+}
+
+func TestHnswBuildParallel(t *testing.T) {
+	const dim = 32
+	dist := distance.NewEuclidean()
+	items := randomBuildItems(dim, 3000)
+
+	index := NewHnsw(dim, dist, HnswEf(100))
+	var last atomic.Int64
+	n, err := index.BuildSlice(context.Background(), items,
+		BuildWorkers(8),
+		BuildProgressEvery(500),
+		BuildProgress(func(done, total int) {
+			assert.Equal(t, len(items), total)
+			last.Store(int64(done))
+		}))
+	assert.Nil(t, err)
+	assert.Equal(t, len(items), n)
+	assert.Equal(t, len(items), index.Len())
+	assert.EqualValues(t, len(items), last.Load())
+
+	hits := 0
+	for q := 0; q < 50; q++ {
+		query := gomath.RandomUniformVector(dim)
+		ids := make([]int, len(items))
+		for i := range ids {
+			ids[i] = i
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return dist.Distance(query, items[ids[i]].Vector) < dist.Distance(query, items[ids[j]].Vector)
+		})
+		result, err := index.Search(context.Background(), query, 10)
+		assert.Nil(t, err)
+		truth := make(map[uint64]struct{}, 10)
+		for _, id := range ids[:10] {
+			truth[uint64(id)] = struct{}{}
+		}
+		for _, item := range result {
+			if _, ok := truth[item.Id]; ok {
+				hits++
+			}
+		}
+	}
+	assert.Greater(t, float64(hits)/500, 0.9)
+
+	// a duplicate id stops the build
+	n, err = index.BuildSlice(context.Background(), items[:10], BuildWorkers(2))
+	assert.Equal(t, ItemAlreadyExistsError, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestHnswBuildDeterministic(t *testing.T) {
+	items := randomBuildItems(16, 1000)
+	a := NewHnsw(16, distance.NewCosine())
+	b := NewHnsw(16, distance.NewCosine())
+
+	_, err := a.BuildSlice(context.Background(), items, BuildDeterministic(7), BuildWorkers(8))
+	assert.Nil(t, err)
+	feed := make(chan BuildItem)
+	go func() {
+		for _, item := range items {
+			feed <- item
+		}
+		close(feed)
+	}()
+	_, err = b.Build(context.Background(), feed, BuildDeterministic(7))
+	assert.Nil(t, err)
+	assert.Nil(t, hnswIsEqual(a, b))
+	assert.Equal(t, a.entrypoint, b.entrypoint)
+}
+
+func TestHnswBuildCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	index := NewHnsw(8, distance.NewEuclidean())
+	_, err := index.BuildSlice(ctx, randomBuildItems(8, 100))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestHnswSearchCancel(t *testing.T) {
+	index := generateRandomIndex(16, 1000, distance.NewEuclidean())
+	index.SetEf(200)
+	query := gomath.RandomUniformVector(16)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := index.Search(ctx, query, 10)
+	assert.Equal(t, context.Canceled, err)
+
+	result, err := index.Search(context.Background(), query, 10)
+	assert.Nil(t, err)
+	assert.Len(t, result, 10)
+}
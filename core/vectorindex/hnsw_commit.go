@@ -1,14 +1,15 @@
 package vectorindex
 
 import (
+	"cmp"
 	"encoding/binary"
 	"errors"
 	"io"
+	"slices"
 	"sync/atomic"
-	"unsafe"
 
-	"github.com/sjy-dv/nnv/edge"
 	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
 )
 
 var (
@@ -22,6 +23,12 @@ func distToDistIdx(dist distance.Space) uint8 {
 		return 1
 	case "l2-squared":
 		return 2
+	case "inner-product":
+		return 3
+	case "manhattan":
+		return 4
+	case "hamming":
+		return 5
 	}
 	return 0
 }
@@ -32,6 +39,12 @@ func distIdxToDist(distIdx uint8) (distance.Space, error) {
 		return distance.NewCosine(), nil
 	case 2:
 		return distance.NewEuclidean(), nil
+	case 3:
+		return distance.NewInnerProduct(), nil
+	case 4:
+		return distance.NewManhattan(), nil
+	case 5:
+		return distance.NewHamming(), nil
 	}
 	return nil, InvalidSpaceTypeErr
 }
@@ -51,7 +64,9 @@ func bytesToId(byt []byte) (uint64, error) {
 
 func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 	if header {
-		if err := xx.config.save(w); err != nil {
+		config := *xx.config
+		config.ef = int(xx.ef.Load())
+		if err := config.save(w); err != nil {
 			return err
 		}
 		if err := binary.Write(w, binary.BigEndian, uint32(xx.dim)); err != nil {
@@ -60,17 +75,24 @@ func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 		if err := binary.Write(w, binary.BigEndian, distToDistIdx(xx.distancer)); err != nil {
 			return err
 		}
+		if xx.levels != nil {
+			if err := binary.Write(w, binary.BigEndian, xx.levels.count()); err != nil {
+				return err
+			}
+		}
 	}
 
 	if xx.Len() == 0 {
 		return nil
 	}
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
 
-	entrypoint := (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint))
-	if entrypoint == nil {
+	entrypoint := atomic.LoadInt64(&xx.entrypoint)
+	if entrypoint == noEntrypoint {
 		return NoEntrypointErr
 	}
-	ebid, err := idToBytes(entrypoint.id)
+	ebid, err := idToBytes(xx.arena.node(uint32(entrypoint)).id)
 	if err != nil {
 		return err
 	}
@@ -78,12 +100,28 @@ func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 		return err
 	}
 
-	for _, verticShard := range xx.vertices {
-		if err := binary.Write(w, binary.BigEndian, uint32(len(verticShard))); err != nil {
+	// slots are taken per shard so vertices and edges are written from the same view
+	shards := make([][]uint32, len(xx.vertices))
+	for i, verticShard := range xx.vertices {
+		xx.verticesMu[i].RLock()
+		shards[i] = make([]uint32, 0, len(verticShard))
+		for _, slot := range verticShard {
+			shards[i] = append(shards[i], slot)
+		}
+		xx.verticesMu[i].RUnlock()
+		// id order, the same graph commits the same bytes after a load too
+		slices.SortFunc(shards[i], func(a, b uint32) int {
+			return cmp.Compare(xx.arena.node(a).id, xx.arena.node(b).id)
+		})
+	}
+
+	for _, slots := range shards {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(slots))); err != nil {
 			return err
 		}
 
-		for _, vertex := range verticShard {
+		for _, slot := range slots {
+			vertex := xx.vertexAt(slot)
 			byid, err := idToBytes(vertex.id)
 			if err != nil {
 				return err
@@ -103,9 +141,9 @@ func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 		}
 	}
 
-	for _, verticesShard := range xx.vertices {
-		for _, vertex := range verticesShard {
-			byid, err := idToBytes(vertex.id)
+	for _, slots := range shards {
+		for _, slot := range slots {
+			byid, err := idToBytes(xx.arena.node(slot).id)
 			if err != nil {
 				return err
 			}
@@ -113,28 +151,29 @@ func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 				return err
 			}
 
-			for l := vertex.level; l >= 0; l-- {
+			for l := xx.arena.level(slot); l >= 0; l-- {
+				neighbors, distances := xx.arena.distances(slot, l)
 				edgesCount := 0
-				for neighbor := range vertex.edges[l] {
-					if atomic.LoadUint32(&neighbor.deleted) == 0 {
+				for _, neighbor := range neighbors {
+					if !xx.arena.isDeleted(neighbor, l) {
 						edgesCount++
 					}
 				}
 				if err := binary.Write(w, binary.BigEndian, uint32(edgesCount)); err != nil {
 					return err
 				}
-				for neighbor, distance := range vertex.edges[l] {
-					if atomic.LoadUint32(&neighbor.deleted) == 1 {
+				for i, neighbor := range neighbors {
+					if xx.arena.isDeleted(neighbor, l) {
 						continue
 					}
-					byid, err := idToBytes(neighbor.id)
+					byid, err := idToBytes(xx.arena.node(neighbor).id)
 					if err != nil {
 						return err
 					}
 					if _, err := w.Write(byid); err != nil {
 						return err
 					}
-					if err := binary.Write(w, binary.BigEndian, distance); err != nil {
+					if err := binary.Write(w, binary.BigEndian, distances[i]); err != nil {
 						return err
 					}
 				}
@@ -151,6 +190,7 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 		if err := xx.config.load(r); err != nil {
 			return err
 		}
+		xx.ef.Store(int64(xx.config.ef))
 		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
 			return err
 		}
@@ -163,6 +203,14 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 		}
 		xx.dim = uint(size)
 		xx.distancer = dist
+		xx.levels = nil
+		if xx.config.seeded {
+			var draws uint64
+			if err := binary.Read(r, binary.BigEndian, &draws); err != nil {
+				return err
+			}
+			xx.levels = newLevelSource(xx.config.seed, draws)
+		}
 	}
 
 	var level int32
@@ -170,7 +218,7 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 	var distance float32
 
 	idBuf := make([]byte, 8)
-	if _, err := r.Read(idBuf); err != nil {
+	if _, err := io.ReadFull(r, idBuf); err != nil {
 		return err
 	}
 	entrypointId, err := bytesToId(idBuf)
@@ -179,20 +227,25 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 	}
 
 	xx.len = 0
+	xx.bytesSize = 0
+	xx.arena = newHnswArena(int(xx.dim), xx.config.mMax, xx.config.mMax0)
+	atomic.StoreInt64(&xx.entrypoint, noEntrypoint)
 	// Load vertices
 	var shardSize uint32
-	var vertex *hnswVertex
+	total := 0
 	for i := range xx.vertices {
 		if err := binary.Read(r, binary.BigEndian, &shardSize); err != nil {
 			return err
 		}
 		xx.len += uint64(shardSize)
+		total += int(shardSize)
 
-		xx.vertices[i] = make(map[uint64]*hnswVertex, int(shardSize))
+		xx.vertices[i] = make(map[uint64]uint32, int(shardSize))
 		verticesShard := xx.vertices[i]
 
+		vector := make(gomath.Vector, xx.dim)
 		for i := 0; i < int(shardSize); i++ {
-			if _, err := r.Read(idBuf); err != nil {
+			if _, err := io.ReadFull(r, idBuf); err != nil {
 				return err
 			}
 			id, err := bytesToId(idBuf)
@@ -203,7 +256,6 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 				return err
 			}
 
-			vector := make(edge.Vector, xx.dim)
 			if err := vector.Load(r); err != nil {
 				return err
 			}
@@ -213,46 +265,55 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 				return err
 			}
 
-			vertex = newHnswVertex(id, vector, metadata, int(level))
-			xx.bytesSize += vertex.bytesSize()
-			verticesShard[id] = vertex
+			slot := xx.arena.alloc()
+			xx.arena.init(slot, id, vector, metadata, int(level))
+			xx.bytesSize += xx.vertexAt(slot).bytesSize()
+			verticesShard[id] = slot
 		}
 	}
 
 	// Set entrypoint
-	s, _ := xx.getVerticesShard(entrypointId)
-	atomic.StorePointer(&xx.entrypoint, unsafe.Pointer(s[entrypointId]))
+	if slot, exists := xx.lookupSlot(entrypointId); exists {
+		atomic.StoreInt64(&xx.entrypoint, int64(slot))
+	}
 
 	// Load edges
-	for _, verticesShard := range xx.vertices {
-		for i := 0; i < len(verticesShard); i++ {
-			if _, err := r.Read(idBuf); err != nil {
-				return err
-			}
-			id, err := bytesToId(idBuf)
-			if err != nil {
+	for i := 0; i < total; i++ {
+		if _, err := io.ReadFull(r, idBuf); err != nil {
+			return err
+		}
+		id, err := bytesToId(idBuf)
+		if err != nil {
+			return err
+		}
+
+		slot, exists := xx.lookupSlot(id)
+		if !exists {
+			return ItemNotFoundError
+		}
+		for l := xx.arena.level(slot); l >= 0; l-- {
+			if err := binary.Read(r, binary.BigEndian, &numEdges); err != nil {
 				return err
 			}
-
-			vertex = verticesShard[id]
-			for l := vertex.level; l >= 0; l-- {
-				if err := binary.Read(r, binary.BigEndian, &numEdges); err != nil {
+			count, ids, distances := xx.arena.edges(slot, l)
+			for j := 0; j < int(numEdges); j++ {
+				if _, err := io.ReadFull(r, idBuf); err != nil {
 					return err
 				}
-				for j := 0; j < int(numEdges); j++ {
-					if _, err := r.Read(idBuf); err != nil {
-						return err
-					}
-					neighborId, err := bytesToId(idBuf)
-					if err != nil {
-						return err
-					}
-					if err := binary.Read(r, binary.BigEndian, &distance); err != nil {
-						return err
-					}
-					s, _ = xx.getVerticesShard(neighborId)
-					vertex.edges[l][s[neighborId]] = distance
+				neighborId, err := bytesToId(idBuf)
+				if err != nil {
+					return err
+				}
+				if err := binary.Read(r, binary.BigEndian, &distance); err != nil {
+					return err
+				}
+				neighbor, exists := xx.lookupSlot(neighborId)
+				if !exists || int(*count) == len(ids) {
+					continue
 				}
+				ids[*count] = neighbor
+				distances[*count] = distance
+				*count++
 			}
 		}
 	}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sync/atomic"
 	"testing"
 
 	"github.com/sjy-dv/nnv/pkg/distance"
@@ -26,11 +25,12 @@ func hnswIsEqual(a, b *Hnsw) error {
 		if len(shard) != len(otherShard) {
 			return errors.New("Not same shard len")
 		}
-		for _, vertex := range shard {
-			otherVertex, exists := otherShard[vertex.id]
+		for id, slot := range shard {
+			otherSlot, exists := otherShard[id]
 			if !exists {
 				return errors.New("Other vertex does not exist")
 			}
+			vertex, otherVertex := a.vertexAt(slot), b.vertexAt(otherSlot)
 			if vertex.level != otherVertex.level {
 				return errors.New("Other vertex level does not match")
 			}
@@ -54,18 +54,8 @@ func hnswIsEqual(a, b *Hnsw) error {
 				}
 			}
 			for l := vertex.level; l >= 0; l-- {
-				neighborDistances := make(map[uint64]float32)
-				otherNeighborDistances := make(map[uint64]float32)
-				for neighbor, distance := range vertex.edges[l] {
-					if atomic.LoadUint32(&neighbor.deleted) == 0 {
-						neighborDistances[neighbor.id] = distance
-					}
-				}
-				for neighbor, distance := range otherVertex.edges[l] {
-					if atomic.LoadUint32(&neighbor.deleted) == 0 {
-						otherNeighborDistances[neighbor.id] = distance
-					}
-				}
+				neighborDistances := liveEdges(a, slot, l)
+				otherNeighborDistances := liveEdges(b, otherSlot, l)
 				if len(neighborDistances) != len(otherNeighborDistances) {
 					return errors.New("Edges count not the same")
 				}
@@ -84,6 +74,17 @@ func hnswIsEqual(a, b *Hnsw) error {
 	return nil
 }
 
+func liveEdges(index *Hnsw, slot uint32, level int) map[uint64]float32 {
+	edges := make(map[uint64]float32)
+	neighbors, distances := index.arena.distances(slot, level)
+	for i, neighbor := range neighbors {
+		if !index.arena.isDeleted(neighbor, level) {
+			edges[index.arena.node(neighbor).id] = distances[i]
+		}
+	}
+	return edges
+}
+
 func generateRandomIndex(dim, size int, dist distance.Space) *Hnsw {
 	insertKeys := make(map[uint64]struct{})
 
@@ -162,3 +163,80 @@ func TestHnswSimple(t *testing.T) {
 
 	assert.Nil(t, hnswIsEqual(index, otherIndex))
 }
+
+func TestHnswCommitAndLoadDistances(t *testing.T) {
+	for _, dist := range []distance.Space{
+		distance.NewInnerProduct(),
+		distance.NewManhattan(),
+		distance.NewHamming(),
+	} {
+		index := generateRandomIndex(32, 300, dist)
+
+		var buf bytes.Buffer
+		assert.Nil(t, index.Commit(&buf, true))
+
+		// loaded space comes from the snapshot header, not the constructor
+		otherIndex := NewHnsw(32, distance.NewEuclidean())
+		assert.Nil(t, otherIndex.Load(&buf, true))
+		assert.Equal(t, dist.Type(), otherIndex.Distance())
+		assert.Nil(t, hnswIsEqual(index, otherIndex))
+	}
+}
+
+func TestHnswSetEfCommit(t *testing.T) {
+	index := generateRandomIndex(16, 200, distance.NewEuclidean())
+	index.SetEf(80)
+	assert.Equal(t, 80, index.Config().Ef)
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf, true))
+	otherIndex := NewHnsw(16, distance.NewEuclidean())
+	assert.Nil(t, otherIndex.Load(&buf, true))
+	assert.Equal(t, 80, otherIndex.Config().Ef)
+	assert.ElementsMatch(t, index.Ids(), otherIndex.Ids())
+}
+
+func TestHnswSeededCommitIsReproducible(t *testing.T) {
+	const dim = 16
+	vectors := make([]gomath.Vector, 600)
+	for i := range vectors {
+		vectors[i] = gomath.RandomUniformVector(dim)
+	}
+	build := func(index *Hnsw, from, to int, remove bool) {
+		for i := from; i < to; i++ {
+			metadata := Metadata{"id": i, "tag": fmt.Sprint(i % 7), "nested": map[string]any{"a": i, "b": "x", "c": true}}
+			assert.Nil(t, index.Insert(uint64(i), vectors[i], metadata, index.RandomLevel()))
+		}
+		for i := from; remove && i < to; i += 9 {
+			assert.Nil(t, index.Remove(uint64(i)))
+		}
+	}
+	commit := func(index *Hnsw) []byte {
+		var buf bytes.Buffer
+		assert.Nil(t, index.Commit(&buf, true))
+		return buf.Bytes()
+	}
+
+	a := NewHnsw(dim, distance.NewCosine(), HnswSeed(42))
+	b := NewHnsw(dim, distance.NewCosine(), HnswSeed(42))
+	build(a, 0, 600, true)
+	build(b, 0, 600, true)
+	assert.Equal(t, commit(a), commit(b))
+	assert.Equal(t, int64(42), b.Config().Seed)
+
+	other := NewHnsw(dim, distance.NewCosine(), HnswSeed(43))
+	build(other, 0, 600, true)
+	assert.NotEqual(t, commit(a), commit(other))
+
+	// a reloaded graph goes on drawing the levels of the committed one, a
+	// removed vertex is dropped by the load so this holds without removes
+	a = NewHnsw(dim, distance.NewEuclidean(), HnswSeed(7))
+	b = NewHnsw(dim, distance.NewEuclidean(), HnswSeed(7))
+	build(a, 0, 600, false)
+	build(b, 0, 300, false)
+	reloaded := NewHnsw(dim, distance.NewEuclidean())
+	assert.Nil(t, reloaded.Load(bytes.NewReader(commit(b)), true))
+	assert.True(t, reloaded.Config().Seeded)
+	build(reloaded, 300, 600, false)
+	assert.Equal(t, commit(a), commit(reloaded))
+}
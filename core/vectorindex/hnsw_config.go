@@ -91,6 +91,15 @@ func HnswHeuristicKeepPruned(value bool) HnswOption {
 	}}
 }
 
+// HnswSeed draws the vertex levels from the seed, inserting the same vectors
+// in the same order builds the same graph and the same Commit output.
+func HnswSeed(value int64) HnswOption {
+	return &hnswOption{func(config *hnswConfig) {
+		config.seed = value
+		config.seeded = true
+	}}
+}
+
 type hnswConfig struct {
 	searchAlgorithm           hnswSearchAlgorithm
 	levelMultiplier           float32
@@ -101,6 +110,8 @@ type hnswConfig struct {
 	mMax0                     int
 	heuristicExtendCandidates bool
 	heuristicKeepPruned       bool
+	seed                      int64
+	seeded                    bool
 }
 
 type ProtoConfig struct {
@@ -113,6 +124,8 @@ type ProtoConfig struct {
 	MMax0                     int
 	HeuristicExtendCandidates bool
 	HeuristicKeepPruned       bool
+	Seed                      int64
+	Seeded                    bool
 }
 
 func newHnswConfig(options []HnswOption) *hnswConfig {
@@ -145,8 +158,12 @@ func newHnswConfig(options []HnswOption) *hnswConfig {
 }
 
 func (this *hnswConfig) String() string {
+	seed := "none"
+	if this.seeded {
+		seed = fmt.Sprint(this.seed)
+	}
 	return fmt.Sprintf(
-		"searchAlgorithm: %s, ef: %d, efConstruction: %d, m: %d, mMax: %d, mMax0: %d, levelMultiplier: %.4f, extendCandidates: %t, keepPruned: %t",
+		"searchAlgorithm: %s, ef: %d, efConstruction: %d, m: %d, mMax: %d, mMax0: %d, levelMultiplier: %.4f, extendCandidates: %t, keepPruned: %t, seed: %s",
 		this.searchAlgorithm,
 		this.ef,
 		this.efConstruction,
@@ -156,11 +173,20 @@ func (this *hnswConfig) String() string {
 		this.levelMultiplier,
 		this.heuristicExtendCandidates,
 		this.heuristicKeepPruned,
+		seed,
 	)
 }
 
+// a seeded config sets this bit of the saved search algorithm and writes the
+// seed last, configs saved before seeds existed load as unseeded
+const seededConfigFlag uint32 = 1 << 31
+
 func (this *hnswConfig) save(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, uint32(this.searchAlgorithm)); err != nil {
+	algorithm := uint32(this.searchAlgorithm)
+	if this.seeded {
+		algorithm |= seededConfigFlag
+	}
+	if err := binary.Write(w, binary.BigEndian, algorithm); err != nil {
 		return err
 	}
 	if err := binary.Write(w, binary.BigEndian, this.levelMultiplier); err != nil {
@@ -181,6 +207,9 @@ func (this *hnswConfig) save(w io.Writer) error {
 	if err := binary.Write(w, binary.BigEndian, int32(this.mMax0)); err != nil {
 		return err
 	}
+	if this.seeded {
+		return binary.Write(w, binary.BigEndian, this.seed)
+	}
 	return nil
 }
 
@@ -192,7 +221,8 @@ func (this *hnswConfig) load(r io.Reader) error {
 	if err := binary.Read(r, binary.BigEndian, &uint32Val); err != nil {
 		return err
 	}
-	this.searchAlgorithm = hnswSearchAlgorithm(uint32Val)
+	this.seeded = uint32Val&seededConfigFlag != 0
+	this.searchAlgorithm = hnswSearchAlgorithm(uint32Val &^ seededConfigFlag)
 
 	if err := binary.Read(r, binary.BigEndian, &float32Val); err != nil {
 		return err
@@ -224,5 +254,11 @@ func (this *hnswConfig) load(r io.Reader) error {
 	}
 	this.mMax0 = int(int32Val)
 
+	this.seed = 0
+	if this.seeded {
+		if err := binary.Read(r, binary.BigEndian, &this.seed); err != nil {
+			return err
+		}
+	}
 	return nil
 }
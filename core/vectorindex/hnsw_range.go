@@ -0,0 +1,52 @@
+package vectorindex
+
+import (
+	"context"
+
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+/*
+SearchRange gives the nodes within radius of query, closest first and at most
+limit of them. The level 0 beam starts at ef and doubles until its farthest
+node lies outside radius, so the frontier of the search has left the radius,
+or until the beam holds limit nodes or every node it can reach.
+*/
+func (xx *Hnsw) SearchRange(ctx context.Context, query gomath.Vector, radius float32, limit uint) (SearchResult, error) {
+	if xx.distancer.Type() == "cosine-dot" {
+		query = Normalize(query)
+	}
+	epoch := xx.arena.pin()
+	defer xx.arena.unpin(epoch)
+
+	entrypoint, ok, err := xx.descend(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || limit == 0 {
+		return make(SearchResult, 0), nil
+	}
+
+	ef := gomath.MaxInt(int(xx.ef.Load()), 1)
+	for {
+		neighbors, err := xx.searchLevel(ctx, query, entrypoint, ef, 0)
+		if err != nil {
+			return nil, err
+		}
+		// the farthest node of the beam is on top
+		if neighbors.Peek().Priority() > radius || neighbors.Len() < ef || ef >= int(limit) || ef >= xx.Len() {
+			result := make(SearchResult, neighbors.Len())
+			for i := neighbors.Len() - 1; i >= 0; i-- {
+				item := neighbors.Pop()
+				node := xx.arena.node(item.Value().(uint32))
+				result[i] = SearchResultItem{Id: node.id, Metadata: node.metadata, Score: item.Priority()}
+			}
+			n := 0
+			for n < len(result) && n < int(limit) && result[n].Score <= radius {
+				n++
+			}
+			return result[:n], nil
+		}
+		ef *= 2
+	}
+}
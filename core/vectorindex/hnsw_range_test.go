@@ -0,0 +1,48 @@
+package vectorindex
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHnswSearchRange(t *testing.T) {
+	const dim = 8
+	dist := distance.NewEuclidean()
+	items := randomBuildItems(dim, 2000)
+	index := NewHnsw(dim, dist, HnswEf(16))
+	_, err := index.BuildSlice(context.Background(), items)
+	assert.Nil(t, err)
+
+	query := gomath.RandomUniformVector(dim)
+	distances := make([]float32, len(items))
+	for i, item := range items {
+		distances[i] = dist.Distance(query, item.Vector)
+	}
+	sort.Slice(distances, func(i, j int) bool { return distances[i] < distances[j] })
+	// far more nodes than ef lie within the radius, the beam has to widen
+	radius := distances[199]
+
+	result, err := index.SearchRange(context.Background(), query, radius, 1000)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, len(result), 190)
+	assert.LessOrEqual(t, len(result), 200)
+	for i, item := range result {
+		assert.LessOrEqual(t, item.Score, radius)
+		if i > 0 {
+			assert.LessOrEqual(t, result[i-1].Score, item.Score)
+		}
+	}
+
+	result, err = index.SearchRange(context.Background(), query, radius, 50)
+	assert.Nil(t, err)
+	assert.Len(t, result, 50)
+
+	result, err = index.SearchRange(context.Background(), query, distances[0]/2, 1000)
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
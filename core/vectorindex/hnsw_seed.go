@@ -0,0 +1,41 @@
+package vectorindex
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+/* levelSource draws vertex levels from a seed. Every level takes one Int63 of
+ * the generator, so a loaded graph forwards a new generator by the number of
+ * draws its snapshot recorded and goes on with the levels the committed graph
+ * would have drawn. */
+type levelSource struct {
+	lock  sync.Mutex
+	rng   *rand.Rand
+	draws uint64
+}
+
+func newLevelSource(seed int64, draws uint64) *levelSource {
+	source := &levelSource{rng: rand.New(rand.NewSource(seed))}
+	for source.draws < draws {
+		source.rng.Int63()
+		source.draws++
+	}
+	return source
+}
+
+func (xx *levelSource) level(multiplier float32) int {
+	xx.lock.Lock()
+	u := float64(xx.rng.Int63()) / (1 << 63)
+	xx.draws++
+	xx.lock.Unlock()
+	return gomath.Floor(-gomath.Log(float32(1-u)) * multiplier)
+}
+
+func (xx *levelSource) count() uint64 {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	return xx.draws
+}
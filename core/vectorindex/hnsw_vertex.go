@@ -4,103 +4,269 @@ import (
 	"sync"
 	"sync/atomic"
 
-	"github.com/sjy-dv/nnv/edge"
+	"github.com/sjy-dv/nnv/pkg/gomath"
 )
 
-const HNSW_VERTEX_EDGE_BYTES = 8 + 4
-const HNSW_VERTEX_MUTEX_BYTES = 24
+// neighbour id + distance
+const HNSW_VERTEX_EDGE_BYTES = 4 + 4
 
-type hnswEdgeSet map[*hnswVertex]float32
+/* Vertices live in an arena addressed by a dense slot instead of one heap
+ * object per vertex. Vectors of HNSW_CHUNK_SIZE slots share one contiguous
+ * float32 block and level 0 neighbours are fixed-capacity uint32 lists in the
+ * chunk, so the graph is a handful of large allocations the GC barely scans.
+ * Neighbour lists are guarded by striped locks keyed by slot.
+ *
+ * A removed slot is retired with the epoch it was removed in and reused once
+ * the epoch has moved on twice. Every reader pins the epoch it started in and
+ * the epoch only moves on when the readers of the one before it are gone, so
+ * no search still holds the slot when it is written again. Neighbour lists may
+ * still point at a retired slot until they are pruned, the deleted mark hides
+ * it until the reuse has written the new vertex. */
+const (
+	HNSW_CHUNK_SHIFT  = 10
+	HNSW_CHUNK_SIZE   = 1 << HNSW_CHUNK_SHIFT
+	HNSW_LOCK_STRIPES = 1024
 
-type hnswVertex struct {
-	id          uint64
-	vector      edge.Vector
-	level       int
-	metadata    Metadata
-	deleted     uint32
-	edges       []hnswEdgeSet
-	edgeMutexes []*sync.RWMutex
-}
-
-func newHnswVertex(id uint64, vector edge.Vector, metadata Metadata, level int) *hnswVertex {
-	vertex := &hnswVertex{
-		id:       id,
-		vector:   vector,
-		metadata: metadata,
-		level:    level,
-		deleted:  0,
+	noEntrypoint int64 = -1
+)
+
+type hnswNode struct {
+	id       uint64
+	level    int32
+	deleted  uint32
+	metadata Metadata
+	// levels above 0, level l owns mMax+1 entries from (l-1)*(mMax+1):
+	// the neighbour count then the neighbours
+	upper     []uint32
+	upperDist []float32
+}
+
+type hnswChunk struct {
+	vectors []float32
+	nodes   []hnswNode
+	// level 0, a slot owns mMax0+1 entries laid out like hnswNode.upper
+	links0 []uint32
+	dists0 []float32
+}
+
+type hnswArena struct {
+	dim   int
+	mMax  int
+	mMax0 int
+
+	chunks atomic.Pointer[[]*hnswChunk]
+	next   atomic.Uint32
+	growMu sync.Mutex
+
+	// readers of the even and odd epochs, retired and free are guarded by growMu
+	epoch   atomic.Uint64
+	readers [2]atomic.Int64
+	retired []retiredSlot
+	free    []uint32
+
+	stripes [HNSW_LOCK_STRIPES]sync.RWMutex
+}
+
+func newHnswArena(dim, mMax, mMax0 int) *hnswArena {
+	arena := &hnswArena{dim: dim, mMax: mMax, mMax0: mMax0}
+	chunks := make([]*hnswChunk, 0)
+	arena.chunks.Store(&chunks)
+	return arena
+}
+
+type retiredSlot struct {
+	slot  uint32
+	epoch uint64
+}
+
+// pin marks a reader of the arena until unpin, the slots it reaches are not
+// reused before then.
+func (xx *hnswArena) pin() uint64 {
+	for {
+		epoch := xx.epoch.Load()
+		xx.readers[epoch&1].Add(1)
+		if xx.epoch.Load() == epoch {
+			return epoch
+		}
+		xx.readers[epoch&1].Add(-1)
 	}
-	vertex.setLevel(level)
-	return vertex
 }
 
-func (xx *hnswVertex) Id() uint64 {
-	return xx.id
+func (xx *hnswArena) unpin(epoch uint64) {
+	xx.readers[epoch&1].Add(-1)
 }
 
-func (xx *hnswVertex) Vector() edge.Vector {
-	return xx.vector
+// retire hands back a removed slot, the caller no longer reads it.
+func (xx *hnswArena) retire(slot uint32) {
+	xx.growMu.Lock()
+	xx.retired = append(xx.retired, retiredSlot{slot: slot, epoch: xx.epoch.Load()})
+	xx.growMu.Unlock()
 }
 
-func (xx *hnswVertex) Metadata() Metadata {
-	return xx.metadata
+// reclaim moves the epoch on when no reader of the epoch before is left and
+// frees the slots retired two epochs back. The caller holds growMu.
+func (xx *hnswArena) reclaim() {
+	if len(xx.retired) == 0 {
+		return
+	}
+	epoch := xx.epoch.Load()
+	if xx.readers[(epoch+1)&1].Load() == 0 {
+		epoch = xx.epoch.Add(1)
+	}
+	n := 0
+	for _, retired := range xx.retired {
+		if retired.epoch+2 <= epoch {
+			xx.free = append(xx.free, retired.slot)
+			continue
+		}
+		xx.retired[n] = retired
+		n++
+	}
+	xx.retired = xx.retired[:n]
 }
 
-func (xx *hnswVertex) Level() int {
-	return xx.level
+// alloc reserves a free slot or the next one, growing the arena by a chunk
+// when it is full.
+func (xx *hnswArena) alloc() uint32 {
+	xx.growMu.Lock()
+	defer xx.growMu.Unlock()
+	xx.reclaim()
+	if n := len(xx.free); n > 0 {
+		slot := xx.free[n-1]
+		xx.free = xx.free[:n-1]
+		return slot
+	}
+	slot := xx.next.Load()
+	chunks := *xx.chunks.Load()
+	if int(slot>>HNSW_CHUNK_SHIFT) >= len(chunks) {
+		grown := make([]*hnswChunk, len(chunks)+1)
+		copy(grown, chunks)
+		grown[len(chunks)] = &hnswChunk{
+			vectors: make([]float32, HNSW_CHUNK_SIZE*xx.dim),
+			nodes:   make([]hnswNode, HNSW_CHUNK_SIZE),
+			links0:  make([]uint32, HNSW_CHUNK_SIZE*(xx.mMax0+1)),
+			dists0:  make([]float32, HNSW_CHUNK_SIZE*xx.mMax0),
+		}
+		xx.chunks.Store(&grown)
+	}
+	xx.next.Store(slot + 1)
+	return slot
+}
+
+func (xx *hnswArena) slots() uint32 {
+	return xx.next.Load()
 }
 
-func (xx *hnswVertex) isDeleted() bool {
-	return atomic.LoadUint32(&xx.deleted) == 1
+func (xx *hnswArena) chunk(slot uint32) (*hnswChunk, int) {
+	return (*xx.chunks.Load())[slot>>HNSW_CHUNK_SHIFT], int(slot & (HNSW_CHUNK_SIZE - 1))
 }
 
-func (xx *hnswVertex) setDeleted() {
-	atomic.StoreUint32(&xx.deleted, 1)
+func (xx *hnswArena) node(slot uint32) *hnswNode {
+	chunk, offset := xx.chunk(slot)
+	return &chunk.nodes[offset]
 }
 
-func (xx *hnswVertex) setLevel(level int) {
-	xx.edges = make([]hnswEdgeSet, level+1)
-	xx.edgeMutexes = make([]*sync.RWMutex, level+1)
+// vector returns the arena backed vector of a slot, capped so appends copy.
+func (xx *hnswArena) vector(slot uint32) gomath.Vector {
+	chunk, offset := xx.chunk(slot)
+	start := offset * xx.dim
+	return chunk.vectors[start : start+xx.dim : start+xx.dim]
+}
 
-	for i := 0; i <= level; i++ {
-		xx.edges[i] = make(hnswEdgeSet)
-		xx.edgeMutexes[i] = &sync.RWMutex{}
+// init writes the vertex into its slot, the slot must not be reachable yet.
+// A reused slot is still marked deleted, the mark is cleared last.
+func (xx *hnswArena) init(slot uint32, id uint64, vector gomath.Vector, metadata Metadata, level int) {
+	copy(xx.vector(slot), vector)
+	node := xx.node(slot)
+	node.id = id
+	node.metadata = metadata
+	node.level = int32(level)
+	node.upper, node.upperDist = nil, nil
+	if level > 0 {
+		node.upper = make([]uint32, level*(xx.mMax+1))
+		node.upperDist = make([]float32, level*xx.mMax)
 	}
+	mu := xx.stripe(slot)
+	mu.Lock()
+	count, _, _ := xx.edges(slot, 0)
+	*count = 0
+	mu.Unlock()
+	atomic.StoreUint32(&node.deleted, 0)
 }
 
-func (xx *hnswVertex) edgesCount(level int) int {
-	defer xx.edgeMutexes[level].RUnlock()
-	xx.edgeMutexes[level].RLock()
+// edges returns the count cell and the neighbour storage of a slot on a level.
+// The caller holds the stripe lock of the slot.
+func (xx *hnswArena) edges(slot uint32, level int) (*uint32, []uint32, []float32) {
+	if level == 0 {
+		chunk, offset := xx.chunk(slot)
+		base := offset * (xx.mMax0 + 1)
+		return &chunk.links0[base], chunk.links0[base+1 : base+1+xx.mMax0], chunk.dists0[offset*xx.mMax0 : (offset+1)*xx.mMax0]
+	}
+	node := xx.node(slot)
+	base := (level - 1) * (xx.mMax + 1)
+	return &node.upper[base], node.upper[base+1 : base+1+xx.mMax], node.upperDist[(level-1)*xx.mMax : level*xx.mMax]
+}
 
-	return len(xx.edges[level])
+func (xx *hnswArena) stripe(slot uint32) *sync.RWMutex {
+	return &xx.stripes[slot%HNSW_LOCK_STRIPES]
 }
 
-func (xx *hnswVertex) addEdge(level int, edge *hnswVertex, distance float32) {
-	defer xx.edgeMutexes[level].Unlock()
-	xx.edgeMutexes[level].Lock()
+// isDeleted tells a link to the slot on a level is dead, the slot is removed or
+// was reused by a vertex that does not reach the level.
+func (xx *hnswArena) isDeleted(slot uint32, level int) bool {
+	node := xx.node(slot)
+	return atomic.LoadUint32(&node.deleted) == 1 || int(node.level) < level
+}
 
-	xx.edges[level][edge] = distance
+func (xx *hnswArena) setDeleted(slot uint32) {
+	atomic.StoreUint32(&xx.node(slot).deleted, 1)
 }
 
-func (xx *hnswVertex) removeEdge(level int, edge *hnswVertex) {
-	defer xx.edgeMutexes[level].Unlock()
-	xx.edgeMutexes[level].Lock()
+func (xx *hnswArena) level(slot uint32) int {
+	return int(xx.node(slot).level)
+}
 
-	delete(xx.edges[level], edge)
+// neighbors appends the live neighbours of a slot on a level to buf.
+func (xx *hnswArena) neighbors(slot uint32, level int, buf []uint32) []uint32 {
+	mu := xx.stripe(slot)
+	mu.RLock()
+	count, ids, _ := xx.edges(slot, level)
+	buf = append(buf, ids[:*count]...)
+	mu.RUnlock()
+	return buf
 }
 
-func (xx *hnswVertex) getEdges(level int) hnswEdgeSet {
-	defer xx.edgeMutexes[level].RUnlock()
-	xx.edgeMutexes[level].RLock()
+// distances returns a copy of the neighbours of a slot on a level with their distances.
+func (xx *hnswArena) distances(slot uint32, level int) ([]uint32, []float32) {
+	mu := xx.stripe(slot)
+	mu.RLock()
+	defer mu.RUnlock()
+	count, ids, dists := xx.edges(slot, level)
+	return append([]uint32(nil), ids[:*count]...), append([]float32(nil), dists[:*count]...)
+}
 
-	return xx.edges[level]
+// hnswVertex is a read-only view of a stored vertex.
+type hnswVertex struct {
+	id       uint64
+	vector   gomath.Vector
+	level    int
+	metadata Metadata
 }
 
-func (xx *hnswVertex) setEdges(level int, edges hnswEdgeSet) {
-	defer xx.edgeMutexes[level].Unlock()
-	xx.edgeMutexes[level].Lock()
+func (xx *hnswVertex) Id() uint64 {
+	return xx.id
+}
 
-	xx.edges[level] = edges
+func (xx *hnswVertex) Vector() gomath.Vector {
+	return xx.vector
+}
+
+func (xx *hnswVertex) Metadata() Metadata {
+	return xx.metadata
+}
+
+func (xx *hnswVertex) Level() int {
+	return xx.level
 }
 
 func (xx *hnswVertex) bytesSize() uint64 {
@@ -108,3 +274,33 @@ func (xx *hnswVertex) bytesSize() uint64 {
 	// float32 => 4 byte x vector len
 	return 8 + 4*uint64(len(xx.vector)) + xx.metadata.byteSize()
 }
+
+// visitedList marks slots seen by one search, bumping the epoch resets it
+// without clearing the marks.
+type visitedList struct {
+	marks []uint32
+	epoch uint32
+}
+
+func (xx *visitedList) reset(size uint32) {
+	xx.epoch++
+	if xx.epoch == 0 || int(size) > len(xx.marks) {
+		xx.marks = make([]uint32, size+HNSW_CHUNK_SIZE)
+		xx.epoch = 1
+	}
+}
+
+// visit reports whether the slot was already visited and marks it.
+func (xx *visitedList) visit(slot uint32) bool {
+	if int(slot) >= len(xx.marks) {
+		// allocated after the search started
+		grown := make([]uint32, int(slot)+HNSW_CHUNK_SIZE)
+		copy(grown, xx.marks)
+		xx.marks = grown
+	}
+	if xx.marks[slot] == xx.epoch {
+		return true
+	}
+	xx.marks[slot] = xx.epoch
+	return false
+}
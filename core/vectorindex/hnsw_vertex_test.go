@@ -0,0 +1,70 @@
+package vectorindex
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHnswArenaReusesRemovedSlots(t *testing.T) {
+	index := NewHnsw(16, distance.NewEuclidean())
+	for i := 0; i < 200; i++ {
+		assert.Nil(t, index.Insert(uint64(i), gomath.RandomUniformVector(16), nil, index.RandomLevel()))
+	}
+	update := func(id uint64) gomath.Vector {
+		vector := gomath.RandomUniformVector(16)
+		assert.Nil(t, index.Remove(id))
+		assert.Nil(t, index.Insert(id, vector, nil, index.RandomLevel()))
+		return vector
+	}
+	for i := 0; i < 20; i++ {
+		update(uint64(i))
+	}
+	// an update frees the slot of the one before last, the arena stops growing
+	slots := index.arena.slots()
+	for i := 0; i < 2000; i++ {
+		id := uint64(i % 200)
+		vector := update(id)
+		found, err := index.Search(context.Background(), vector, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, id, found[0].Id)
+	}
+	assert.Equal(t, slots, index.arena.slots())
+	assert.Equal(t, 200, index.Len())
+}
+
+func TestHnswArenaReuseUnderSearch(t *testing.T) {
+	index := NewHnsw(16, distance.NewEuclidean())
+	for i := 0; i < 200; i++ {
+		assert.Nil(t, index.Insert(uint64(i), gomath.RandomUniformVector(16), nil, index.RandomLevel()))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				found, err := index.Search(context.Background(), gomath.RandomUniformVector(16), 10)
+				assert.Nil(t, err)
+				for _, item := range found {
+					assert.Less(t, item.Id, uint64(200))
+				}
+			}
+		}()
+	}
+	for i := 0; i < 2000; i++ {
+		id := uint64(i % 200)
+		assert.Nil(t, index.Remove(id))
+		assert.Nil(t, index.Insert(id, gomath.RandomUniformVector(16), nil, index.RandomLevel()))
+	}
+	cancel()
+	wg.Wait()
+	assert.Equal(t, 200, index.Len())
+	// pinned searches hold slots back for a while, they do not leak
+	assert.Less(t, index.arena.slots(), uint32(2200))
+}
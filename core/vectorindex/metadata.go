@@ -1,10 +1,12 @@
 package vectorindex
 
 import (
+	"bytes"
 	"encoding/binary"
 	"io"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -15,8 +17,14 @@ func (this Metadata) save(w io.Writer) error {
 	if err := binary.Write(w, binary.BigEndian, uint16(len(this))); err != nil {
 		return err
 	}
-	for k, v := range this {
-		if err := this.saveKV(w, k, v); err != nil {
+	// sorted keys, equal metadata saves the same bytes
+	keys := make([]string, 0, len(this))
+	for k := range this {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := this.saveKV(w, k, this[k]); err != nil {
 			return err
 		}
 	}
@@ -46,10 +54,13 @@ func (this Metadata) saveKV(w io.Writer, k string, v any) error {
 		return err
 	}
 
-	valBytes, err := msgpack.Marshal(v)
-	if err != nil {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf).SetSortMapKeys(true)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(v); err != nil {
 		return err
 	}
+	valBytes := buf.Bytes()
 
 	if err := binary.Write(w, binary.BigEndian, uint16(len(valBytes))); err != nil {
 		return err
@@ -66,7 +77,7 @@ func (this *Metadata) loadKV(r io.Reader) (string, any, error) {
 		return "", nil, err
 	}
 	keyBytes := make([]byte, keyLength)
-	if _, err := r.Read(keyBytes); err != nil {
+	if _, err := io.ReadFull(r, keyBytes); err != nil {
 		return "", nil, err
 	}
 
@@ -75,7 +86,7 @@ func (this *Metadata) loadKV(r io.Reader) (string, any, error) {
 		return "", nil, err
 	}
 	valBytes := make([]byte, valLength)
-	if _, err := r.Read(valBytes); err != nil {
+	if _, err := io.ReadFull(r, valBytes); err != nil {
 		return "", nil, err
 	}
 
@@ -50,6 +50,15 @@ func (q BFloat16Quantization) Lower(v Vector) (bfloat16Vec, error) {
 	return out, nil
 }
 
+// Raise converts a lowered vector back to float32.
+func (q BFloat16Quantization) Raise(v bfloat16Vec) Vector {
+	out := make(Vector, len(v))
+	for i, x := range v {
+		out[i] = x.Float32()
+	}
+	return out
+}
+
 func (q BFloat16Quantization) Name() string {
 	return "float8"
 }
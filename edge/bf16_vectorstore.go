@@ -1,16 +1,17 @@
 package edge
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
-	"github.com/sjy-dv/nnv/pkg/concurrentmap"
 	"github.com/sjy-dv/nnv/pkg/distance"
 )
 
 type bf16vecSpace struct {
 	dimension      int
-	vectors        *concurrentmap.Map[uint64, bfloat16Vec]
+	vectors        *shardMap[bfloat16Vec]
+	parallelism    int
 	collectionName string
 	distance       distance.Space
 	quantization   BFloat16Quantization
@@ -20,17 +21,11 @@ type bf16vecSpace struct {
 func newBF16Vectorstore(config CollectionConfig) *bf16vecSpace {
 	return &bf16vecSpace{
 		dimension:      config.Dimension,
-		vectors:        concurrentmap.New[uint64, bfloat16Vec](),
+		vectors:        newShardMap[bfloat16Vec](),
+		parallelism:    config.ScanParallelism,
 		collectionName: config.CollectionName,
-		distance: func() distance.Space {
-			if config.Distance == COSINE {
-				return distance.NewCosine()
-			} else if config.Distance == EUCLIDEAN {
-				return distance.NewEuclidean()
-			}
-			return distance.NewCosine()
-		}(),
-		quantization: BFloat16Quantization{},
+		distance:       distanceSpace(config.Distance),
+		quantization:   BFloat16Quantization{},
 	}
 }
 
@@ -63,21 +58,51 @@ func (qx *bf16vecSpace) RemoveVector(collectionName string, id uint64) error {
 	return nil
 }
 
-func (qx *bf16vecSpace) FullScan(collectionName string, target Vector, topK int,
+func (qx *bf16vecSpace) FullScan(ctx context.Context, collectionName string, target Vector, topK int,
 ) (*ResultSet, error) {
 	if qx.distance.Type() == "cosine-dot" {
 		target = Normalize(target)
 	}
-	rs := NewResultSet(topK)
-
 	lower, err := qx.quantization.Lower(target)
 	if err != nil {
 		return nil, fmt.Errorf(ErrQuantizedFailed, err)
 	}
+	return parallelScan(ctx, qx.parallelism, topK, NewResultSet, func(shard int, rs *ResultSet, stop func() error) error {
+		var err error
+		qx.vectors.shards[shard].ForEach(func(u uint64, fv bfloat16Vec) bool {
+			if err = stop(); err != nil {
+				return false
+			}
+			sim := qx.quantization.Similarity(lower, fv, qx.distance)
+			rs.AddResult(ID(u), sim)
+			return true
+		})
+		return err
+	})
+}
+
+// dequantize returns the vector as it is stored, after the quantization roundtrip.
+func (qx *bf16vecSpace) dequantize(vector Vector) (Vector, error) {
+	if qx.distance.Type() == "cosine-dot" {
+		vector = Normalize(vector)
+	}
+	lower, err := qx.quantization.Lower(vector)
+	if err != nil {
+		return nil, fmt.Errorf(ErrQuantizedFailed, err)
+	}
+	return qx.quantization.Raise(lower), nil
+}
+
+func (qx *bf16vecSpace) forEach(fn func(id uint64, vector Vector) bool) {
 	qx.vectors.ForEach(func(u uint64, fv bfloat16Vec) bool {
-		sim := qx.quantization.Similarity(lower, fv, qx.distance)
-		rs.AddResult(ID(u), sim)
-		return true
+		return fn(u, qx.quantization.Raise(fv))
 	})
-	return rs, nil
+}
+
+func (qx *bf16vecSpace) get(id uint64) (Vector, bool) {
+	fv, ok := qx.vectors.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return qx.quantization.Raise(fv), true
 }
@@ -0,0 +1,255 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sjy-dv/nnv/pkg/distance"
+)
+
+// VectorField is one named vector of a cflat record.
+type VectorField struct {
+	Name      string `json:"name"`
+	Dimension int    `json:"dimension"`
+	Distance  string `json:"distance"`
+}
+
+// FieldQuery scores one field of a cflat search. A field scoring under
+// MinScore drops the record, Weight is its share of the composite score.
+type FieldQuery struct {
+	Field    string
+	Vector   Vector
+	Weight   float32
+	MinScore float32
+}
+
+type cflatField struct {
+	VectorField
+	offset int
+	space  distance.Space
+}
+
+type cflatQuery struct {
+	field    *cflatField
+	vector   Vector
+	weight   float32
+	minScore float32
+}
+
+/* cflatvecSpace (composite flat) keeps the field vectors of a record as one
+ * full precision vector, the fields laid out in their declared order. The
+ * same layout is what lands on disk, so a record is still a single vector for
+ * the rest of the edge. A search scores every record on each queried field
+ * and ranks by the weighted mean of the 0-100 field scores. */
+type cflatvecSpace struct {
+	fields         []*cflatField
+	dimension      int
+	vectors        *shardMap[Vector]
+	parallelism    int
+	collectionName string
+}
+
+func newCFlatVectorstore(config CollectionConfig) (*cflatvecSpace, error) {
+	if config.Quantization != NONE_QAUNTIZATION {
+		return nil, fmt.Errorf(ErrCFlatQuantization, config.Quantization)
+	}
+	if len(config.VectorFields) == 0 {
+		return nil, fmt.Errorf(ErrCFlatNoFields, config.CollectionName)
+	}
+	space := &cflatvecSpace{
+		fields:         make([]*cflatField, 0, len(config.VectorFields)),
+		vectors:        newShardMap[Vector](),
+		parallelism:    config.ScanParallelism,
+		collectionName: config.CollectionName,
+	}
+	for _, field := range config.VectorFields {
+		if field.Name == "" || field.Dimension <= 0 {
+			return nil, fmt.Errorf(ErrCFlatField, field.Name, field.Dimension)
+		}
+		if space.field(field.Name) != nil {
+			return nil, fmt.Errorf(ErrCFlatDuplicateField, field.Name)
+		}
+		space.fields = append(space.fields, &cflatField{
+			VectorField: field,
+			offset:      space.dimension,
+			space:       distanceSpace(field.Distance),
+		})
+		space.dimension += field.Dimension
+	}
+	return space, nil
+}
+
+func (qx *cflatvecSpace) field(name string) *cflatField {
+	for _, field := range qx.fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// compose lays the vector of every field out in the stored order.
+func (qx *cflatvecSpace) compose(vectors map[string]Vector) (Vector, error) {
+	for name := range vectors {
+		if qx.field(name) == nil {
+			return nil, fmt.Errorf(ErrCFlatUnknownField, name, qx.collectionName)
+		}
+	}
+	out := make(Vector, 0, qx.dimension)
+	for _, field := range qx.fields {
+		vector, ok := vectors[field.Name]
+		if !ok {
+			return nil, fmt.Errorf(ErrCFlatMissingField, field.Name)
+		}
+		if len(vector) != field.Dimension {
+			return nil, fmt.Errorf(ErrCFlatDimension, field.Name, field.Dimension, len(vector))
+		}
+		out = append(out, vector...)
+	}
+	return out, nil
+}
+
+// lower normalizes the cosine fields of a composed vector.
+func (qx *cflatvecSpace) lower(vector Vector) (Vector, error) {
+	if len(vector) != qx.dimension {
+		return nil, fmt.Errorf(ErrCFlatRecord, qx.collectionName, qx.dimension, len(vector))
+	}
+	out := vector.Clone()
+	for _, field := range qx.fields {
+		if field.space.Type() == T_COSINE {
+			copy(out[field.offset:], Normalize(out[field.offset:field.offset+field.Dimension]))
+		}
+	}
+	return out, nil
+}
+
+func (qx *cflatvecSpace) InsertVector(collectionName string, commitId uint64, vector Vector) error {
+	lower, err := qx.lower(vector)
+	if err != nil {
+		return err
+	}
+	qx.vectors.Set(commitId, lower)
+	return nil
+}
+
+func (qx *cflatvecSpace) UpdateVector(collectionName string, id uint64, vector Vector) error {
+	return qx.InsertVector(collectionName, id, vector)
+}
+
+func (qx *cflatvecSpace) RemoveVector(collectionName string, id uint64) error {
+	qx.vectors.Del(id)
+	return nil
+}
+
+// FullScan has no single query vector to rank by, cflat is searched with CompositeScan.
+func (qx *cflatvecSpace) FullScan(ctx context.Context, collectionName string, target Vector, topK int,
+) (*ResultSet, error) {
+	return nil, fmt.Errorf(ErrCFlatFieldQueries, collectionName)
+}
+
+// get gives the stored row of id, the fields concatenated.
+func (qx *cflatvecSpace) get(id uint64) (Vector, bool) {
+	return qx.vectors.Get(id)
+}
+
+func (qx *cflatvecSpace) plan(queries []FieldQuery) ([]cflatQuery, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf(ErrCFlatFieldQueries, qx.collectionName)
+	}
+	plan := make([]cflatQuery, 0, len(queries))
+	var weights float32
+	for _, query := range queries {
+		field := qx.field(query.Field)
+		if field == nil {
+			return nil, fmt.Errorf(ErrCFlatUnknownField, query.Field, qx.collectionName)
+		}
+		if len(query.Vector) != field.Dimension {
+			return nil, fmt.Errorf(ErrCFlatDimension, field.Name, field.Dimension, len(query.Vector))
+		}
+		if query.Weight < 0 {
+			return nil, fmt.Errorf(ErrCFlatWeight, field.Name)
+		}
+		vector := query.Vector
+		if field.space.Type() == T_COSINE {
+			vector = Normalize(vector)
+		}
+		plan = append(plan, cflatQuery{
+			field:    field,
+			vector:   vector,
+			weight:   query.Weight,
+			minScore: query.MinScore,
+		})
+		weights += query.Weight
+	}
+	if weights == 0 {
+		return nil, errors.New(ErrCFlatNoWeight)
+	}
+	// weights are shares of the composite
+	for i := range plan {
+		plan[i].weight /= weights
+	}
+	return plan, nil
+}
+
+// fieldScore maps a field distance to the 0-100 score.
+func fieldScore(field *cflatField, value float32) float32 {
+	return distanceScore(field.Distance, value, int32(field.Dimension))
+}
+
+// score gives the composite score of a record, ok is false when a field is
+// under its threshold.
+func score(plan []cflatQuery, vector Vector) (float32, bool) {
+	var composite float32
+	for _, query := range plan {
+		field := query.field
+		s := fieldScore(field, field.space.Distance(query.vector, vector[field.offset:field.offset+field.Dimension]))
+		if s < query.minScore {
+			return 0, false
+		}
+		composite += query.weight * s
+	}
+	return composite, true
+}
+
+/* CompositeScan ranks the records by their composite score over the sharded
+ * scan of the worker pool. The per field scores of the kept records are
+ * computed once more at the end, topK records cost far less than carrying
+ * them for every scanned one. */
+func (qx *cflatvecSpace) CompositeScan(ctx context.Context, queries []FieldQuery, topK int,
+) (*ResultSet, map[ID]map[string]float32, error) {
+	plan, err := qx.plan(queries)
+	if err != nil {
+		return nil, nil, err
+	}
+	rs, err := parallelScan(ctx, qx.parallelism, topK, NewScoreResultSet, func(shard int, rs *ResultSet, stop func() error) error {
+		var err error
+		qx.vectors.shards[shard].ForEach(func(u uint64, vector Vector) bool {
+			if err = stop(); err != nil {
+				return false
+			}
+			if composite, ok := score(plan, vector); ok {
+				rs.AddResult(ID(u), composite)
+			}
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := make(map[ID]map[string]float32, rs.valid)
+	for _, id := range rs.ids[:rs.valid] {
+		vector, ok := qx.vectors.shard(uint64(id)).Get(uint64(id))
+		if !ok {
+			continue
+		}
+		scores := make(map[string]float32, len(plan))
+		for _, query := range plan {
+			field := query.field
+			scores[field.Name] = fieldScore(field, field.space.Distance(query.vector, vector[field.offset:field.offset+field.Dimension]))
+		}
+		fields[id] = scores
+	}
+	return rs, fields, nil
+}
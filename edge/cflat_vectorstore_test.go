@@ -0,0 +1,76 @@
+package edge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCFlatCompositeScan(t *testing.T) {
+	config := CollectionConfig{
+		CollectionName: "cflat",
+		Quantization:   NONE_QAUNTIZATION,
+		IndexConfig: IndexConfig{IndexType: CFLAT_INDEX, VectorFields: []VectorField{
+			{Name: "text", Dimension: 2, Distance: COSINE},
+			{Name: "image", Dimension: 3, Distance: EUCLIDEAN},
+		}},
+	}
+	space, err := newCFlatVectorstore(config)
+	assert.Nil(t, err)
+
+	records := map[uint64]map[string]Vector{
+		1: {"text": {1, 0}, "image": {9, 9, 9}},
+		2: {"text": {0, 1}, "image": {0, 0, 0}},
+		3: {"text": {1, 0.2}, "image": {0, 0, 30}},
+	}
+	for id, fields := range records {
+		vector, err := space.compose(fields)
+		assert.Nil(t, err)
+		assert.Len(t, vector, 5)
+		assert.Nil(t, space.InsertVector("cflat", id, vector))
+	}
+
+	text := FieldQuery{Field: "text", Vector: Vector{1, 0}, Weight: 1}
+	image := FieldQuery{Field: "image", Vector: Vector{0, 0, 0}, Weight: 1}
+
+	rs, fields, err := space.CompositeScan(context.Background(), []FieldQuery{text}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, rs.valid)
+	assert.Equal(t, ID(1), rs.ids[0])
+	assert.InDelta(t, 100, fields[1]["text"], 0.01)
+
+	rs, fields, err = space.CompositeScan(context.Background(), []FieldQuery{text, image}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []ID{1, 3, 2}, rs.ids[:rs.valid])
+	assert.Len(t, fields[3], 2)
+	assert.InDelta(t, 70, fields[3]["image"], 0.01)
+
+	// weights shift the ranking
+	image.Weight = 10
+	rs, _, err = space.CompositeScan(context.Background(), []FieldQuery{text, image}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, ID(2), rs.ids[0])
+
+	// a field under its threshold drops the record
+	text.MinScore = 90
+	rs, _, err = space.CompositeScan(context.Background(), []FieldQuery{text, image}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rs.valid)
+	assert.NotContains(t, rs.ids[:rs.valid], ID(2))
+
+	_, err = space.compose(map[string]Vector{"text": {1, 0}})
+	assert.NotNil(t, err)
+	_, err = space.compose(map[string]Vector{"text": {1, 0}, "image": {0, 0}})
+	assert.NotNil(t, err)
+	_, err = space.compose(map[string]Vector{"text": {1, 0}, "image": {0, 0, 0}, "audio": {1}})
+	assert.NotNil(t, err)
+	_, _, err = space.CompositeScan(context.Background(), []FieldQuery{{Field: "text", Vector: Vector{1, 0}}}, 3)
+	assert.NotNil(t, err)
+	_, err = space.FullScan(context.Background(), "cflat", Vector{1, 0, 0, 0, 0}, 3)
+	assert.NotNil(t, err)
+
+	config.Quantization = F16_QUANTIZATION
+	_, err = newCFlatVectorstore(config)
+	assert.NotNil(t, err)
+}
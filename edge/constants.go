@@ -33,19 +33,62 @@ var (
 	edgeIndex             = "./data_dir/%s-edge.bin"
 	edgeVector            = "./data_dir/%s-vec-edge.cdat"
 	edgeConfig            = "./data_dir/%s-edge_conf.json"
+	edgeGraph             = "./data_dir/%s-edge_hnsw.raw"
 	collectionEdgeJson    = "./data_dir/collection-edge.json"
 	TargetIdNotFound      = "NodeID: %d is not found"
+
+	ErrCFlatQuantization   = "cflat collections keep full precision vectors, quantization %s is not supported"
+	ErrCFlatNoFields       = "cflat collection: %s declares no vector fields"
+	ErrCFlatField          = "cflat vector field %q needs a name and a positive dimension, got %d"
+	ErrCFlatDuplicateField = "cflat vector field %q is declared twice"
+	ErrCFlatUnknownField   = "vector field %q is not declared in collection: %s"
+	ErrCFlatMissingField   = "cflat record misses vector field %q"
+	ErrCFlatDimension      = "vector field %q expects dimension %d, got %d"
+	ErrCFlatRecord         = "cflat record of collection: %s expects %d values, got %d"
+	ErrCFlatWeight         = "cflat field %q has a negative weight"
+	ErrCFlatNoWeight       = "cflat search needs a positive weight on at least one field"
+	ErrCFlatFieldQueries   = "collection: %s is cflat, it is searched with field_queries"
+	ErrNotCFlat            = "collection: %s is not a cflat collection"
+	ErrVectorNotFound      = "vector of record %d not found in collection: %s"
+	ErrDiversifyLambda     = "diversify: lambda must be between 0 and 1"
+	ErrGroupDiversify      = "group_by search: diversify is not supported"
+	ErrRecommendCFlat      = "collection: %s is cflat, recommend is not supported"
+	ErrRecommendPositive   = "recommend: needs a positive example"
+	ErrRecommendRecord     = "recommend: record %s not found"
+	ErrRecommendDim        = "recommend: example vector has dimension %d, expected %d"
+	ErrRangeSearch         = "radius search: needs a vector, it does not combine with field_queries, group_by or diversify"
+	ErrPageToken           = "page token: invalid or of another query"
+	ErrPageOffset          = "page token: offset must be 0 with a page token"
+	ErrPageSearch          = "paging: does not combine with group_by or diversify"
 )
 
 const (
 	COSINE                   = "cosine"
 	EUCLIDEAN                = "euclidean"
+	INNER_PRODUCT            = "inner-product"
+	MANHATTAN                = "manhattan"
+	HAMMING                  = "hamming"
 	NONE_QAUNTIZATION        = "none"
 	F16_QUANTIZATION         = "f16"
 	F8_QUANTIZATION          = "f8"
 	BF16_QUANTIZATION        = "bf16"
 	T_COSINE                 = "cosine-dot"
+	FLAT_INDEX               = "flat"
+	HNSW_INDEX               = "hnsw"
+	CFLAT_INDEX              = "cflat"
 	EDGE_MAP_SHARD_COUNT int = 16
+	// a geo filter drops most of the scan candidates, keep more before filtering
+	GEO_OVERSAMPLE = 12
+	// candidates a diversified search picks from per result, unless fetch_k says otherwise
+	MMR_OVERSAMPLE = 4
+	// times a grouped search doubles its scan at most
+	GROUP_MAX_WIDEN = 6
+	// a filtered recommend scan drops candidates afterwards, scan wider first
+	RECOMMEND_OVERSAMPLE = 3
+	// results a radius search gives at most
+	RANGE_MAX_RESULTS = 10000
+	// search breadth of hnsw collections created without ef_search
+	DEFAULT_HNSW_EF_SEARCH = 64
 )
 
 type ENode struct {
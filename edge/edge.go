@@ -19,16 +19,19 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/sjy-dv/nnv/diskv"
 	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
 	"github.com/sjy-dv/nnv/gen/protoc/v2/phonyproto"
 	"github.com/sjy-dv/nnv/pkg/concurrentmap"
+	"github.com/sjy-dv/nnv/pkg/mmr"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -45,6 +48,9 @@ type EdgeData struct {
 	dim          int32
 	distance     string
 	quantization string
+	index        IndexConfig
+	maxQueryTime time.Duration
+	parallelism  int
 	lock         sync.RWMutex
 }
 
@@ -98,6 +104,149 @@ func (xx *Edge) getDist(collectionName string) string {
 	return val.distance
 }
 
+func (xx *Edge) getDim(collectionName string) int32 {
+	val, ok := xx.Datas.Get(collectionName)
+	if ok {
+		return val.dim
+	}
+	return 0
+}
+
+// queryContext bounds a search by the rpc ctx and the collection max query time.
+func (xx *Edge) queryContext(ctx context.Context, collectionName string) (context.Context, context.CancelFunc) {
+	val, ok := xx.Datas.Get(collectionName)
+	if ok && val.maxQueryTime > 0 {
+		return context.WithTimeout(ctx, val.maxQueryTime)
+	}
+	return context.WithCancel(ctx)
+}
+
+// contextError turns a scan stopped by its ctx into the grpc
+// DEADLINE_EXCEEDED or CANCELLED status, other errors give nil.
+func contextError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+func distanceName(dist edgeproto.Distance) string {
+	switch dist {
+	case edgeproto.Distance_Cosine:
+		return COSINE
+	case edgeproto.Distance_InnerProduct:
+		return INNER_PRODUCT
+	case edgeproto.Distance_Manhattan:
+		return MANHATTAN
+	case edgeproto.Distance_Hamming:
+		return HAMMING
+	}
+	return EUCLIDEAN
+}
+
+// datasetVector is the vector stored for a record, the field vectors of a
+// cflat record are composed into one.
+func (xx *Edge) datasetVector(req *edgeproto.ModifyDataset) (Vector, error) {
+	if len(req.GetVectors()) == 0 {
+		return req.GetVector(), nil
+	}
+	vectors := make(map[string]Vector, len(req.GetVectors()))
+	for name, field := range req.GetVectors() {
+		vectors[name] = field.GetVector()
+	}
+	return xx.VectorStore.ComposeVector(req.GetCollectionName(), vectors)
+}
+
+// scanHelper runs the vector part of a search. Field queries score a cflat
+// collection, its scores come back final along with the score of each field.
+func (xx *Edge) scanHelper(ctx context.Context, req *edgeproto.SearchReq, topK int,
+) (*ResultSet, map[ID]map[string]float32, error) {
+	if len(req.GetFieldQueries()) == 0 {
+		rs, err := xx.VectorStore.FullScan(ctx, req.GetCollectionName(), req.GetVector(), topK)
+		return rs, nil, err
+	}
+	queries := make([]FieldQuery, 0, len(req.GetFieldQueries()))
+	for _, query := range req.GetFieldQueries() {
+		queries = append(queries, FieldQuery{
+			Field:    query.GetField(),
+			Vector:   query.GetVector(),
+			Weight:   query.GetWeight(),
+			MinScore: query.GetMinScore(),
+		})
+	}
+	return xx.VectorStore.CompositeScan(ctx, req.GetCollectionName(), queries, topK)
+}
+
+// rangeSearch gives the ids within the radius of the request with their scan
+// values, closest first and up to limit, or RANGE_MAX_RESULTS when limit is 0.
+func (xx *Edge) rangeSearch(ctx context.Context, req *edgeproto.SearchReq, limit int) ([]ID, []float32, error) {
+	if len(req.GetVector()) == 0 || len(req.GetFieldQueries()) > 0 || req.GetDiversify() != nil {
+		return nil, nil, errors.New(ErrRangeSearch)
+	}
+	if limit == 0 || limit > RANGE_MAX_RESULTS {
+		limit = RANGE_MAX_RESULTS
+	}
+	space := distanceSpace(xx.getDist(req.GetCollectionName()))
+	return xx.VectorStore.RangeScan(ctx, req.GetCollectionName(), req.GetVector(), space, req.GetRadius(), limit)
+}
+
+// diversifyFetch is how many candidates a search scans, topK without diversify.
+func diversifyFetch(diversify *edgeproto.Diversify, topK int) int {
+	if diversify == nil {
+		return topK
+	}
+	if diversify.GetFetchK() == 0 {
+		return topK * MMR_OVERSAMPLE
+	}
+	return max(int(diversify.GetFetchK()), topK)
+}
+
+/* diversify picks k of the scanned candidates by maximal marginal relevance.
+ * sims are the scan values of ids, final scores for a composite scan. The
+ * relevance is the score scaled to [0, 1] over the candidates, two candidates
+ * are compared by their pairScore. */
+func (xx *Edge) diversify(req *edgeproto.SearchReq, ids []ID, sims []float32, composite bool, k int,
+) ([]ID, []float32, error) {
+	lambda := float32(mmr.DefaultLambda)
+	if req.GetDiversify().Lambda != nil {
+		lambda = req.GetDiversify().GetLambda()
+		if lambda < 0 || lambda > 1 {
+			return nil, nil, errors.New(ErrDiversifyLambda)
+		}
+	}
+	dist := xx.getDist(req.GetCollectionName())
+	dim := xx.getDim(req.GetCollectionName())
+	keep := make([]int, 0, len(ids))
+	relevance := make([]float32, 0, len(ids))
+	vectors := make([]Vector, 0, len(ids))
+	for i, id := range ids {
+		score := sims[i]
+		if !composite {
+			if beyondCutoff(dist, sims[i], req.GetMinScoreThreshold()) {
+				continue
+			}
+			score = distanceScore(dist, sims[i], dim)
+		}
+		vector, err := xx.VectorStore.Vector(req.GetCollectionName(), uint64(id))
+		if err != nil {
+			return nil, nil, err
+		}
+		keep = append(keep, i)
+		relevance = append(relevance, score)
+		vectors = append(vectors, vector)
+	}
+	space := distanceSpace(dist)
+	picked := mmr.Select(mmr.Normalize(relevance), func(i, j int) float32 {
+		return pairScore(dist, space, vectors[i], vectors[j], dim) / 100
+	}, lambda, k)
+	outIds := make([]ID, len(picked))
+	outSims := make([]float32, len(picked))
+	for i, pick := range picked {
+		outIds[i], outSims[i] = ids[keep[pick]], sims[keep[pick]]
+	}
+	return outIds, outSims, nil
+}
+
 func (xx *Edge) CreateCollection(ctx context.Context, req *edgeproto.Collection) (
 	*edgeproto.CollectionResponse, error) {
 	type reply struct {
@@ -127,12 +276,7 @@ func (xx *Edge) CreateCollection(ctx context.Context, req *edgeproto.Collection)
 			}
 			return
 		}
-		dist := func() string {
-			if req.GetDistance() == edgeproto.Distance_Cosine {
-				return COSINE
-			}
-			return EUCLIDEAN
-		}()
+		dist := distanceName(req.GetDistance())
 		q := func() string {
 			if req.GetQuantization() == edgeproto.Quantization_F16 {
 				return F16_QUANTIZATION
@@ -145,6 +289,26 @@ func (xx *Edge) CreateCollection(ctx context.Context, req *edgeproto.Collection)
 			}
 			return NONE_QAUNTIZATION
 		}()
+		index := IndexConfig{IndexType: FLAT_INDEX}
+		if req.GetIndexType() == edgeproto.IndexType_Hnsw {
+			index = IndexConfig{
+				IndexType:          HNSW_INDEX,
+				HnswM:              int(req.GetHnswParams().GetM()),
+				HnswEfConstruction: int(req.GetHnswParams().GetEfConstruction()),
+				HnswEfSearch:       int(req.GetHnswParams().GetEfSearch()),
+			}
+		}
+		if req.GetIndexType() == edgeproto.IndexType_CFlat {
+			fields := make([]VectorField, 0, len(req.GetVectorFields()))
+			for _, field := range req.GetVectorFields() {
+				fields = append(fields, VectorField{
+					Name:      field.GetName(),
+					Dimension: int(field.GetDim()),
+					Distance:  distanceName(field.GetDistance()),
+				})
+			}
+			index = IndexConfig{IndexType: CFLAT_INDEX, VectorFields: fields}
+		}
 		// xx.lock.Lock()
 		// xx.Datas[req.GetCollectionName()] = &EdgeData{
 		// 	dim:          int32(req.GetDim()),
@@ -156,13 +320,19 @@ func (xx *Edge) CreateCollection(ctx context.Context, req *edgeproto.Collection)
 			dim:          int32(req.GetDim()),
 			distance:     dist,
 			quantization: q,
+			index:        index,
+			maxQueryTime: time.Duration(req.GetMaxQueryTimeMs()) * time.Millisecond,
+			parallelism:  int(req.GetScanParallelism()),
 		})
 		//=========vector============
 		cfg := CollectionConfig{
-			Dimension:      int(req.GetDim()),
-			CollectionName: req.GetCollectionName(),
-			Distance:       dist,
-			Quantization:   q,
+			Dimension:       int(req.GetDim()),
+			CollectionName:  req.GetCollectionName(),
+			Distance:        dist,
+			Quantization:    q,
+			MaxQueryTimeMs:  req.GetMaxQueryTimeMs(),
+			ScanParallelism: int(req.GetScanParallelism()),
+			IndexConfig:     index,
 		}
 		err := xx.VectorStore.CreateCollection(cfg)
 		if err != nil {
@@ -240,10 +410,15 @@ func (xx *Edge) CreateCollection(ctx context.Context, req *edgeproto.Collection)
 			Result: &edgeproto.CollectionResponse{
 				Status: true,
 				Collection: &edgeproto.Collection{
-					CollectionName: req.GetCollectionName(),
-					Distance:       req.Distance,
-					Quantization:   req.Quantization,
-					Dim:            req.GetDim(),
+					CollectionName:  req.GetCollectionName(),
+					Distance:        req.Distance,
+					Quantization:    req.Quantization,
+					Dim:             req.GetDim(),
+					IndexType:       req.GetIndexType(),
+					HnswParams:      req.GetHnswParams(),
+					MaxQueryTimeMs:  req.GetMaxQueryTimeMs(),
+					ScanParallelism: req.GetScanParallelism(),
+					VectorFields:    req.GetVectorFields(),
 				},
 			},
 		}
@@ -448,6 +623,9 @@ func (xx *Edge) LoadCollection(ctx context.Context, req *edgeproto.CollectionNam
 			dim:          int32(loadConfig.Dimension),
 			distance:     loadConfig.Distance,
 			quantization: loadConfig.Quantization,
+			index:        loadConfig.IndexConfig,
+			maxQueryTime: time.Duration(loadConfig.MaxQueryTimeMs) * time.Millisecond,
+			parallelism:  loadConfig.ScanParallelism,
 		}
 		// xx.lock.Lock()
 		// xx.Datas[req.GetCollectionName()] = &EdgeData{}
@@ -528,6 +706,11 @@ func (xx *Edge) ReleaseCollection(ctx context.Context, req *edgeproto.Collection
 			c <- reply{Result: &edgeproto.Response{Status: false, Error: &edgeproto.Error{ErrorMessage: err.Error(), ErrorCode: edgeproto.ErrorCode_INTERNAL_FUNC_ERROR}}}
 			return
 		}
+		err = xx.VectorStore.Commit(req.GetCollectionName())
+		if err != nil {
+			c <- reply{Result: &edgeproto.Response{Status: false, Error: &edgeproto.Error{ErrorMessage: err.Error(), ErrorCode: edgeproto.ErrorCode_INTERNAL_FUNC_ERROR}}}
+			return
+		}
 
 		// xx.lock.Lock()
 		// delete(xx.Datas, req.GetCollectionName())
@@ -664,26 +847,31 @@ func (xx *Edge) Insert(ctx context.Context, req *edgeproto.ModifyDataset) (
 			}
 			return
 		}
+		vector, err := xx.datasetVector(req)
+		if err != nil {
+			c <- reply{Result: &edgeproto.Response{Status: false, Error: &edgeproto.Error{ErrorMessage: err.Error(), ErrorCode: edgeproto.ErrorCode_INTERNAL_FUNC_ERROR}}}
+			return
+		}
 		autoID := autoCommitID()
 		cloneMap := req.GetMetadata().AsMap()
 		// xx.Datas[req.GetCollectionName()].lock.Lock()
 		// xx.Datas[req.GetCollectionName()].Data[autoID] = cloneMap
 		// xx.Datas[req.GetCollectionName()].lock.Unlock()
 
-		err := indexdb.indexes[req.GetCollectionName()].Add(autoID, cloneMap)
+		err = indexdb.indexes[req.GetCollectionName()].Add(autoID, cloneMap)
 		if err != nil {
 			c <- reply{Result: &edgeproto.Response{Status: false, Error: &edgeproto.Error{ErrorMessage: err.Error(), ErrorCode: edgeproto.ErrorCode_INTERNAL_FUNC_ERROR}}}
 			return
 		}
 
-		err = xx.VectorStore.InsertVector(req.GetCollectionName(), autoID, req.GetVector())
+		err = xx.VectorStore.InsertVector(req.GetCollectionName(), autoID, vector)
 		if err != nil {
 			c <- reply{Result: &edgeproto.Response{Status: false, Error: &edgeproto.Error{ErrorMessage: err.Error(), ErrorCode: edgeproto.ErrorCode_INTERNAL_FUNC_ERROR}}}
 			return
 		}
 		phonywrap := phonyproto.PhonyWrapper{
 			Id:       req.GetId(),
-			Vector:   req.GetVector(),
+			Vector:   vector,
 			Metadata: req.GetMetadata(),
 		}
 		mapping, err := proto.Marshal(&phonywrap)
@@ -757,6 +945,11 @@ func (xx *Edge) Update(ctx context.Context, req *edgeproto.ModifyDataset) (
 			}
 			return
 		}
+		vector, err := xx.datasetVector(req)
+		if err != nil {
+			c <- reply{Result: &edgeproto.Response{Status: false, Error: &edgeproto.Error{ErrorMessage: err.Error(), ErrorCode: edgeproto.ErrorCode_INTERNAL_FUNC_ERROR}}}
+			return
+		}
 		// xx.Datas[req.GetCollectionName()].lock.RLock()
 		// cloneMeta := xx.Datas[req.GetCollectionName()].Data[getId[0]]
 		// xx.Datas[req.GetCollectionName()].lock.RUnlock()
@@ -818,7 +1011,7 @@ func (xx *Edge) Update(ctx context.Context, req *edgeproto.ModifyDataset) (
 			}
 			return
 		}
-		err = xx.VectorStore.UpdateVector(req.GetCollectionName(), getId[0], req.GetVector())
+		err = xx.VectorStore.UpdateVector(req.GetCollectionName(), getId[0], vector)
 		if err != nil {
 			c <- reply{
 				Result: &edgeproto.Response{
@@ -833,7 +1026,7 @@ func (xx *Edge) Update(ctx context.Context, req *edgeproto.ModifyDataset) (
 		}
 		phonywrap := phonyproto.PhonyWrapper{
 			Id:       req.GetId(),
-			Vector:   req.GetVector(),
+			Vector:   vector,
 			Metadata: req.GetMetadata(),
 		}
 		mapping, err := proto.Marshal(&phonywrap)
@@ -1053,15 +1246,44 @@ func (xx *Edge) VectorSearch(ctx context.Context, req *edgeproto.SearchReq) (
 			return
 		}
 		var (
-			rs  *ResultSet
-			err error
+			rs     *ResultSet
+			fields map[ID]map[string]float32
+			err    error
 		)
 		// if xx.getQuantization(req.GetCollectionName()) == NONE_QAUNTIZATION {
 		// 	rs, err = normalEdgeV.FullScan(req.GetCollectionName(), req.GetVector(), int(req.GetTopK()))
 		// } else {
 		// 	rs, err = quantizedEdgeV.FullScan(req.GetCollectionName(), req.GetVector(), int(req.GetTopK()))
 		// }
-		rs, err = xx.VectorStore.FullScan(req.GetCollectionName(), req.GetVector(), int(req.GetTopK()))
+		ctx, cancel := xx.queryContext(ctx, req.GetCollectionName())
+		defer cancel()
+		if req.GetGroupBy() != "" {
+			groups, err := xx.groupSearch(ctx, req)
+			if err != nil {
+				if cerr := contextError(err); cerr != nil {
+					c <- reply{Error: cerr}
+					return
+				}
+				c <- reply{
+					Result: &edgeproto.SearchResponse{
+						Status: false,
+						Error: &edgeproto.Error{
+							ErrorMessage: err.Error(),
+							ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+						},
+					},
+				}
+				return
+			}
+			c <- reply{
+				Result: &edgeproto.SearchResponse{
+					Status: true,
+					Groups: groups,
+				},
+			}
+			return
+		}
+		page, err := newPageQuery(req)
 		if err != nil {
 			c <- reply{
 				Result: &edgeproto.SearchResponse{
@@ -1072,19 +1294,37 @@ func (xx *Edge) VectorSearch(ctx context.Context, req *edgeproto.SearchReq) (
 					},
 				},
 			}
+			return
 		}
-		dist := xx.getDist(req.GetCollectionName())
-		retval := make([]*edgeproto.Candidates, 0, req.GetTopK())
-		for rank, nodeId := range rs.ids {
-			if dist == EUCLIDEAN {
-				if rs.sims[rank] > 100 {
-					continue
-				}
+		scanK := int(page.fetch(req.GetTopK()))
+		if req.GetDiversify() != nil {
+			scanK = diversifyFetch(req.GetDiversify(), int(req.GetTopK()))
+		}
+		var ids []ID
+		var sims []float32
+		if req.Radius != nil {
+			ids, sims, err = xx.rangeSearch(ctx, req, scanK)
+		} else if rs, fields, err = xx.scanHelper(ctx, req, scanK); err == nil {
+			ids, sims = rs.ids[:rs.valid], rs.sims[:rs.valid]
+		}
+		if err != nil {
+			if cerr := contextError(err); cerr != nil {
+				c <- reply{Error: cerr}
+				return
 			}
-			// xx.Datas[req.GetCollectionName()].lock.RLock()
-			// clone := xx.Datas[req.GetCollectionName()].Data[uint64(nodeId)]
-			// xx.Datas[req.GetCollectionName()].lock.RUnlock()
-			phonyD, err := xx.Disk.Get([]byte(fmt.Sprintf("%s_%d", req.GetCollectionName(), nodeId)))
+			c <- reply{
+				Result: &edgeproto.SearchResponse{
+					Status: false,
+					Error: &edgeproto.Error{
+						ErrorMessage: err.Error(),
+						ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+					},
+				},
+			}
+			return
+		}
+		if req.GetDiversify() != nil {
+			ids, sims, err = xx.diversify(req, ids, sims, fields != nil, int(req.GetTopK()))
 			if err != nil {
 				c <- reply{
 					Result: &edgeproto.SearchResponse{
@@ -1095,9 +1335,22 @@ func (xx *Edge) VectorSearch(ctx context.Context, req *edgeproto.SearchReq) (
 						},
 					},
 				}
+				return
 			}
-			phonydec := phonyproto.PhonyWrapper{}
-			err = proto.Unmarshal(phonyD, &phonydec)
+		}
+		dist := xx.getDist(req.GetCollectionName())
+		dim := xx.getDim(req.GetCollectionName())
+		proj := newProjection(req)
+		retval := make([]*edgeproto.Candidates, 0, req.GetTopK())
+		for rank, nodeId := range ids {
+			// the radius bounds a range search instead
+			if fields == nil && req.Radius == nil && beyondCutoff(dist, sims[rank], req.GetMinScoreThreshold()) {
+				continue
+			}
+			// xx.Datas[req.GetCollectionName()].lock.RLock()
+			// clone := xx.Datas[req.GetCollectionName()].Data[uint64(nodeId)]
+			// xx.Datas[req.GetCollectionName()].lock.RUnlock()
+			candidate, err := xx.searchCandidate(req.GetCollectionName(), nodeId, sims[rank], fields, dist, dim, proj)
 			if err != nil {
 				c <- reply{
 					Result: &edgeproto.SearchResponse{
@@ -1108,23 +1361,24 @@ func (xx *Edge) VectorSearch(ctx context.Context, req *edgeproto.SearchReq) (
 						},
 					},
 				}
+				return
+			}
+			if candidate.Score < req.GetMinScoreThreshold() {
+				continue
 			}
-			candidate := new(edgeproto.Candidates)
-			candidate.Id = phonydec.GetId()
-			candidate.Metadata = phonydec.GetMetadata()
-			candidate.Score = func() float32 {
-				if dist == COSINE {
-					return ((rs.sims[rank] + 1) / 2) * 100
-				}
-				return float32(math.Max(0, float64(100-rs.sims[rank])))
-			}()
 
 			retval = append(retval, candidate)
 		}
+		// diversified results keep their pick order
+		var nextPageToken string
+		if req.GetDiversify() == nil {
+			retval, nextPageToken = page.page(retval, req.GetTopK())
+		}
 		c <- reply{
 			Result: &edgeproto.SearchResponse{
-				Status:     true,
-				Candidates: retval,
+				Status:        true,
+				Candidates:    retval,
+				NextPageToken: nextPageToken,
 			},
 		}
 	}()
@@ -1172,11 +1426,40 @@ func (xx *Edge) FilterSearch(ctx context.Context, req *edgeproto.SearchReq) (
 			}
 			return
 		}
+		geo, err := geoQueryHelper(req.GetGeoFilter())
+		if err != nil {
+			c <- reply{
+				Result: &edgeproto.SearchResponse{
+					Status: false,
+					Error: &edgeproto.Error{
+						ErrorMessage: err.Error(),
+						ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+					},
+				},
+			}
+			return
+		}
+		page, err := newPageQuery(req)
+		if err != nil {
+			c <- reply{
+				Result: &edgeproto.SearchResponse{
+					Status: false,
+					Error: &edgeproto.Error{
+						ErrorMessage: err.Error(),
+						ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+					},
+				},
+			}
+			return
+		}
 		indexdb.indexLock.RLock()
-		candidates := indexdb.indexes[req.GetCollectionName()].PureSearch(req.GetFilter())
+		bitmap := indexdb.indexes[req.GetCollectionName()]
+		candidates := bitmap.PureGeoSearch(req.GetFilter(), geo)
 		indexdb.indexLock.RUnlock()
-		retval := make([]*edgeproto.Candidates, 0, req.GetTopK())
-		for _, nodeId := range candidates {
+		start, end := page.span(len(candidates), req.GetTopK())
+		proj := newProjection(req)
+		retval := make([]*edgeproto.Candidates, 0, end-start)
+		for _, nodeId := range candidates[start:end] {
 			// xx.Datas[req.GetCollectionName()].lock.RLock()
 			// clone := xx.Datas[req.GetCollectionName()].Data[nodeId]
 			// xx.Datas[req.GetCollectionName()].lock.RUnlock()
@@ -1207,14 +1490,22 @@ func (xx *Edge) FilterSearch(ctx context.Context, req *edgeproto.SearchReq) (
 			}
 			candidate := new(edgeproto.Candidates)
 			candidate.Id = phonydec.GetId()
-			candidate.Metadata = phonydec.GetMetadata()
+			proj.apply(candidate, &phonydec)
 			candidate.Score = 100
+			if geo != nil {
+				candidate.GeoDistance, _ = bitmap.GeoDistance(geo.Field, nodeId, geo.Origin())
+			}
 			retval = append(retval, candidate)
 		}
+		var nextPageToken string
+		if end < len(candidates) && len(retval) > 0 {
+			nextPageToken = page.next(len(retval), retval[len(retval)-1])
+		}
 		c <- reply{
 			Result: &edgeproto.SearchResponse{
-				Status:     true,
-				Candidates: retval,
+				Status:        true,
+				Candidates:    retval,
+				NextPageToken: nextPageToken,
 			},
 		}
 	}()
@@ -1271,16 +1562,40 @@ func (xx *Edge) HybridSearch(ctx context.Context, req *edgeproto.SearchReq) (
 		// cosine => 100 - (score * 100)
 		// euclidean => 100 - score// when score > 100 going away //(0~ infinite)
 		var (
-			rs  *ResultSet
-			err error
+			rs     *ResultSet
+			fields map[ID]map[string]float32
+			err    error
 		)
+		geo, err := geoQueryHelper(req.GetGeoFilter())
+		if err != nil {
+			c <- reply{
+				Result: &edgeproto.SearchResponse{
+					Status: false,
+					Error: &edgeproto.Error{
+						ErrorMessage: err.Error(),
+						ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+					},
+				},
+			}
+			return
+		}
+		scanK := diversifyFetch(req.GetDiversify(), int(req.GetTopK()))
+		if geo != nil {
+			scanK *= GEO_OVERSAMPLE
+		}
 		// if xx.getQuantization(req.GetCollectionName()) == NONE_QAUNTIZATION {
 		// 	rs, err = normalEdgeV.FullScan(req.GetCollectionName(), req.GetVector(), int(req.GetTopK())*3)
 		// } else {
 		// 	rs, err = quantizedEdgeV.FullScan(req.GetCollectionName(), req.GetVector(), int(req.GetTopK())*3)
 		// }
-		rs, err = xx.VectorStore.FullScan(req.GetCollectionName(), req.GetVector(), int(req.GetTopK()))
+		ctx, cancel := xx.queryContext(ctx, req.GetCollectionName())
+		defer cancel()
+		rs, fields, err = xx.scanHelper(ctx, req, scanK)
 		if err != nil {
+			if cerr := contextError(err); cerr != nil {
+				c <- reply{Error: cerr}
+				return
+			}
 			c <- reply{
 				Result: &edgeproto.SearchResponse{
 					Status: false,
@@ -1290,23 +1605,54 @@ func (xx *Edge) HybridSearch(ctx context.Context, req *edgeproto.SearchReq) (
 					},
 				},
 			}
+			return
 		}
 		scores := make(map[uint64]float32)
-		cvU64 := make([]uint64, 0, len(rs.ids))
-		for index, candidate := range rs.ids {
+		cvU64 := make([]uint64, 0, rs.valid)
+		for index, candidate := range rs.ids[:rs.valid] {
 			scores[uint64(candidate)] = rs.sims[index]
 			cvU64 = append(cvU64, uint64(candidate))
 		}
 		dist := xx.getDist(req.GetCollectionName())
+		dim := xx.getDim(req.GetCollectionName())
 		indexdb.indexLock.RLock()
-		mergeCandidates := indexdb.indexes[req.GetCollectionName()].SearchWitCandidates(cvU64, req.GetFilter())
+		bitmap := indexdb.indexes[req.GetCollectionName()]
+		mergeCandidates := bitmap.SearchWitCandidatesGeo(cvU64, req.GetFilter(), geo)
 		indexdb.indexLock.RUnlock()
+		// the filter gives the ids in id order, the scan order is kept unless
+		// the geo filter sorts by distance
+		if geo == nil || !geo.SortByDistance {
+			mergeCandidates = scanOrder(cvU64, mergeCandidates)
+		}
+		if req.GetDiversify() != nil {
+			ids := make([]ID, len(mergeCandidates))
+			sims := make([]float32, len(mergeCandidates))
+			for i, nodeId := range mergeCandidates {
+				ids[i], sims[i] = ID(nodeId), scores[nodeId]
+			}
+			ids, _, err = xx.diversify(req, ids, sims, fields != nil, int(req.GetTopK()))
+			if err != nil {
+				c <- reply{
+					Result: &edgeproto.SearchResponse{
+						Status: false,
+						Error: &edgeproto.Error{
+							ErrorMessage: err.Error(),
+							ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+						},
+					},
+				}
+				return
+			}
+			mergeCandidates = make([]uint64, len(ids))
+			for i, id := range ids {
+				mergeCandidates[i] = uint64(id)
+			}
+		}
 		retval := make([]*edgeproto.Candidates, 0, len(mergeCandidates))
+		proj := newProjection(req)
 		for _, nodeId := range mergeCandidates {
-			if dist == EUCLIDEAN {
-				if scores[nodeId] > 100 {
-					continue
-				}
+			if fields == nil && beyondCutoff(dist, scores[nodeId], req.GetMinScoreThreshold()) {
+				continue
 			}
 			phonyD, err := xx.Disk.Get([]byte(fmt.Sprintf("%s_%d", req.GetCollectionName(), nodeId)))
 			if err != nil {
@@ -1319,6 +1665,7 @@ func (xx *Edge) HybridSearch(ctx context.Context, req *edgeproto.SearchReq) (
 						},
 					},
 				}
+				return
 			}
 			phonydec := phonyproto.PhonyWrapper{}
 			err = proto.Unmarshal(phonyD, &phonydec)
@@ -1332,26 +1679,36 @@ func (xx *Edge) HybridSearch(ctx context.Context, req *edgeproto.SearchReq) (
 						},
 					},
 				}
+				return
 			}
 			candidate := new(edgeproto.Candidates)
 			candidate.Id = phonydec.GetId()
-			candidate.Metadata = phonydec.GetMetadata()
-			candidate.Score = func() float32 {
-				if dist == COSINE {
-					return ((scores[nodeId] + 1) / 2) * 100
-				}
-				return float32(math.Max(0, float64(100-scores[nodeId])))
-			}()
+			proj.apply(candidate, &phonydec)
+			if fields != nil {
+				candidate.Score = scores[nodeId]
+				candidate.FieldScores = fields[ID(nodeId)]
+			} else {
+				candidate.Score = distanceScore(dist, scores[nodeId], dim)
+			}
+			if candidate.Score < req.GetMinScoreThreshold() {
+				continue
+			}
+			if geo != nil {
+				candidate.GeoDistance, _ = bitmap.GeoDistance(geo.Field, nodeId, geo.Origin())
+			}
 			retval = append(retval, candidate)
 		}
-		slices.SortFunc(retval, func(i, j *edgeproto.Candidates) int {
-			if i.Score > j.Score {
-				return -1
-			} else if i.Score < j.Score {
-				return 1
-			}
-			return 0
-		})
+		// a diversified list is in pick order already
+		if req.GetDiversify() == nil && (geo == nil || !geo.SortByDistance) {
+			slices.SortFunc(retval, func(i, j *edgeproto.Candidates) int {
+				if i.Score > j.Score {
+					return -1
+				} else if i.Score < j.Score {
+					return 1
+				}
+				return 0
+			})
+		}
 		if len(retval) > int(req.GetTopK()) {
 			retval = retval[:req.GetTopK()]
 		}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
 	"github.com/sjy-dv/nnv/pkg/index"
 )
 
@@ -87,3 +88,44 @@ func (xx *IndexGroup) DropIndex(collectionName string) error {
 	}()
 	return <-c
 }
+
+// scanOrder gives the ids of ranked that are in matched, in the order of
+// ranked.
+func scanOrder(ranked, matched []uint64) []uint64 {
+	keep := make(map[uint64]struct{}, len(matched))
+	for _, id := range matched {
+		keep[id] = struct{}{}
+	}
+	out := make([]uint64, 0, len(matched))
+	for _, id := range ranked {
+		if _, ok := keep[id]; ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func geoQueryHelper(f *edgeproto.GeoFilter) (*index.GeoQuery, error) {
+	if f == nil {
+		return nil, nil
+	}
+	if f.GetField() == "" {
+		return nil, errors.New("geo filter field is empty")
+	}
+	q := &index.GeoQuery{Field: f.GetField(), SortByDistance: f.GetSortByDistance()}
+	if f.GetRadiusMeters() > 0 {
+		q.Center = index.GeoPoint{Lat: f.GetCenter().GetLat(), Lon: f.GetCenter().GetLon()}
+		q.Radius = f.GetRadiusMeters()
+		return q, nil
+	}
+	box := f.GetBoundingBox()
+	if box == nil {
+		return nil, errors.New("geo filter needs a radius or a bounding box")
+	}
+	q.MaxLat, q.MinLon = box.GetTopLeft().GetLat(), box.GetTopLeft().GetLon()
+	q.MinLat, q.MaxLon = box.GetBottomRight().GetLat(), box.GetBottomRight().GetLon()
+	if q.MinLat > q.MaxLat {
+		return nil, errors.New("geo filter bounding box top is below bottom")
+	}
+	return q, nil
+}
@@ -29,7 +29,6 @@ import (
 	"strings"
 
 	"github.com/sjy-dv/nnv/gen/protoc/v2/phonyproto"
-	"github.com/sjy-dv/nnv/pkg/distance"
 	"github.com/sjy-dv/nnv/pkg/gomath"
 	"github.com/sjy-dv/nnv/pkg/index"
 	"google.golang.org/protobuf/proto"
@@ -51,7 +50,50 @@ func init() {
 }
 
 func (xx *Edge) LoadData(collectionName string, config CollectionConfig) error {
-	if config.Quantization == F8_QUANTIZATION {
+	if config.IndexType == CFLAT_INDEX {
+		vecspace, err := newCFlatVectorstore(config)
+		if err != nil {
+			return err
+		}
+		iserror := false
+		sep := fmt.Sprintf("%s_", collectionName)
+		var werr error
+		xx.Disk.AscendKeys([]byte(sep), true, func(k []byte) (bool, error) {
+			key, err := strconv.Atoi(strings.Split(string(k), sep)[1])
+			if err != nil {
+				iserror = true
+				werr = err
+				return false, err
+			}
+			val, err := xx.Disk.Get(k)
+			if err != nil {
+				iserror = true
+				werr = err
+				return false, err
+			}
+			phony := phonyproto.PhonyWrapper{}
+			err = proto.Unmarshal(val, &phony)
+			if err != nil {
+				iserror = true
+				werr = err
+				return false, err
+			}
+			// the field vectors were written composed
+			err = vecspace.InsertVector(collectionName, uint64(key), phony.GetVector())
+			if err != nil {
+				iserror = true
+				werr = err
+				return false, err
+			}
+			return true, nil
+		})
+		if iserror {
+			return werr
+		}
+		xx.VectorStore.slock.Lock()
+		defer xx.VectorStore.slock.Unlock()
+		xx.VectorStore.Space[collectionName] = vecspace
+	} else if config.Quantization == F8_QUANTIZATION {
 		vecspace := newF8Vectorstore(config)
 		iserror := false
 		sep := fmt.Sprintf("%s_", collectionName)
@@ -88,9 +130,13 @@ func (xx *Edge) LoadData(collectionName string, config CollectionConfig) error {
 		if iserror {
 			return werr
 		}
+		space, err := loadIndex(config, vecspace)
+		if err != nil {
+			return err
+		}
 		xx.VectorStore.slock.Lock()
 		defer xx.VectorStore.slock.Unlock()
-		xx.VectorStore.Space[collectionName] = vecspace
+		xx.VectorStore.Space[collectionName] = space
 	} else if config.Quantization == F16_QUANTIZATION {
 		vecspace := newF16Vectorstore(config)
 		iserror := false
@@ -130,9 +176,13 @@ func (xx *Edge) LoadData(collectionName string, config CollectionConfig) error {
 		if iserror {
 			return werr
 		}
+		space, err := loadIndex(config, vecspace)
+		if err != nil {
+			return err
+		}
 		xx.VectorStore.slock.Lock()
 		defer xx.VectorStore.slock.Unlock()
-		xx.VectorStore.Space[collectionName] = vecspace
+		xx.VectorStore.Space[collectionName] = space
 	} else if config.Quantization == BF16_QUANTIZATION {
 		vecspace := newBF16Vectorstore(config)
 		iserror := false
@@ -172,9 +222,13 @@ func (xx *Edge) LoadData(collectionName string, config CollectionConfig) error {
 		if iserror {
 			return werr
 		}
+		space, err := loadIndex(config, vecspace)
+		if err != nil {
+			return err
+		}
 		xx.VectorStore.slock.Lock()
 		defer xx.VectorStore.slock.Unlock()
-		xx.VectorStore.Space[collectionName] = vecspace
+		xx.VectorStore.Space[collectionName] = space
 	} else if config.Quantization == NONE_QAUNTIZATION {
 		// vecspace := newSimpleVectorstore(config)
 		// iserror := false
@@ -223,6 +277,19 @@ func (xx *Edge) LoadData(collectionName string, config CollectionConfig) error {
 	return nil
 }
 
+// loadIndex wraps the rebuilt flat store with the collection index, the hnsw
+// graph is restored from its last commit.
+func loadIndex(config CollectionConfig, base vectorspace) (vectorspace, error) {
+	space, err := withIndex(config, base)
+	if err != nil {
+		return nil, err
+	}
+	if graph, ok := space.(*hnswvecSpace); ok {
+		graph.load(fmt.Sprintf(edgeGraph, config.CollectionName), config)
+	}
+	return space, nil
+}
+
 func (xx *Edge) CommitConfig(collectionName string) error {
 	_, err := os.Stat(fmt.Sprintf(edgeConfig, collectionName))
 	if err != nil {
@@ -243,6 +310,9 @@ func (xx *Edge) CommitConfig(collectionName string) error {
 	conf.Dimension = int(cfg.dim)
 	conf.Distance = cfg.distance
 	conf.Quantization = cfg.quantization
+	conf.IndexConfig = cfg.index
+	conf.MaxQueryTimeMs = uint32(cfg.maxQueryTime.Milliseconds())
+	conf.ScanParallelism = cfg.parallelism
 	// xx.lock.RUnlock()
 	// xx.Datas[collectionName].lock.RUnlock()
 	f, err := os.Create(fmt.Sprintf(edgeConfig, collectionName))
@@ -474,14 +544,7 @@ EmptyData:
 		dimension:      cfg.Dimension,
 		vectors:        make(map[uint64]gomath.Vector),
 		collectionName: collectionName,
-		distance: func() distance.Space {
-			if cfg.Distance == COSINE {
-				return distance.NewCosine()
-			} else if cfg.Distance == EUCLIDEAN {
-				return distance.NewEuclidean()
-			}
-			return distance.NewCosine()
-		}(),
+		distance:       distanceSpace(cfg.Distance),
 	}
 	normalEdgeV.lock.Unlock()
 	return nil
@@ -513,14 +576,7 @@ ExistsData:
 		dimension:      cfg.Dimension,
 		vectors:        cdat,
 		collectionName: collectionName,
-		distance: func() distance.Space {
-			if cfg.Distance == COSINE {
-				return distance.NewCosine()
-			} else if cfg.Distance == EUCLIDEAN {
-				return distance.NewEuclidean()
-			}
-			return distance.NewCosine()
-		}(),
+		distance:       distanceSpace(cfg.Distance),
 	}
 	normalEdgeV.lock.Unlock()
 	return nil
@@ -531,4 +587,5 @@ func allremover(collectionName string) {
 	os.Remove(fmt.Sprintf(edgeIndex, collectionName))
 	os.Remove(fmt.Sprintf(edgeVector, collectionName))
 	os.Remove(fmt.Sprintf(edgeConfig, collectionName))
+	os.Remove(fmt.Sprintf(edgeGraph, collectionName))
 }
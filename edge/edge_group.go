@@ -0,0 +1,129 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v2/phonyproto"
+	"github.com/sjy-dv/nnv/pkg/index"
+	"google.golang.org/protobuf/proto"
+)
+
+// searchCandidate loads the record of a scanned id, sim is the scan value or
+// the composite score when fields is set.
+func (xx *Edge) searchCandidate(collectionName string, nodeId ID, sim float32, fields map[ID]map[string]float32,
+	dist string, dim int32, proj *projection) (*edgeproto.Candidates, error) {
+	record, err := xx.record(collectionName, nodeId)
+	if err != nil {
+		return nil, err
+	}
+	return recordCandidate(record, nodeId, sim, fields, dist, dim, proj), nil
+}
+
+func (xx *Edge) record(collectionName string, nodeId ID) (*phonyproto.PhonyWrapper, error) {
+	phonyD, err := xx.Disk.Get([]byte(fmt.Sprintf("%s_%d", collectionName, nodeId)))
+	if err != nil {
+		return nil, err
+	}
+	phonydec := phonyproto.PhonyWrapper{}
+	if err := proto.Unmarshal(phonyD, &phonydec); err != nil {
+		return nil, err
+	}
+	return &phonydec, nil
+}
+
+func recordCandidate(record *phonyproto.PhonyWrapper, nodeId ID, sim float32, fields map[ID]map[string]float32,
+	dist string, dim int32, proj *projection) *edgeproto.Candidates {
+	candidate := new(edgeproto.Candidates)
+	candidate.Id = record.GetId()
+	proj.apply(candidate, record)
+	if fields != nil {
+		candidate.Score = sim
+		candidate.FieldScores = fields[nodeId]
+	} else {
+		candidate.Score = distanceScore(dist, sim, dim)
+	}
+	return candidate
+}
+
+/*
+groupSearch scans until limit groups of group_size results by the group_by
+field are filled. Each round doubles the scan, which widens the ef of an hnsw
+collection and the result set of a flat one, and it stops once the scan gives
+fewer results than asked. Groups are ordered by their best result, results
+without the field are left out.
+*/
+func (xx *Edge) groupSearch(ctx context.Context, req *edgeproto.SearchReq) ([]*edgeproto.Group, error) {
+	if req.GetDiversify() != nil {
+		return nil, errors.New(ErrGroupDiversify)
+	}
+	if req.Radius != nil {
+		return nil, errors.New(ErrRangeSearch)
+	}
+	if req.GetOffset() > 0 || req.GetPageToken() != "" {
+		return nil, errors.New(ErrPageSearch)
+	}
+	size, limit := int(req.GetGroupSize()), int(req.GetLimit())
+	if size == 0 {
+		size = 1
+	}
+	if limit == 0 {
+		limit = int(req.GetTopK())
+	}
+	if limit == 0 {
+		return nil, nil
+	}
+	dist := xx.getDist(req.GetCollectionName())
+	dim := xx.getDim(req.GetCollectionName())
+	proj := newProjection(req)
+	scanK := size * limit
+	for widen := 0; ; widen++ {
+		rs, fields, err := xx.scanHelper(ctx, req, scanK)
+		if err != nil {
+			return nil, err
+		}
+		groups := make([]*edgeproto.Group, 0, limit)
+		pos := make(map[string]int)
+		for rank, nodeId := range rs.ids[:rs.valid] {
+			if fields == nil && beyondCutoff(dist, rs.sims[rank], req.GetMinScoreThreshold()) {
+				continue
+			}
+			record, err := xx.record(req.GetCollectionName(), nodeId)
+			if err != nil {
+				return nil, err
+			}
+			candidate := recordCandidate(record, nodeId, rs.sims[rank], fields, dist, dim, proj)
+			if candidate.Score < req.GetMinScoreThreshold() {
+				continue
+			}
+			// the projection may leave the field out of the candidate
+			val, ok := record.GetMetadata().GetFields()[req.GetGroupBy()]
+			if !ok || val.AsInterface() == nil {
+				continue
+			}
+			value := index.FilterValue(val.AsInterface())
+			i, ok := pos[value]
+			if !ok {
+				if len(groups) == limit {
+					continue
+				}
+				i = len(groups)
+				pos[value] = i
+				groups = append(groups, &edgeproto.Group{Value: value})
+			}
+			if len(groups[i].Candidates) < size {
+				groups[i].Candidates = append(groups[i].Candidates, candidate)
+			}
+		}
+		full := len(groups) == limit
+		for _, group := range groups {
+			full = full && len(group.Candidates) == size
+		}
+		if full || rs.valid < scanK || widen == GROUP_MAX_WIDEN {
+			return groups, nil
+		}
+		scanK *= 2
+	}
+}
@@ -0,0 +1,79 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupSearch(t *testing.T) {
+	e := newTestEdge(t)
+	testCollection(t, e, &edgeproto.Collection{
+		CollectionName: "group", Dim: 2, Distance: edgeproto.Distance_Euclidean,
+		Quantization: edgeproto.Quantization_F16,
+	})
+	// record i lies at (i, 0), the query at the origin. Every fifth record
+	// has no group and the farthest one is alone in its group
+	for i := 0; i < 30; i++ {
+		metadata := map[string]any{}
+		switch {
+		case i == 29:
+			metadata["g"] = "far"
+		case i%5 != 4:
+			metadata["g"] = fmt.Sprint(i % 2)
+		}
+		testInsert(t, e, "group", fmt.Sprint(i), []float32{float32(i), 0}, metadata)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		size   uint32
+		limit  uint32
+		topK   uint64
+		groups map[string][]string
+		order  []string
+	}{
+		{"groups by their best result", 2, 2, 0, map[string][]string{"0": {"0", "2"}, "1": {"1", "3"}}, []string{"0", "1"}},
+		{"topK is the limit", 1, 0, 1, map[string][]string{"0": {"0"}}, []string{"0"}},
+		{"records without the field are left out", 3, 2, 0,
+			map[string][]string{"0": {"0", "2", "6"}, "1": {"1", "3", "5"}}, []string{"0", "1"}},
+		{"widens to the farthest group", 1, 3, 0,
+			map[string][]string{"0": {"0"}, "1": {"1"}, "far": {"29"}}, []string{"0", "1", "far"}},
+		{"stops once the collection is exhausted", 20, 5, 0,
+			map[string][]string{"0": {"0", "2", "6", "8", "10", "12", "16", "18", "20", "22", "26", "28"},
+				"1": {"1", "3", "5", "7", "11", "13", "15", "17", "21", "23", "25", "27"}, "far": {"29"}},
+			[]string{"0", "1", "far"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			groups, err := e.groupSearch(context.Background(), &edgeproto.SearchReq{
+				CollectionName: "group", Vector: []float32{0, 0}, GroupBy: "g",
+				GroupSize: tc.size, Limit: tc.limit, TopK: tc.topK,
+			})
+			assert.Nil(t, err)
+			order := make([]string, 0, len(groups))
+			for _, group := range groups {
+				order = append(order, group.GetValue())
+				assert.Equal(t, tc.groups[group.GetValue()], candidateIds(group.GetCandidates()))
+			}
+			assert.Equal(t, tc.order, order)
+		})
+	}
+
+	// the group value comes from the record, the projection only shapes the
+	// candidates
+	groups, err := e.groupSearch(context.Background(), &edgeproto.SearchReq{
+		CollectionName: "group", Vector: []float32{0, 0}, GroupBy: "g", Limit: 2,
+		ExcludeFields: []string{"g"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, groups, 2)
+	for _, group := range groups {
+		for _, candidate := range group.GetCandidates() {
+			assert.NotContains(t, candidate.GetMetadata().GetFields(), "g")
+			assert.Contains(t, candidate.GetMetadata().GetFields(), "_id")
+		}
+	}
+}
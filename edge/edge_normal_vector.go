@@ -51,6 +51,21 @@ type CollectionConfig struct {
 	CollectionName string `json:"collection_name"`
 	Distance       string `json:"distance"`
 	Quantization   string `json:"quantization"`
+	MaxQueryTimeMs uint32 `json:"max_query_time_ms,omitempty"`
+	// jobs one scan fans out to, 0 uses one per pool worker
+	ScanParallelism int `json:"scan_parallelism,omitempty"`
+	IndexConfig
+}
+
+// IndexConfig selects how a collection is searched, configs written before
+// the option existed load as flat.
+type IndexConfig struct {
+	IndexType          string `json:"index_type,omitempty"`
+	HnswM              int    `json:"hnsw_m,omitempty"`
+	HnswEfConstruction int    `json:"hnsw_ef_construction,omitempty"`
+	HnswEfSearch       int    `json:"hnsw_ef_search,omitempty"`
+	// named vectors of a cflat record, in their stored order
+	VectorFields []VectorField `json:"vector_fields,omitempty"`
 }
 
 func (xx *EdgeVectors) CreateCollection(config CollectionConfig) error {
@@ -65,14 +80,7 @@ func (xx *EdgeVectors) CreateCollection(config CollectionConfig) error {
 	xx.Edges[config.CollectionName].collectionName = config.CollectionName
 	xx.Edges[config.CollectionName].dimension = config.Dimension
 	xx.Edges[config.CollectionName].distance = &distance.Cosine{}
-	xx.Edges[config.CollectionName].distance = func() distance.Space {
-		if config.Distance == COSINE {
-			return distance.NewCosine()
-		} else if config.Distance == EUCLIDEAN {
-			return distance.NewEuclidean()
-		}
-		return distance.NewCosine()
-	}()
+	xx.Edges[config.CollectionName].distance = distanceSpace(config.Distance)
 	xx.Edges[config.CollectionName].vectors = make(map[uint64]gomath.Vector)
 	xx.lock.Unlock()
 	return nil
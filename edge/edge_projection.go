@@ -0,0 +1,59 @@
+package edge
+
+import (
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
+	"github.com/sjy-dv/nnv/gen/protoc/v2/phonyproto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// projection is the part of each record a search gives back, nil gives all of
+// the metadata and no vector.
+type projection struct {
+	include    map[string]struct{}
+	exclude    map[string]struct{}
+	withVector bool
+}
+
+func newProjection(req *edgeproto.SearchReq) *projection {
+	if len(req.GetIncludeFields()) == 0 && len(req.GetExcludeFields()) == 0 && !req.GetWithVector() {
+		return nil
+	}
+	proj := &projection{withVector: req.GetWithVector()}
+	if len(req.GetIncludeFields()) > 0 {
+		proj.include = make(map[string]struct{}, len(req.GetIncludeFields()))
+		for _, field := range req.GetIncludeFields() {
+			proj.include[field] = struct{}{}
+		}
+	}
+	proj.exclude = make(map[string]struct{}, len(req.GetExcludeFields()))
+	for _, field := range req.GetExcludeFields() {
+		proj.exclude[field] = struct{}{}
+	}
+	return proj
+}
+
+// apply sets the metadata and vector of a candidate from its record, the
+// record keeps the vector as it was written.
+func (xx *projection) apply(candidate *edgeproto.Candidates, record *phonyproto.PhonyWrapper) {
+	candidate.Metadata = record.GetMetadata()
+	if xx == nil {
+		return
+	}
+	if xx.withVector {
+		candidate.Vector = record.GetVector()
+	}
+	if xx.include == nil && len(xx.exclude) == 0 {
+		return
+	}
+	metadata := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(record.GetMetadata().GetFields()))}
+	for field, value := range record.GetMetadata().GetFields() {
+		if _, ok := xx.include[field]; xx.include != nil && !ok {
+			continue
+		}
+		if _, ok := xx.exclude[field]; ok {
+			continue
+		}
+		metadata.Fields[field] = value
+	}
+	candidate.Metadata = metadata
+}
@@ -0,0 +1,249 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
+)
+
+func (xx *Edge) Recommend(ctx context.Context, req *edgeproto.RecommendReq) (
+	*edgeproto.SearchResponse, error) {
+	type reply struct {
+		Result *edgeproto.SearchResponse
+		Error  error
+	}
+	c := make(chan reply, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c <- reply{
+					Error: fmt.Errorf(panicr, r),
+				}
+			}
+		}()
+		failFn := func(errMsg string) reply {
+			return reply{
+				Result: &edgeproto.SearchResponse{
+					Status: false,
+					Error: &edgeproto.Error{
+						ErrorMessage: errMsg,
+						ErrorCode:    edgeproto.ErrorCode_INTERNAL_FUNC_ERROR,
+					},
+				},
+			}
+		}
+		if !existsCollection(req.GetCollectionName()) {
+			c <- failFn(fmt.Sprintf(ErrCollectionNotFound, req.GetCollectionName()))
+			return
+		}
+		if !alreadyLoadCollection(req.GetCollectionName()) {
+			c <- failFn(fmt.Sprintf(ErrCollectionNotLoad, req.GetCollectionName()))
+			return
+		}
+		if _, err := xx.VectorStore.cflat(req.GetCollectionName()); err == nil {
+			c <- failFn(fmt.Sprintf(ErrRecommendCFlat, req.GetCollectionName()))
+			return
+		}
+		ctx, cancel := xx.queryContext(ctx, req.GetCollectionName())
+		defer cancel()
+		ids, scores, err := xx.recommend(ctx, req)
+		if err != nil {
+			if cerr := contextError(err); cerr != nil {
+				c <- reply{Error: cerr}
+				return
+			}
+			c <- failFn(err.Error())
+			return
+		}
+		// the scores are final, no field scores to attach
+		final := map[ID]map[string]float32{}
+		retval := make([]*edgeproto.Candidates, 0, len(ids))
+		for i, nodeId := range ids {
+			candidate, err := xx.searchCandidate(req.GetCollectionName(), nodeId, scores[i], final, "", 0, nil)
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			retval = append(retval, candidate)
+		}
+		c <- reply{
+			Result: &edgeproto.SearchResponse{
+				Status:     true,
+				Candidates: retval,
+			},
+		}
+	}()
+	res := <-c
+	return res.Result, res.Error
+}
+
+// recommendExamples resolves the examples to vectors, ids are looked up in the
+// collection and added to exclude.
+func (xx *Edge) recommendExamples(collectionName string, ids []string, vectors []*edgeproto.FieldVector,
+	exclude map[ID]struct{}) ([]Vector, error) {
+	dist := xx.getDist(collectionName)
+	dim := xx.getDim(collectionName)
+	out := make([]Vector, 0, len(ids)+len(vectors))
+	for _, id := range ids {
+		getId := indexdb.indexes[collectionName].PureSearch(map[string]string{"_id": id})
+		if len(getId) == 0 {
+			return nil, fmt.Errorf(ErrRecommendRecord, id)
+		}
+		vector, err := xx.VectorStore.Vector(collectionName, getId[0])
+		if err != nil {
+			return nil, err
+		}
+		exclude[ID(getId[0])] = struct{}{}
+		out = append(out, vector)
+	}
+	for _, raw := range vectors {
+		if int32(len(raw.GetVector())) != dim {
+			return nil, fmt.Errorf(ErrRecommendDim, len(raw.GetVector()), dim)
+		}
+		vector := Vector(raw.GetVector())
+		if dist == COSINE {
+			vector = Normalize(vector)
+		}
+		out = append(out, vector)
+	}
+	return out, nil
+}
+
+func meanVector(vectors []Vector) Vector {
+	out := make(Vector, len(vectors[0]))
+	for _, vector := range vectors {
+		for i, v := range vector {
+			out[i] += v / float32(len(vectors))
+		}
+	}
+	return out
+}
+
+/*
+recommend gives the topK ids of a recommend search with their 0-100 scores,
+or below 0 for best score results closer to a negative example.
+*/
+func (xx *Edge) recommend(ctx context.Context, req *edgeproto.RecommendReq) ([]ID, []float32, error) {
+	exclude := make(map[ID]struct{})
+	positive, err := xx.recommendExamples(req.GetCollectionName(), req.GetPositive(), req.GetPositiveVectors(), exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(positive) == 0 {
+		return nil, nil, errors.New(ErrRecommendPositive)
+	}
+	negative, err := xx.recommendExamples(req.GetCollectionName(), req.GetNegative(), req.GetNegativeVectors(), exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+	var allow map[ID]struct{}
+	if len(req.GetFilter()) > 0 {
+		indexdb.indexLock.RLock()
+		matched := indexdb.indexes[req.GetCollectionName()].PureSearch(req.GetFilter())
+		indexdb.indexLock.RUnlock()
+		allow = make(map[ID]struct{}, len(matched))
+		for _, id := range matched {
+			allow[ID(id)] = struct{}{}
+		}
+	}
+	topK := int(req.GetTopK())
+	scanK := topK + len(exclude)
+	if allow != nil {
+		scanK *= RECOMMEND_OVERSAMPLE
+	}
+	dist := xx.getDist(req.GetCollectionName())
+	dim := xx.getDim(req.GetCollectionName())
+	// scan gives the ids of one scan that pass the filter and are no examples
+	scan := func(query Vector) ([]ID, []float32, error) {
+		rs, err := xx.VectorStore.FullScan(ctx, req.GetCollectionName(), query, scanK)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make([]ID, 0, topK)
+		sims := make([]float32, 0, topK)
+		for i, nodeId := range rs.ids[:rs.valid] {
+			if len(ids) == topK {
+				break
+			}
+			if beyondCutoff(dist, rs.sims[i], 0) {
+				continue
+			}
+			if _, ok := exclude[nodeId]; ok {
+				continue
+			}
+			if _, ok := allow[nodeId]; allow != nil && !ok {
+				continue
+			}
+			ids = append(ids, nodeId)
+			sims = append(sims, distanceScore(dist, rs.sims[i], dim))
+		}
+		return ids, sims, nil
+	}
+
+	if req.GetStrategy() == edgeproto.RecommendStrategy_AverageVector {
+		query := meanVector(positive)
+		if len(negative) > 0 {
+			mean := meanVector(negative)
+			for i := range query {
+				query[i] += query[i] - mean[i]
+			}
+		}
+		return scan(query)
+	}
+
+	space := distanceSpace(dist)
+	bestScore := func(vector Vector, examples []Vector) float32 {
+		best := float32(0)
+		for _, example := range examples {
+			best = max(best, pairScore(dist, space, vector, example, dim))
+		}
+		return best
+	}
+	seen := make(map[ID]struct{})
+	ids := make([]ID, 0, topK)
+	scores := make([]float32, 0, topK)
+	for _, example := range positive {
+		found, _, err := scan(example)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, nodeId := range found {
+			if _, ok := seen[nodeId]; ok {
+				continue
+			}
+			seen[nodeId] = struct{}{}
+			vector, err := xx.VectorStore.Vector(req.GetCollectionName(), uint64(nodeId))
+			if err != nil {
+				return nil, nil, err
+			}
+			score := bestScore(vector, positive)
+			if len(negative) > 0 {
+				if neg := bestScore(vector, negative); neg > score {
+					score = -neg
+				}
+			}
+			ids = append(ids, nodeId)
+			scores = append(scores, score)
+		}
+	}
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+	if len(order) > topK {
+		order = order[:topK]
+	}
+	outIds := make([]ID, len(order))
+	outScores := make([]float32, len(order))
+	for i, o := range order {
+		outIds[i], outScores[i] = ids[o], scores[o]
+	}
+	return outIds, outScores, nil
+}
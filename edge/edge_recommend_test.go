@@ -0,0 +1,116 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRecommendEdge holds an hnsw collection of 30 records, record i lies at
+// (i, 0) and is tagged odd or even.
+func newRecommendEdge(t *testing.T) *Edge {
+	e := newTestEdge(t)
+	testCollection(t, e, &edgeproto.Collection{
+		CollectionName: "recommend", Dim: 2, Distance: edgeproto.Distance_Euclidean,
+		Quantization: edgeproto.Quantization_F16, IndexType: edgeproto.IndexType_Hnsw,
+	})
+	for i := 0; i < 30; i++ {
+		testInsert(t, e, "recommend", fmt.Sprint(i), []float32{float32(i), 0}, map[string]any{"odd": fmt.Sprint(i%2 == 1)})
+	}
+	return e
+}
+
+func recommend(t *testing.T, e *Edge, req *edgeproto.RecommendReq) []*edgeproto.Candidates {
+	req.CollectionName = "recommend"
+	res, err := e.Recommend(context.Background(), req)
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	return res.GetCandidates()
+}
+
+func TestRecommendAverageVector(t *testing.T) {
+	e := newRecommendEdge(t)
+
+	// 2 * mean(positive) - mean(negative) = 2 * (4, 0) - (2, 0) = (6, 0)
+	found := recommend(t, e, &edgeproto.RecommendReq{
+		PositiveVectors: []*edgeproto.FieldVector{{Vector: []float32{3, 0}}, {Vector: []float32{5, 0}}},
+		NegativeVectors: []*edgeproto.FieldVector{{Vector: []float32{2, 0}}},
+		TopK:            1,
+	})
+	assert.Equal(t, []string{"6"}, candidateIds(found))
+	assert.InDelta(t, 100, found[0].GetScore(), 1e-4)
+
+	// the mean of the positives alone
+	found = recommend(t, e, &edgeproto.RecommendReq{
+		PositiveVectors: []*edgeproto.FieldVector{{Vector: []float32{3, 0}}, {Vector: []float32{5, 0}}},
+		TopK:            1,
+	})
+	assert.Equal(t, []string{"4"}, candidateIds(found))
+}
+
+func TestRecommendBestScore(t *testing.T) {
+	e := newRecommendEdge(t)
+
+	// every result is closer to the positive example than to the negative
+	// one until 5, the rest scores minus its negative match
+	found := recommend(t, e, &edgeproto.RecommendReq{
+		PositiveVectors: []*edgeproto.FieldVector{{Vector: []float32{0, 0}}},
+		NegativeVectors: []*edgeproto.FieldVector{{Vector: []float32{9, 0}}},
+		Strategy:        edgeproto.RecommendStrategy_BestScore,
+		TopK:            10,
+	})
+	assert.Len(t, found, 10)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, candidateIds(found[:5]))
+	assert.InDelta(t, 100, found[0].GetScore(), 1e-4)
+	for i, candidate := range found {
+		if i < 5 {
+			assert.Greater(t, candidate.GetScore(), float32(0))
+			continue
+		}
+		assert.Less(t, candidate.GetScore(), float32(0))
+		assert.LessOrEqual(t, candidate.GetScore(), found[i-1].GetScore())
+	}
+	// 9 is the negative example itself
+	assert.Equal(t, "9", found[9].GetId())
+	assert.InDelta(t, -100, found[9].GetScore(), 1e-4)
+}
+
+func TestRecommendExcludesExamples(t *testing.T) {
+	e := newRecommendEdge(t)
+	for _, strategy := range []edgeproto.RecommendStrategy{edgeproto.RecommendStrategy_AverageVector, edgeproto.RecommendStrategy_BestScore} {
+		found := recommend(t, e, &edgeproto.RecommendReq{
+			Positive: []string{"10", "11"},
+			Negative: []string{"12"},
+			Strategy: strategy,
+			TopK:     5,
+		})
+		assert.Len(t, found, 5, strategy.String())
+		for _, id := range []string{"10", "11", "12"} {
+			assert.NotContains(t, candidateIds(found), id, strategy.String())
+		}
+	}
+}
+
+func TestRecommendFilterOversamples(t *testing.T) {
+	e := newRecommendEdge(t)
+
+	// the hnsw scan is widened for the filter, a scan of topK plus the
+	// examples would reach 3 only and miss the third odd record
+	found := recommend(t, e, &edgeproto.RecommendReq{
+		Positive: []string{"0"},
+		TopK:     3,
+		Filter:   map[string]string{"odd": "true"},
+	})
+	assert.Equal(t, []string{"1", "3", "5"}, candidateIds(found))
+
+	found = recommend(t, e, &edgeproto.RecommendReq{
+		Positive: []string{"0"},
+		Strategy: edgeproto.RecommendStrategy_BestScore,
+		TopK:     3,
+		Filter:   map[string]string{"odd": "false"},
+	})
+	assert.Equal(t, []string{"2", "4", "6"}, candidateIds(found))
+}
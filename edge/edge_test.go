@@ -0,0 +1,151 @@
+package edge
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sjy-dv/nnv/gen/protoc/v2/edgeproto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestEdge opens an edge in a temporary directory, the data_dir of edge is
+// relative to the working directory.
+func newTestEdge(t *testing.T) *Edge {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	NewStateManager()
+	assert.Nil(t, NewIdGenerator())
+	e, err := NewEdge()
+	assert.Nil(t, err)
+	t.Cleanup(e.Close)
+	NewIndexDB()
+	return e
+}
+
+func testCollection(t *testing.T, e *Edge, collection *edgeproto.Collection) {
+	res, err := e.CreateCollection(context.Background(), collection)
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+}
+
+func testInsert(t *testing.T, e *Edge, collectionName, id string, vector []float32, metadata map[string]any) {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadata["_id"] = id
+	md, err := structpb.NewStruct(metadata)
+	assert.Nil(t, err)
+	res, err := e.Insert(context.Background(), &edgeproto.ModifyDataset{
+		Id: id, CollectionName: collectionName, Vector: vector, Metadata: md,
+	})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+}
+
+func candidateIds(candidates []*edgeproto.Candidates) []string {
+	ids := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.GetId())
+	}
+	return ids
+}
+
+func TestDiversifyCosinePicksNearestFirst(t *testing.T) {
+	e := newTestEdge(t)
+	testCollection(t, e, &edgeproto.Collection{
+		CollectionName: "mmr", Dim: 2, Distance: edgeproto.Distance_Cosine,
+		Quantization: edgeproto.Quantization_F16,
+	})
+	testInsert(t, e, "mmr", "far", []float32{0.6, 0.8}, nil)
+	testInsert(t, e, "mmr", "dup", []float32{1, 0.06}, nil)
+	testInsert(t, e, "mmr", "near", []float32{1, 0.05}, nil)
+	testInsert(t, e, "mmr", "opposite", []float32{-1, 0.2}, nil)
+
+	// the relevance and the pair term score cosine alike, the nearest
+	// candidate leads and its near duplicate gives way to a novel one
+	lambda := float32(0.3)
+	res, err := e.VectorSearch(context.Background(), &edgeproto.SearchReq{
+		CollectionName: "mmr", Vector: []float32{1, 0}, TopK: 2,
+		Diversify: &edgeproto.Diversify{Lambda: &lambda},
+	})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	ids := candidateIds(res.GetCandidates())
+	assert.Len(t, ids, 2)
+	assert.Equal(t, "near", ids[0])
+	assert.NotContains(t, ids, "dup")
+
+	// all relevance keeps the scan order
+	lambda = 1
+	res, err = e.VectorSearch(context.Background(), &edgeproto.SearchReq{
+		CollectionName: "mmr", Vector: []float32{1, 0}, TopK: 3,
+		Diversify: &edgeproto.Diversify{Lambda: &lambda},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"near", "dup", "far"}, candidateIds(res.GetCandidates()))
+}
+
+func TestHybridSearchKeepsDiversifyOrder(t *testing.T) {
+	e := newTestEdge(t)
+	testCollection(t, e, &edgeproto.Collection{
+		CollectionName: "mmr", Dim: 2, Distance: edgeproto.Distance_Cosine,
+		Quantization: edgeproto.Quantization_F16,
+	})
+	testInsert(t, e, "mmr", "far", []float32{0.6, 0.8}, map[string]any{"kind": "a"})
+	testInsert(t, e, "mmr", "dup", []float32{1, 0.06}, map[string]any{"kind": "a"})
+	testInsert(t, e, "mmr", "near", []float32{1, 0.05}, map[string]any{"kind": "a"})
+	testInsert(t, e, "mmr", "opposite", []float32{-1, 0.2}, map[string]any{"kind": "a"})
+
+	// the novel candidate is picked before the closer ones, a sort by score
+	// would push it back
+	lambda := float32(0.3)
+	req := &edgeproto.SearchReq{
+		CollectionName: "mmr", Vector: []float32{1, 0}, TopK: 3,
+		Filter: map[string]string{"kind": "a"}, Diversify: &edgeproto.Diversify{Lambda: &lambda},
+	}
+	res, err := e.VectorSearch(context.Background(), req)
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	hybrid, err := e.HybridSearch(context.Background(), req)
+	assert.Nil(t, err)
+	assert.True(t, hybrid.GetStatus(), hybrid.GetError().GetErrorMessage())
+	ids := candidateIds(hybrid.GetCandidates())
+	assert.Equal(t, candidateIds(res.GetCandidates()), ids)
+	assert.Equal(t, "opposite", ids[1])
+}
+
+func TestVectorSearchFarEuclidean(t *testing.T) {
+	e := newTestEdge(t)
+	testCollection(t, e, &edgeproto.Collection{
+		CollectionName: "far", Dim: 2, Distance: edgeproto.Distance_Euclidean,
+		Quantization: edgeproto.Quantization_F16,
+	})
+	testInsert(t, e, "far", "near", []float32{10, 0}, nil)
+	testInsert(t, e, "far", "far", []float32{500, 0}, nil)
+
+	// without a threshold records past a distance of 100 are left out
+	res, err := e.VectorSearch(context.Background(), &edgeproto.SearchReq{
+		CollectionName: "far", Vector: []float32{0, 0}, TopK: 2,
+	})
+	assert.Nil(t, err)
+	assert.True(t, res.GetStatus(), res.GetError().GetErrorMessage())
+	assert.Equal(t, []string{"near"}, candidateIds(res.GetCandidates()))
+
+	// a threshold replaces the cutoff
+	res, err = e.VectorSearch(context.Background(), &edgeproto.SearchReq{
+		CollectionName: "far", Vector: []float32{0, 0}, TopK: 2, MinScoreThreshold: 1,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"near"}, candidateIds(res.GetCandidates()))
+	res, err = e.VectorSearch(context.Background(), &edgeproto.SearchReq{
+		CollectionName: "far", Vector: []float32{0, 0}, TopK: 2, MinScoreThreshold: -1,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"near", "far"}, candidateIds(res.GetCandidates()))
+	assert.Equal(t, float32(0), res.GetCandidates()[1].GetScore())
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"runtime"
 	"sync"
+
+	"github.com/sjy-dv/nnv/pkg/concurrentmap"
+	"github.com/sjy-dv/nnv/pkg/sharding"
 )
 
+// Job scans part of the shards of one query on a pool worker.
 type Job struct {
-	Vectorspace vectorspace
-	Target      Vector
-	TopK        int
-	ResultChan  chan *ResultSet
-	CancelFunc  context.CancelFunc
+	Ctx        context.Context
+	Scan       func(ctx context.Context) (*ResultSet, error)
+	ResultChan chan<- KResult
 }
 
 type KResult struct {
@@ -19,8 +21,148 @@ type KResult struct {
 	Error     error
 }
 
+/* every collection shares the workers, a query waits for room in jobQueue
+ * when it is full so a burst of scans can not pile up unbounded goroutines */
 var (
 	workerPoolSize = runtime.NumCPU()
 	jobQueue       = make(chan Job, workerPoolSize*2)
 	once           sync.Once
 )
+
+func startWorkerPool() {
+	once.Do(func() {
+		for i := 0; i < workerPoolSize; i++ {
+			go worker()
+		}
+	})
+}
+
+func worker() {
+	for job := range jobQueue {
+		if err := job.Ctx.Err(); err != nil {
+			job.ResultChan <- KResult{Error: err}
+			continue
+		}
+		rs, err := job.Scan(job.Ctx)
+		job.ResultChan <- KResult{ResultSet: rs, Error: err}
+	}
+}
+
+// scanParallelism is the number of jobs a scan fans out to, a collection
+// created without scan_parallelism uses one per worker.
+func scanParallelism(parallelism int) int {
+	if parallelism < 1 {
+		parallelism = workerPoolSize
+	}
+	return min(parallelism, EDGE_MAP_SHARD_COUNT)
+}
+
+/* parallelScan splits the shards over parallelism jobs, each keeps its own
+ * top-K and the jobs are merged once all of them answered. newSet builds the
+ * result sets, NewResultSet for distances. scanShard adds the vectors of one
+ * shard to rs and gives up once stop returns an error. The first failed job
+ * cancels the others. */
+func parallelScan(ctx context.Context, parallelism, topK int, newSet func(topK int) *ResultSet,
+	scanShard func(shard int, rs *ResultSet, stop func() error) error) (*ResultSet, error) {
+	parallelism = scanParallelism(parallelism)
+	scanShards := func(ctx context.Context, first int) (*ResultSet, error) {
+		rs := newSet(topK)
+		stop := scanStop(ctx)
+		for shard := first; shard < EDGE_MAP_SHARD_COUNT; shard += parallelism {
+			if err := scanShard(shard, rs, stop); err != nil {
+				return nil, err
+			}
+		}
+		return rs, nil
+	}
+	if parallelism == 1 {
+		return scanShards(ctx, 0)
+	}
+
+	startWorkerPool()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan KResult, parallelism)
+	var err error
+	submitted := 0
+	for first := 0; first < parallelism && err == nil; first++ {
+		job := Job{
+			Ctx: ctx,
+			Scan: func(ctx context.Context) (*ResultSet, error) {
+				return scanShards(ctx, first)
+			},
+			ResultChan: results,
+		}
+		select {
+		case jobQueue <- job:
+			submitted++
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+
+	merged := newSet(topK)
+	for ; submitted > 0; submitted-- {
+		res := <-results
+		if res.Error != nil {
+			if err == nil {
+				err = res.Error
+				cancel()
+			}
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		for i := 0; i < res.ResultSet.valid; i++ {
+			merged.AddResult(res.ResultSet.ids[i], res.ResultSet.sims[i])
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// shardMap spreads the vectors of a collection over EDGE_MAP_SHARD_COUNT maps.
+type shardMap[T any] struct {
+	shards [EDGE_MAP_SHARD_COUNT]*concurrentmap.Map[uint64, T]
+}
+
+func newShardMap[T any]() *shardMap[T] {
+	xx := &shardMap[T]{}
+	for i := range xx.shards {
+		xx.shards[i] = concurrentmap.New[uint64, T]()
+	}
+	return xx
+}
+
+func (xx *shardMap[T]) shard(id uint64) *concurrentmap.Map[uint64, T] {
+	return xx.shards[sharding.ShardVertex(id, uint64(EDGE_MAP_SHARD_COUNT))]
+}
+
+func (xx *shardMap[T]) Set(id uint64, value T) {
+	xx.shard(id).Set(id, value)
+}
+
+func (xx *shardMap[T]) Get(id uint64) (T, bool) {
+	return xx.shard(id).Get(id)
+}
+
+func (xx *shardMap[T]) Del(id uint64) {
+	xx.shard(id).Del(id)
+}
+
+// ForEach walks the shards one after the other until fn returns false.
+func (xx *shardMap[T]) ForEach(fn func(uint64, T) bool) {
+	next := true
+	for _, shard := range xx.shards {
+		shard.ForEach(func(id uint64, value T) bool {
+			next = fn(id, value)
+			return next
+		})
+		if !next {
+			return
+		}
+	}
+}
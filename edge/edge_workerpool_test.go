@@ -0,0 +1,49 @@
+package edge
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelScanMatchesSequential(t *testing.T) {
+	config := CollectionConfig{
+		Dimension:      24,
+		CollectionName: "pool",
+		Distance:       COSINE,
+		Quantization:   F16_QUANTIZATION,
+	}
+	sequential := newF16Vectorstore(config)
+	sequential.parallelism = 1
+	parallel := newF16Vectorstore(config)
+	parallel.parallelism = EDGE_MAP_SHARD_COUNT
+	for i := 0; i < 2000; i++ {
+		vec := make(Vector, config.Dimension)
+		for j := range vec {
+			vec[j] = rand.Float32()
+		}
+		assert.Nil(t, sequential.InsertVector("pool", uint64(i), vec))
+		assert.Nil(t, parallel.InsertVector("pool", uint64(i), vec))
+	}
+
+	for q := 0; q < 20; q++ {
+		target := make(Vector, config.Dimension)
+		for j := range target {
+			target[j] = rand.Float32()
+		}
+		want, err := sequential.FullScan(context.Background(), "pool", target, 10)
+		assert.Nil(t, err)
+		got, err := parallel.FullScan(context.Background(), "pool", target, 10)
+		assert.Nil(t, err)
+		// equal scores may come in another order, the scores themselves may not
+		assert.Equal(t, want.valid, got.valid)
+		assert.Equal(t, want.sims, got.sims)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := parallel.FullScan(ctx, "pool", make(Vector, config.Dimension), 10)
+	assert.Equal(t, context.Canceled, err)
+}
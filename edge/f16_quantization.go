@@ -69,6 +69,15 @@ func (q Float16Quantization) Unmarshal(data []byte) (float16Vec, error) {
 	return out, nil
 }
 
+// Raise converts a lowered vector back to float32.
+func (q Float16Quantization) Raise(v float16Vec) Vector {
+	out := make(Vector, len(v))
+	for i, x := range v {
+		out[i] = x.Float32()
+	}
+	return out
+}
+
 func (q Float16Quantization) Name() string {
 	return "float16"
 }
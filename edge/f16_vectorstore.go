@@ -1,17 +1,18 @@
 package edge
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
-	"github.com/sjy-dv/nnv/pkg/concurrentmap"
 	"github.com/sjy-dv/nnv/pkg/distance"
 )
 
 type f16vecSpace struct {
 	dimension int
 	// vectors        map[uint64]float16Vec
-	vectors        *concurrentmap.Map[uint64, float16Vec]
+	vectors        *shardMap[float16Vec]
+	parallelism    int
 	collectionName string
 	distance       distance.Space
 	quantization   Float16Quantization
@@ -21,17 +22,11 @@ type f16vecSpace struct {
 func newF16Vectorstore(config CollectionConfig) *f16vecSpace {
 	return &f16vecSpace{
 		dimension:      config.Dimension,
-		vectors:        concurrentmap.New[uint64, float16Vec](),
+		vectors:        newShardMap[float16Vec](),
+		parallelism:    config.ScanParallelism,
 		collectionName: config.CollectionName,
-		distance: func() distance.Space {
-			if config.Distance == COSINE {
-				return distance.NewCosine()
-			} else if config.Distance == EUCLIDEAN {
-				return distance.NewEuclidean()
-			}
-			return distance.NewCosine()
-		}(),
-		quantization: Float16Quantization{},
+		distance:       distanceSpace(config.Distance),
+		quantization:   Float16Quantization{},
 	}
 }
 
@@ -73,13 +68,11 @@ func (qx *f16vecSpace) RemoveVector(collectionName string, id uint64) error {
 	return nil
 }
 
-func (qx *f16vecSpace) FullScan(collectionName string, target Vector, topK int,
+func (qx *f16vecSpace) FullScan(ctx context.Context, collectionName string, target Vector, topK int,
 ) (*ResultSet, error) {
 	if qx.distance.Type() == "cosine-dot" {
 		target = Normalize(target)
 	}
-	rs := NewResultSet(topK)
-
 	lower, err := qx.quantization.Lower(target)
 	if err != nil {
 		return nil, fmt.Errorf(ErrQuantizedFailed, err)
@@ -90,12 +83,18 @@ func (qx *f16vecSpace) FullScan(collectionName string, target Vector, topK int,
 	// 	sim := qx.quantization.Similarity(lower, qvec, qx.distance)
 	// 	rs.AddResult(ID(index), sim)
 	// }
-	qx.vectors.ForEach(func(u uint64, fv float16Vec) bool {
-		sim := qx.quantization.Similarity(lower, fv, qx.distance)
-		rs.AddResult(ID(u), sim)
-		return true
+	return parallelScan(ctx, qx.parallelism, topK, NewResultSet, func(shard int, rs *ResultSet, stop func() error) error {
+		var err error
+		qx.vectors.shards[shard].ForEach(func(u uint64, fv float16Vec) bool {
+			if err = stop(); err != nil {
+				return false
+			}
+			sim := qx.quantization.Similarity(lower, fv, qx.distance)
+			rs.AddResult(ID(u), sim)
+			return true
+		})
+		return err
 	})
-	return rs, nil
 }
 
 // func (qx *f16vecSpace) Commit() error {
@@ -181,3 +180,29 @@ func (qx *f16vecSpace) FullScan(collectionName string, target Vector, topK int,
 // 	qx.lock.Unlock()
 // 	return nil
 // }
+
+// dequantize returns the vector as it is stored, after the quantization roundtrip.
+func (qx *f16vecSpace) dequantize(vector Vector) (Vector, error) {
+	if qx.distance.Type() == "cosine-dot" {
+		vector = Normalize(vector)
+	}
+	lower, err := qx.quantization.Lower(vector)
+	if err != nil {
+		return nil, fmt.Errorf(ErrQuantizedFailed, err)
+	}
+	return qx.quantization.Raise(lower), nil
+}
+
+func (qx *f16vecSpace) forEach(fn func(id uint64, vector Vector) bool) {
+	qx.vectors.ForEach(func(u uint64, fv float16Vec) bool {
+		return fn(u, qx.quantization.Raise(fv))
+	})
+}
+
+func (qx *f16vecSpace) get(id uint64) (Vector, bool) {
+	fv, ok := qx.vectors.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return qx.quantization.Raise(fv), true
+}
@@ -50,6 +50,15 @@ func (q Float8Quantization) Lower(v Vector) (float8Vec, error) {
 	return out, nil
 }
 
+// Raise converts a lowered vector back to float32.
+func (q Float8Quantization) Raise(v float8Vec) Vector {
+	out := make(Vector, len(v))
+	for i, x := range v {
+		out[i] = x.Float32()
+	}
+	return out
+}
+
 func (q Float8Quantization) Name() string {
 	return "float8"
 }
@@ -1,16 +1,17 @@
 package edge
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
-	"github.com/sjy-dv/nnv/pkg/concurrentmap"
 	"github.com/sjy-dv/nnv/pkg/distance"
 )
 
 type f8vecSpace struct {
 	dimension      int
-	vectors        *concurrentmap.Map[uint64, float8Vec]
+	vectors        *shardMap[float8Vec]
+	parallelism    int
 	collectionName string
 	distance       distance.Space
 	quantization   Float8Quantization
@@ -20,17 +21,11 @@ type f8vecSpace struct {
 func newF8Vectorstore(config CollectionConfig) *f8vecSpace {
 	return &f8vecSpace{
 		dimension:      config.Dimension,
-		vectors:        concurrentmap.New[uint64, float8Vec](),
+		vectors:        newShardMap[float8Vec](),
+		parallelism:    config.ScanParallelism,
 		collectionName: config.CollectionName,
-		distance: func() distance.Space {
-			if config.Distance == COSINE {
-				return distance.NewCosine()
-			} else if config.Distance == EUCLIDEAN {
-				return distance.NewEuclidean()
-			}
-			return distance.NewCosine()
-		}(),
-		quantization: Float8Quantization{},
+		distance:       distanceSpace(config.Distance),
+		quantization:   Float8Quantization{},
 	}
 }
 
@@ -72,13 +67,11 @@ func (qx *f8vecSpace) RemoveVector(collectionName string, id uint64) error {
 	return nil
 }
 
-func (qx *f8vecSpace) FullScan(collectionName string, target Vector, topK int,
+func (qx *f8vecSpace) FullScan(ctx context.Context, collectionName string, target Vector, topK int,
 ) (*ResultSet, error) {
 	if qx.distance.Type() == "cosine-dot" {
 		target = Normalize(target)
 	}
-	rs := NewResultSet(topK)
-
 	lower, err := qx.quantization.Lower(target)
 	if err != nil {
 		return nil, fmt.Errorf(ErrQuantizedFailed, err)
@@ -89,10 +82,42 @@ func (qx *f8vecSpace) FullScan(collectionName string, target Vector, topK int,
 	// 	sim := qx.quantization.Similarity(lower, qvec, qx.distance)
 	// 	rs.AddResult(ID(index), sim)
 	// }
+	return parallelScan(ctx, qx.parallelism, topK, NewResultSet, func(shard int, rs *ResultSet, stop func() error) error {
+		var err error
+		qx.vectors.shards[shard].ForEach(func(u uint64, fv float8Vec) bool {
+			if err = stop(); err != nil {
+				return false
+			}
+			sim := qx.quantization.Similarity(lower, fv, qx.distance)
+			rs.AddResult(ID(u), sim)
+			return true
+		})
+		return err
+	})
+}
+
+// dequantize returns the vector as it is stored, after the quantization roundtrip.
+func (qx *f8vecSpace) dequantize(vector Vector) (Vector, error) {
+	if qx.distance.Type() == "cosine-dot" {
+		vector = Normalize(vector)
+	}
+	lower, err := qx.quantization.Lower(vector)
+	if err != nil {
+		return nil, fmt.Errorf(ErrQuantizedFailed, err)
+	}
+	return qx.quantization.Raise(lower), nil
+}
+
+func (qx *f8vecSpace) forEach(fn func(id uint64, vector Vector) bool) {
 	qx.vectors.ForEach(func(u uint64, fv float8Vec) bool {
-		sim := qx.quantization.Similarity(lower, fv, qx.distance)
-		rs.AddResult(ID(u), sim)
-		return true
+		return fn(u, qx.quantization.Raise(fv))
 	})
-	return rs, nil
+}
+
+func (qx *f8vecSpace) get(id uint64) (Vector, bool) {
+	fv, ok := qx.vectors.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return qx.quantization.Raise(fv), true
 }
@@ -0,0 +1,197 @@
+package edge
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+// quantizedSpace is a flat store which can hand back its vectors after the
+// quantization roundtrip, the graph is built on those so graph distances
+// match what the flat scan would compute.
+type quantizedSpace interface {
+	vectorspace
+	dequantize(vector Vector) (Vector, error)
+	forEach(fn func(id uint64, vector Vector) bool)
+	// get gives the vector as it is stored
+	get(id uint64) (Vector, bool)
+}
+
+// hnswvecSpace keeps the quantized flat store as the source of truth and
+// answers FullScan from an HNSW graph instead of scanning every vector.
+type hnswvecSpace struct {
+	base           quantizedSpace
+	graph          *vectorindex.Hnsw
+	collectionName string
+	dimension      int
+}
+
+func newHnswVectorstore(config CollectionConfig, base vectorspace) (*hnswvecSpace, error) {
+	qbase, ok := base.(quantizedSpace)
+	if !ok {
+		return nil, fmt.Errorf("quantization %s does not support hnsw index", config.Quantization)
+	}
+	return &hnswvecSpace{
+		base:           qbase,
+		graph:          newEdgeGraph(config),
+		collectionName: config.CollectionName,
+		dimension:      config.Dimension,
+	}, nil
+}
+
+func newEdgeGraph(config CollectionConfig) *vectorindex.Hnsw {
+	options := make([]vectorindex.HnswOption, 0, 3)
+	if config.HnswM > 0 {
+		options = append(options, vectorindex.HnswM(config.HnswM))
+	}
+	if config.HnswEfConstruction > 0 {
+		options = append(options, vectorindex.HnswEfConstruction(config.HnswEfConstruction))
+	}
+	ef := config.HnswEfSearch
+	if ef <= 0 {
+		ef = DEFAULT_HNSW_EF_SEARCH
+	}
+	options = append(options, vectorindex.HnswEf(ef))
+	return vectorindex.NewHnsw(uint(config.Dimension), distanceSpace(config.Distance), options...)
+}
+
+func (qx *hnswvecSpace) insertGraph(id uint64, vector Vector) error {
+	stored, err := qx.base.dequantize(vector)
+	if err != nil {
+		return err
+	}
+	return qx.graph.Insert(id, gomath.Vector(stored), vectorindex.Metadata{}, qx.graph.RandomLevel())
+}
+
+func (qx *hnswvecSpace) InsertVector(collectionName string, commitId uint64, vector Vector) error {
+	if err := qx.base.InsertVector(collectionName, commitId, vector); err != nil {
+		return err
+	}
+	return qx.insertGraph(commitId, vector)
+}
+
+func (qx *hnswvecSpace) UpdateVector(collectionName string, id uint64, vector Vector) error {
+	if err := qx.base.UpdateVector(collectionName, id, vector); err != nil {
+		return err
+	}
+	// vertices are immutable, relink the node with the new vector
+	qx.graph.Remove(id)
+	return qx.insertGraph(id, vector)
+}
+
+func (qx *hnswvecSpace) RemoveVector(collectionName string, id uint64) error {
+	if err := qx.base.RemoveVector(collectionName, id); err != nil {
+		return err
+	}
+	qx.graph.Remove(id)
+	return nil
+}
+
+func (qx *hnswvecSpace) FullScan(ctx context.Context, collectionName string, target Vector, topK int,
+) (*ResultSet, error) {
+	found, err := qx.graph.Search(ctx, gomath.Vector(target), uint(topK))
+	if err != nil {
+		return nil, err
+	}
+	// graph results come closest first with the distances the flat stores
+	// keep, so distanceScore scores both paths alike
+	rs := NewResultSet(topK)
+	for i, item := range found {
+		rs.ids[i] = ID(item.Id)
+		rs.sims[i] = item.Score
+	}
+	rs.valid = len(found)
+	return rs, nil
+}
+
+func (qx *hnswvecSpace) get(id uint64) (Vector, bool) {
+	return qx.base.get(id)
+}
+
+func (qx *hnswvecSpace) commit(filename string) error {
+	f, err := os.OpenFile(filename, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	if err := qx.graph.Commit(w, true); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// load restores the graph written by commit and reconciles it with the flat
+// store, the store was rebuilt from disk and is always the newer one.
+// A missing or broken graph file is rebuilt from the store.
+func (qx *hnswvecSpace) load(filename string, config CollectionConfig) {
+	if err := qx.loadGraph(filename); err != nil {
+		// an empty graph is committed without an entrypoint
+		if !os.IsNotExist(err) && !errors.Is(err, io.EOF) {
+			log.Warn().Err(err).Str("collection", qx.collectionName).Msg("hnsw graph is damaged, rebuilding")
+		}
+		qx.graph = newEdgeGraph(config)
+	}
+	count := 0
+	qx.base.forEach(func(id uint64, vector Vector) bool {
+		count++
+		expected := vector
+		if qx.graph.Distance() == T_COSINE {
+			// the graph keeps cosine vectors normalized
+			expected = Normalize(vector)
+		}
+		if stored, err := qx.graph.Get(id); err == nil {
+			if equalVector(stored, expected) {
+				return true
+			}
+			qx.graph.Remove(id)
+		}
+		qx.graph.Insert(id, gomath.Vector(vector), vectorindex.Metadata{}, qx.graph.RandomLevel())
+		return true
+	})
+	if qx.graph.Len() != count {
+		// the graph still holds nodes deleted after the last flush
+		log.Debug().Str("collection", qx.collectionName).Msg("hnsw graph is out of date, rebuilding")
+		qx.graph = newEdgeGraph(config)
+		qx.base.forEach(func(id uint64, vector Vector) bool {
+			qx.graph.Insert(id, gomath.Vector(vector), vectorindex.Metadata{}, qx.graph.RandomLevel())
+			return true
+		})
+	}
+}
+
+func (qx *hnswvecSpace) loadGraph(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	graph := vectorindex.NewHnsw(uint(qx.dimension), nil)
+	if err := graph.Load(bufio.NewReader(f), true); err != nil {
+		return err
+	}
+	if graph.Dim() != uint32(qx.dimension) {
+		return fmt.Errorf("hnsw graph dimension %d, expected %d", graph.Dim(), qx.dimension)
+	}
+	qx.graph = graph
+	return nil
+}
+
+func equalVector(a gomath.Vector, b Vector) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
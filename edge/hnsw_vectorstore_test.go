@@ -0,0 +1,134 @@
+package edge
+
+import (
+	"context"
+	"math/rand/v2"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHnswVecSpaceCommitAndLoad(t *testing.T) {
+	config := CollectionConfig{
+		Dimension:      32,
+		CollectionName: "hnsw",
+		Distance:       EUCLIDEAN,
+		Quantization:   F16_QUANTIZATION,
+		IndexConfig:    IndexConfig{IndexType: HNSW_INDEX, HnswM: 8, HnswEfSearch: 32},
+	}
+	base := newF16Vectorstore(config)
+	space, err := withIndex(config, base)
+	assert.Nil(t, err)
+
+	vecs := make([]Vector, 500)
+	for i := range vecs {
+		vecs[i] = make(Vector, config.Dimension)
+		for j := range vecs[i] {
+			vecs[i][j] = rand.Float32()
+		}
+		assert.Nil(t, space.InsertVector("hnsw", uint64(i), vecs[i]))
+	}
+	rs, err := space.FullScan(context.Background(), "hnsw", vecs[42], 5)
+	assert.Nil(t, err)
+	assert.Equal(t, ID(42), rs.ids[0])
+
+	filename := filepath.Join(t.TempDir(), "graph.raw")
+	assert.Nil(t, space.(*hnswvecSpace).commit(filename))
+
+	// changes after the commit are reconciled from the flat store on load
+	assert.Nil(t, base.RemoveVector("hnsw", 7))
+	assert.Nil(t, base.UpdateVector("hnsw", 42, vecs[0]))
+
+	loaded, err := newHnswVectorstore(config, base)
+	assert.Nil(t, err)
+	loaded.load(filename, config)
+	assert.Equal(t, 499, loaded.graph.Len())
+
+	rs, err = loaded.FullScan(context.Background(), "hnsw", vecs[100], 5)
+	assert.Nil(t, err)
+	assert.Equal(t, ID(100), rs.ids[0])
+	_, err = loaded.graph.Get(7)
+	assert.NotNil(t, err)
+	rs, err = loaded.FullScan(context.Background(), "hnsw", vecs[0], 2)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []ID{0, 42}, rs.ids[:rs.valid])
+}
+
+func TestFullScanCancel(t *testing.T) {
+	config := CollectionConfig{
+		Dimension:      16,
+		CollectionName: "cancel",
+		Distance:       EUCLIDEAN,
+		Quantization:   BF16_QUANTIZATION,
+	}
+	space := newBF16Vectorstore(config)
+	for i := 0; i < 3*scanCheckEvery; i++ {
+		vec := make(Vector, config.Dimension)
+		for j := range vec {
+			vec[j] = rand.Float32()
+		}
+		assert.Nil(t, space.InsertVector("cancel", uint64(i), vec))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := space.FullScan(ctx, "cancel", make(Vector, config.Dimension), 5)
+	assert.Equal(t, context.Canceled, err)
+
+	rs, err := space.FullScan(context.Background(), "cancel", make(Vector, config.Dimension), 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, rs.valid)
+}
+
+func TestHnswAndFlatScoreAlike(t *testing.T) {
+	for _, distance := range []string{COSINE, INNER_PRODUCT, HAMMING} {
+		t.Run(distance, func(t *testing.T) {
+			config := CollectionConfig{
+				Dimension:      16,
+				CollectionName: "scores",
+				Distance:       distance,
+				Quantization:   F16_QUANTIZATION,
+			}
+			flat := newF16Vectorstore(config)
+			config.IndexConfig = IndexConfig{IndexType: HNSW_INDEX, HnswEfSearch: 200}
+			graph, err := withIndex(config, newF16Vectorstore(config))
+			assert.Nil(t, err)
+
+			r := rand.New(rand.NewPCG(1, 2))
+			vector := func() Vector {
+				v := make(Vector, config.Dimension)
+				for j := range v {
+					if distance == HAMMING {
+						v[j] = float32(r.IntN(2))
+					} else {
+						v[j] = r.Float32()*2 - 1
+					}
+				}
+				return v
+			}
+			for i := 0; i < 100; i++ {
+				v := vector()
+				assert.Nil(t, flat.InsertVector("scores", uint64(i), v))
+				assert.Nil(t, graph.InsertVector("scores", uint64(i), v))
+			}
+			target := vector()
+			flatRs, err := flat.FullScan(context.Background(), "scores", target, 10)
+			assert.Nil(t, err)
+			graphRs, err := graph.FullScan(context.Background(), "scores", target, 10)
+			assert.Nil(t, err)
+
+			// both paths give distances closest first, so the scores fall
+			// and the two agree
+			assert.Equal(t, flatRs.valid, graphRs.valid)
+			for i := 0; i < flatRs.valid; i++ {
+				flatScore := distanceScore(distance, flatRs.sims[i], int32(config.Dimension))
+				graphScore := distanceScore(distance, graphRs.sims[i], int32(config.Dimension))
+				assert.InDelta(t, flatScore, graphScore, 0.01)
+				if i > 0 {
+					assert.GreaterOrEqual(t, distanceScore(distance, flatRs.sims[i-1], int32(config.Dimension)), flatScore)
+					assert.GreaterOrEqual(t, distanceScore(distance, graphRs.sims[i-1], int32(config.Dimension)), graphScore)
+				}
+			}
+		})
+	}
+}
@@ -37,8 +37,11 @@ type ResultSet struct {
 	ids   []ID
 	k     int
 	valid int
+	// scores keeps the largest values first, distances the smallest
+	scores bool
 }
 
+// NewResultSet keeps the topK smallest distances, closest first.
 func NewResultSet(topK int) *ResultSet {
 	return &ResultSet{
 		k:     topK,
@@ -48,6 +51,22 @@ func NewResultSet(topK int) *ResultSet {
 	}
 }
 
+// NewScoreResultSet keeps the topK highest scores, for scans that rank by a
+// composite score instead of a distance.
+func NewScoreResultSet(topK int) *ResultSet {
+	rs := NewResultSet(topK)
+	rs.scores = true
+	return rs
+}
+
+// ahead tells value a ranks before value b.
+func (rs *ResultSet) ahead(a, b float32) bool {
+	if rs.scores {
+		return a > b
+	}
+	return a < b
+}
+
 func (rs *ResultSet) Len() int {
 	return len(rs.sims)
 }
@@ -73,7 +92,7 @@ func (rs *ResultSet) AddResult(id ID, sim float32) bool {
 	if rs.valid == rs.k {
 		// Bail if the last one beats us
 		last := rs.sims[len(rs.sims)-1]
-		if last > sim {
+		if rs.ahead(last, sim) {
 			return false
 		}
 	}
@@ -84,14 +103,13 @@ func (rs *ResultSet) AddResult(id ID, sim float32) bool {
 	for insert != rs.k {
 		// If we're building it out, then the new insertion point is at the end.
 		if rs.valid <= insert {
-			rs.valid += 1
 			found = true
 			break
 		}
 		if rs.ids[insert] == id {
 			return true
 		}
-		if rs.sims[insert] < sim {
+		if rs.ahead(sim, rs.sims[insert]) {
 			found = true
 			break
 		}
@@ -100,6 +118,9 @@ func (rs *ResultSet) AddResult(id ID, sim float32) bool {
 	if !found {
 		return false
 	}
+	if rs.valid < rs.k {
+		rs.valid += 1
+	}
 	copy(rs.sims[insert+1:], rs.sims[insert:])
 	rs.sims[insert] = sim
 	copy(rs.ids[insert+1:], rs.ids[insert:])
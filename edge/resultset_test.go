@@ -0,0 +1,35 @@
+package edge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultSetAddResult(t *testing.T) {
+	rs := NewScoreResultSet(3)
+	rs.AddResult(1, 50)
+	rs.AddResult(2, 90)
+	rs.AddResult(3, 70)
+	assert.Equal(t, 3, rs.valid)
+	assert.Equal(t, []ID{2, 3, 1}, rs.ids)
+
+	// a better result pushes the worst one out
+	rs.AddResult(4, 80)
+	assert.Equal(t, 3, rs.valid)
+	assert.Equal(t, []ID{2, 4, 3}, rs.ids)
+	assert.Equal(t, []float32{90, 80, 70}, rs.sims)
+	assert.False(t, rs.AddResult(5, 10))
+}
+
+func TestResultSetKeepsClosest(t *testing.T) {
+	rs := NewResultSet(3)
+	rs.AddResult(1, 0.5)
+	rs.AddResult(2, 0.1)
+	rs.AddResult(3, 0.3)
+	rs.AddResult(4, 0.2)
+	assert.Equal(t, 3, rs.valid)
+	assert.Equal(t, []ID{2, 4, 3}, rs.ids)
+	assert.Equal(t, []float32{0.1, 0.2, 0.3}, rs.sims)
+	assert.False(t, rs.AddResult(5, 0.9))
+}
@@ -23,15 +23,8 @@ func newSimpleVectorstore(config CollectionConfig) *simplevecSpace {
 	vecspace := &simplevecSpace{
 		dimension:      config.Dimension,
 		collectionName: config.CollectionName,
-		distance: func() distance.Space {
-			if config.Distance == COSINE {
-				return distance.NewCosine()
-			} else if config.Distance == EUCLIDEAN {
-				return distance.NewEuclidean()
-			}
-			return distance.NewCosine()
-		}(),
-		quantization: NoQuantization{},
+		distance:       distanceSpace(config.Distance),
+		quantization:   NoQuantization{},
 	}
 	for i := 0; i < EDGE_MAP_SHARD_COUNT; i++ {
 		vecspace.vertices[i] = make(map[uint64]ENode)
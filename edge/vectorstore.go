@@ -1,10 +1,15 @@
 package edge
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
+
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
 )
 
 type vectorspace interface {
@@ -13,7 +18,23 @@ type vectorspace interface {
 	InsertVector(collectionName string, commitId uint64, vector Vector) error
 	UpdateVector(collectionName string, id uint64, vector Vector) error
 	RemoveVector(collectionName string, id uint64) error
-	FullScan(collectionName string, target Vector, topK int) (*ResultSet, error)
+	// FullScan gives up with the ctx error once ctx is done
+	FullScan(ctx context.Context, collectionName string, target Vector, topK int) (*ResultSet, error)
+}
+
+// vectors compared by a scan between two looks at its ctx
+const scanCheckEvery = 1024
+
+// scanStop returns the ctx error every scanCheckEvery calls, nil otherwise.
+func scanStop(ctx context.Context) func() error {
+	n := 0
+	return func() error {
+		n++
+		if n%scanCheckEvery != 0 {
+			return nil
+		}
+		return ctx.Err()
+	}
 }
 
 type Vectorstore struct {
@@ -34,6 +55,16 @@ func (xx *Vectorstore) CreateCollection(config CollectionConfig) error {
 	if ok {
 		return fmt.Errorf(ErrCollectionExists, config.CollectionName)
 	}
+	if config.IndexType == CFLAT_INDEX {
+		vectorstore, err := newCFlatVectorstore(config)
+		if err != nil {
+			return err
+		}
+		xx.slock.Lock()
+		xx.Space[config.CollectionName] = vectorstore
+		xx.slock.Unlock()
+		return nil
+	}
 	var vectorstore vectorspace
 	if config.Quantization == F8_QUANTIZATION {
 		vectorstore = newF8Vectorstore(config)
@@ -46,6 +77,10 @@ func (xx *Vectorstore) CreateCollection(config CollectionConfig) error {
 	} else {
 		return errors.New("not support quantization type")
 	}
+	vectorstore, err := withIndex(config, vectorstore)
+	if err != nil {
+		return err
+	}
 	xx.slock.Lock()
 	xx.Space[config.CollectionName] = vectorstore
 	xx.slock.Unlock()
@@ -96,7 +131,7 @@ func (xx *Vectorstore) RemoveVector(collectionName string, id uint64) error {
 	return basis.RemoveVector(collectionName, id)
 }
 
-func (xx *Vectorstore) FullScan(collectionName string, target Vector, topK int,
+func (xx *Vectorstore) FullScan(ctx context.Context, collectionName string, target Vector, topK int,
 ) (*ResultSet, error) {
 	xx.slock.RLock()
 	basis, ok := xx.Space[collectionName]
@@ -104,28 +139,186 @@ func (xx *Vectorstore) FullScan(collectionName string, target Vector, topK int,
 	if !ok {
 		return nil, fmt.Errorf(ErrCollectionNotFound, collectionName)
 	}
-	return basis.FullScan(collectionName, target, topK)
+	return basis.FullScan(ctx, collectionName, target, topK)
 }
 
-func (xx *Vectorstore) Commit(collectionName string) error {
+/*
+RangeScan gives the ids within radius of target closest first, at most limit
+of them, with their distances. Hnsw collections widen the graph beam until
+it leaves the radius, flat ones compare every vector.
+*/
+func (xx *Vectorstore) RangeScan(ctx context.Context, collectionName string, target Vector, space distance.Space,
+	radius float32, limit int) ([]ID, []float32, error) {
+	xx.slock.RLock()
+	basis, ok := xx.Space[collectionName]
+	xx.slock.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf(ErrCollectionNotFound, collectionName)
+	}
+	var (
+		ids   []ID
+		dists []float32
+	)
+	switch store := basis.(type) {
+	case *hnswvecSpace:
+		found, err := store.graph.SearchRange(ctx, gomath.Vector(target), radius, uint(limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, item := range found {
+			ids = append(ids, ID(item.Id))
+			dists = append(dists, item.Score)
+		}
+		return ids, dists, nil
+	case quantizedSpace:
+		if space.Type() == T_COSINE {
+			target = Normalize(target)
+		}
+		stop := scanStop(ctx)
+		var err error
+		store.forEach(func(id uint64, vector Vector) bool {
+			if err = stop(); err != nil {
+				return false
+			}
+			if d := space.Distance(target, vector); d <= radius {
+				ids = append(ids, ID(id))
+				dists = append(dists, d)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf(ErrCFlatFieldQueries, collectionName)
+	}
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return dists[order[i]] < dists[order[j]] })
+	if len(order) > limit {
+		order = order[:limit]
+	}
+	outIds := make([]ID, len(order))
+	outDists := make([]float32, len(order))
+	for i, o := range order {
+		outIds[i], outDists[i] = ids[o], dists[o]
+	}
+	return outIds, outDists, nil
+}
+
+// Vector gives the stored vector of a record, quantized vectors come back raised.
+func (xx *Vectorstore) Vector(collectionName string, id uint64) (Vector, error) {
+	xx.slock.RLock()
+	basis, ok := xx.Space[collectionName]
+	xx.slock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf(ErrCollectionNotFound, collectionName)
+	}
+	stored, ok := basis.(interface {
+		get(id uint64) (Vector, bool)
+	})
+	if !ok {
+		return nil, fmt.Errorf(ErrVectorNotFound, id, collectionName)
+	}
+	vector, ok := stored.get(id)
+	if !ok {
+		return nil, fmt.Errorf(ErrVectorNotFound, id, collectionName)
+	}
+	return vector, nil
+}
+
+func (xx *Vectorstore) cflat(collectionName string) (*cflatvecSpace, error) {
+	xx.slock.RLock()
+	basis, ok := xx.Space[collectionName]
+	xx.slock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf(ErrCollectionNotFound, collectionName)
+	}
+	composite, ok := basis.(*cflatvecSpace)
+	if !ok {
+		return nil, fmt.Errorf(ErrNotCFlat, collectionName)
+	}
+	return composite, nil
+}
 
+// ComposeVector lays the field vectors of a cflat record out as the one
+// vector the collection stores.
+func (xx *Vectorstore) ComposeVector(collectionName string, vectors map[string]Vector) (Vector, error) {
+	composite, err := xx.cflat(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	return composite.compose(vectors)
+}
+
+func (xx *Vectorstore) CompositeScan(ctx context.Context, collectionName string, queries []FieldQuery, topK int,
+) (*ResultSet, map[ID]map[string]float32, error) {
+	composite, err := xx.cflat(collectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return composite.CompositeScan(ctx, queries, topK)
+}
+
+// Commit persists what can not be rebuilt cheaply from the vectors on disk,
+// which is the graph of hnsw collections.
+func (xx *Vectorstore) Commit(collectionName string) error {
+	xx.slock.RLock()
+	basis, ok := xx.Space[collectionName]
+	xx.slock.RUnlock()
+	if !ok {
+		return fmt.Errorf(ErrCollectionNotFound, collectionName)
+	}
+	if graph, ok := basis.(*hnswvecSpace); ok {
+		return graph.commit(fmt.Sprintf(edgeGraph, collectionName))
+	}
 	return nil
 }
 
+// withIndex wraps the flat store with the index selected in the config.
+func withIndex(config CollectionConfig, base vectorspace) (vectorspace, error) {
+	switch config.IndexType {
+	case "", FLAT_INDEX:
+		return base, nil
+	case HNSW_INDEX:
+		return newHnswVectorstore(config, base)
+	}
+	return nil, fmt.Errorf("not support index type: %s", config.IndexType)
+}
+
 func (xx *Vectorstore) Load(collectionName string, config CollectionConfig) error {
-	xx.slock.Lock()
-	defer xx.slock.Unlock()
+	if config.IndexType == CFLAT_INDEX {
+		vectorstore, err := newCFlatVectorstore(config)
+		if err != nil {
+			return err
+		}
+		xx.slock.Lock()
+		defer xx.slock.Unlock()
+		xx.Space[collectionName] = vectorstore
+		return nil
+	}
+	var vectorstore vectorspace
 	if config.Quantization == F8_QUANTIZATION {
-		xx.Space[collectionName] = newF8Vectorstore(config)
+		vectorstore = newF8Vectorstore(config)
 	} else if config.Quantization == F16_QUANTIZATION {
-		xx.Space[collectionName] = newF16Vectorstore(config)
+		vectorstore = newF16Vectorstore(config)
 	} else if config.Quantization == BF16_QUANTIZATION {
-		xx.Space[collectionName] = newBF16Vectorstore(config)
+		vectorstore = newBF16Vectorstore(config)
 	} else if config.Quantization == NONE_QAUNTIZATION {
-		// xx.Space[collectionName] = newSimpleVectorstore(config)
+		// vectorstore = newSimpleVectorstore(config)
+		return nil
 	} else {
 		return errors.New("not support quantization type")
 	}
+	vectorstore, err := withIndex(config, vectorstore)
+	if err != nil {
+		return err
+	}
+	xx.slock.Lock()
+	defer xx.slock.Unlock()
+	xx.Space[collectionName] = vectorstore
 	return nil
 }
 
@@ -146,3 +339,50 @@ func Normalize(v []float32) []float32 {
 
 	return out
 }
+
+// distanceSpace resolves the distance space of a collection config, unknown
+// names fall back to cosine.
+func distanceSpace(dist string) distance.Space {
+	switch dist {
+	case EUCLIDEAN:
+		return distance.NewEuclidean()
+	case INNER_PRODUCT:
+		return distance.NewInnerProduct()
+	case MANHATTAN:
+		return distance.NewManhattan()
+	case HAMMING:
+		return distance.NewHamming()
+	}
+	return distance.NewCosine()
+}
+
+// beyondCutoff tells a euclidean or manhattan distance past 100, those records
+// are left out of a search that sets no min_score_threshold.
+func beyondCutoff(dist string, value float32, minScore float32) bool {
+	return minScore == 0 && (dist == EUCLIDEAN || dist == MANHATTAN) && value > 100
+}
+
+// distanceScore maps a raw distance to the 0-100 score returned to clients,
+// the score falls as the distance grows in every space. The cosine space
+// measures 1 - cos.
+func distanceScore(dist string, value float32, dim int32) float32 {
+	switch dist {
+	case COSINE:
+		return ((2 - value) / 2) * 100
+	case INNER_PRODUCT:
+		// unbounded, squash the inner product (-value) with a sigmoid
+		return float32(100 / (1 + math.Exp(float64(value))))
+	case HAMMING:
+		if dim == 0 {
+			return 0
+		}
+		return (1 - value/float32(dim)) * 100
+	}
+	return float32(math.Max(0, float64(100-value)))
+}
+
+// pairScore scores how alike two stored vectors are, the same mapping as the
+// relevance of a candidate so the two terms of a selection agree.
+func pairScore(dist string, space distance.Space, a, b Vector, dim int32) float32 {
+	return distanceScore(dist, space.Distance(a, b), dim)
+}
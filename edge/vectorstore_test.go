@@ -0,0 +1,28 @@
+package edge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatScanInnerProduct(t *testing.T) {
+	config := CollectionConfig{
+		Dimension:      2,
+		CollectionName: "ip",
+		Distance:       INNER_PRODUCT,
+		Quantization:   F16_QUANTIZATION,
+	}
+	space := newF16Vectorstore(config)
+	// the dot product with {1, 0} grows with the id
+	for i := 0; i < 50; i++ {
+		assert.Nil(t, space.InsertVector("ip", uint64(i), Vector{float32(i) / 10, 1}))
+	}
+	rs, err := space.FullScan(context.Background(), "ip", Vector{1, 0}, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []ID{49, 48, 47, 46, 45}, rs.ids[:rs.valid])
+	for i := 1; i < rs.valid; i++ {
+		assert.Greater(t, distanceScore(INNER_PRODUCT, rs.sims[i-1], 2), distanceScore(INNER_PRODUCT, rs.sims[i], 2))
+	}
+}
@@ -22,11 +22,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type IndexType int32
+
+const (
+	IndexType_Flat  IndexType = 0
+	IndexType_Hnsw  IndexType = 1
+	IndexType_CFlat IndexType = 2 // composite flat, records carry several named vectors
+)
+
+// Enum value maps for IndexType.
+var (
+	IndexType_name = map[int32]string{
+		0: "Flat",
+		1: "Hnsw",
+		2: "CFlat",
+	}
+	IndexType_value = map[string]int32{
+		"Flat":  0,
+		"Hnsw":  1,
+		"CFlat": 2,
+	}
+)
+
+func (x IndexType) Enum() *IndexType {
+	p := new(IndexType)
+	*p = x
+	return p
+}
+
+func (x IndexType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexType) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v2_edge_proto_enumTypes[0].Descriptor()
+}
+
+func (IndexType) Type() protoreflect.EnumType {
+	return &file_idl_proto_v2_edge_proto_enumTypes[0]
+}
+
+func (x IndexType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexType.Descriptor instead.
+func (IndexType) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{0}
+}
+
 type Distance int32
 
 const (
-	Distance_Cosine    Distance = 0
-	Distance_Euclidean Distance = 1
+	Distance_Cosine       Distance = 0
+	Distance_Euclidean    Distance = 1
+	Distance_InnerProduct Distance = 2
+	Distance_Manhattan    Distance = 3
+	Distance_Hamming      Distance = 4
 )
 
 // Enum value maps for Distance.
@@ -34,10 +86,16 @@ var (
 	Distance_name = map[int32]string{
 		0: "Cosine",
 		1: "Euclidean",
+		2: "InnerProduct",
+		3: "Manhattan",
+		4: "Hamming",
 	}
 	Distance_value = map[string]int32{
-		"Cosine":    0,
-		"Euclidean": 1,
+		"Cosine":       0,
+		"Euclidean":    1,
+		"InnerProduct": 2,
+		"Manhattan":    3,
+		"Hamming":      4,
 	}
 )
 
@@ -52,11 +110,11 @@ func (x Distance) String() string {
 }
 
 func (Distance) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v2_edge_proto_enumTypes[0].Descriptor()
+	return file_idl_proto_v2_edge_proto_enumTypes[1].Descriptor()
 }
 
 func (Distance) Type() protoreflect.EnumType {
-	return &file_idl_proto_v2_edge_proto_enumTypes[0]
+	return &file_idl_proto_v2_edge_proto_enumTypes[1]
 }
 
 func (x Distance) Number() protoreflect.EnumNumber {
@@ -65,7 +123,7 @@ func (x Distance) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Distance.Descriptor instead.
 func (Distance) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{0}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{1}
 }
 
 type Quantization int32
@@ -104,11 +162,11 @@ func (x Quantization) String() string {
 }
 
 func (Quantization) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v2_edge_proto_enumTypes[1].Descriptor()
+	return file_idl_proto_v2_edge_proto_enumTypes[2].Descriptor()
 }
 
 func (Quantization) Type() protoreflect.EnumType {
-	return &file_idl_proto_v2_edge_proto_enumTypes[1]
+	return &file_idl_proto_v2_edge_proto_enumTypes[2]
 }
 
 func (x Quantization) Number() protoreflect.EnumNumber {
@@ -117,7 +175,7 @@ func (x Quantization) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Quantization.Descriptor instead.
 func (Quantization) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{1}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{2}
 }
 
 type ErrorCode int32
@@ -162,11 +220,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v2_edge_proto_enumTypes[2].Descriptor()
+	return file_idl_proto_v2_edge_proto_enumTypes[3].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_idl_proto_v2_edge_proto_enumTypes[2]
+	return &file_idl_proto_v2_edge_proto_enumTypes[3]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -175,7 +233,56 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{2}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{3}
+}
+
+type RecommendStrategy int32
+
+const (
+	// one search with 2 * mean(positive) - mean(negative), or mean(positive)
+	RecommendStrategy_AverageVector RecommendStrategy = 0
+	// one search per positive example, a result scores its best positive match,
+	// or minus its best negative match when a negative example is closer
+	RecommendStrategy_BestScore RecommendStrategy = 1
+)
+
+// Enum value maps for RecommendStrategy.
+var (
+	RecommendStrategy_name = map[int32]string{
+		0: "AverageVector",
+		1: "BestScore",
+	}
+	RecommendStrategy_value = map[string]int32{
+		"AverageVector": 0,
+		"BestScore":     1,
+	}
+)
+
+func (x RecommendStrategy) Enum() *RecommendStrategy {
+	p := new(RecommendStrategy)
+	*p = x
+	return p
+}
+
+func (x RecommendStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecommendStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v2_edge_proto_enumTypes[4].Descriptor()
+}
+
+func (RecommendStrategy) Type() protoreflect.EnumType {
+	return &file_idl_proto_v2_edge_proto_enumTypes[4]
+}
+
+func (x RecommendStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecommendStrategy.Descriptor instead.
+func (RecommendStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{4}
 }
 
 type Collection struct {
@@ -183,10 +290,15 @@ type Collection struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Distance       Distance     `protobuf:"varint,2,opt,name=distance,proto3,enum=edgeproto.Distance" json:"distance,omitempty"`
-	Quantization   Quantization `protobuf:"varint,3,opt,name=quantization,proto3,enum=edgeproto.Quantization" json:"quantization,omitempty"`
-	Dim            uint32       `protobuf:"varint,4,opt,name=dim,proto3" json:"dim,omitempty"`
+	CollectionName  string         `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Distance        Distance       `protobuf:"varint,2,opt,name=distance,proto3,enum=edgeproto.Distance" json:"distance,omitempty"`
+	Quantization    Quantization   `protobuf:"varint,3,opt,name=quantization,proto3,enum=edgeproto.Quantization" json:"quantization,omitempty"`
+	Dim             uint32         `protobuf:"varint,4,opt,name=dim,proto3" json:"dim,omitempty"`
+	IndexType       IndexType      `protobuf:"varint,5,opt,name=index_type,json=indexType,proto3,enum=edgeproto.IndexType" json:"index_type,omitempty"`
+	HnswParams      *HnswParams    `protobuf:"bytes,6,opt,name=hnsw_params,json=hnswParams,proto3" json:"hnsw_params,omitempty"`
+	MaxQueryTimeMs  uint32         `protobuf:"varint,7,opt,name=max_query_time_ms,json=maxQueryTimeMs,proto3" json:"max_query_time_ms,omitempty"` // searches past it fail with DEADLINE_EXCEEDED, 0 is no limit
+	ScanParallelism uint32         `protobuf:"varint,8,opt,name=scan_parallelism,json=scanParallelism,proto3" json:"scan_parallelism,omitempty"`  // shard jobs of one flat scan, 0 is one per worker
+	VectorFields    []*VectorField `protobuf:"bytes,9,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"`            // the named vectors of a CFlat record, dim and distance are unused
 }
 
 func (x *Collection) Reset() {
@@ -247,6 +359,164 @@ func (x *Collection) GetDim() uint32 {
 	return 0
 }
 
+func (x *Collection) GetIndexType() IndexType {
+	if x != nil {
+		return x.IndexType
+	}
+	return IndexType_Flat
+}
+
+func (x *Collection) GetHnswParams() *HnswParams {
+	if x != nil {
+		return x.HnswParams
+	}
+	return nil
+}
+
+func (x *Collection) GetMaxQueryTimeMs() uint32 {
+	if x != nil {
+		return x.MaxQueryTimeMs
+	}
+	return 0
+}
+
+func (x *Collection) GetScanParallelism() uint32 {
+	if x != nil {
+		return x.ScanParallelism
+	}
+	return 0
+}
+
+func (x *Collection) GetVectorFields() []*VectorField {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+type VectorField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dim      uint32   `protobuf:"varint,2,opt,name=dim,proto3" json:"dim,omitempty"`
+	Distance Distance `protobuf:"varint,3,opt,name=distance,proto3,enum=edgeproto.Distance" json:"distance,omitempty"`
+}
+
+func (x *VectorField) Reset() {
+	*x = VectorField{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorField) ProtoMessage() {}
+
+func (x *VectorField) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorField.ProtoReflect.Descriptor instead.
+func (*VectorField) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VectorField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VectorField) GetDim() uint32 {
+	if x != nil {
+		return x.Dim
+	}
+	return 0
+}
+
+func (x *VectorField) GetDistance() Distance {
+	if x != nil {
+		return x.Distance
+	}
+	return Distance_Cosine
+}
+
+// zero values fall back to the index defaults (m=16, ef_construction=200, ef_search=64)
+type HnswParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	M              uint32 `protobuf:"varint,1,opt,name=m,proto3" json:"m,omitempty"`
+	EfConstruction uint32 `protobuf:"varint,2,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
+	EfSearch       uint32 `protobuf:"varint,3,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`
+}
+
+func (x *HnswParams) Reset() {
+	*x = HnswParams{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HnswParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HnswParams) ProtoMessage() {}
+
+func (x *HnswParams) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HnswParams.ProtoReflect.Descriptor instead.
+func (*HnswParams) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HnswParams) GetM() uint32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *HnswParams) GetEfConstruction() uint32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *HnswParams) GetEfSearch() uint32 {
+	if x != nil {
+		return x.EfSearch
+	}
+	return 0
+}
+
 type CollectionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -259,7 +529,7 @@ type CollectionResponse struct {
 
 func (x *CollectionResponse) Reset() {
 	*x = CollectionResponse{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[1]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +541,7 @@ func (x *CollectionResponse) String() string {
 func (*CollectionResponse) ProtoMessage() {}
 
 func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[1]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,7 +554,7 @@ func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionResponse.ProtoReflect.Descriptor instead.
 func (*CollectionResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{1}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CollectionResponse) GetCollection() *Collection {
@@ -322,7 +592,7 @@ type CollectionDetail struct {
 
 func (x *CollectionDetail) Reset() {
 	*x = CollectionDetail{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[2]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +604,7 @@ func (x *CollectionDetail) String() string {
 func (*CollectionDetail) ProtoMessage() {}
 
 func (x *CollectionDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[2]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +617,7 @@ func (x *CollectionDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionDetail.ProtoReflect.Descriptor instead.
 func (*CollectionDetail) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{2}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CollectionDetail) GetCollection() *Collection {
@@ -396,7 +666,7 @@ type DeleteCollectionResponse struct {
 
 func (x *DeleteCollectionResponse) Reset() {
 	*x = DeleteCollectionResponse{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[3]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -408,7 +678,7 @@ func (x *DeleteCollectionResponse) String() string {
 func (*DeleteCollectionResponse) ProtoMessage() {}
 
 func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[3]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +691,7 @@ func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCollectionResponse.ProtoReflect.Descriptor instead.
 func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{3}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeleteCollectionResponse) GetStatus() bool {
@@ -443,15 +713,16 @@ type ModifyDataset struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CollectionName string           `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Vector         []float32        `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
-	Metadata       *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id             string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollectionName string                  `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Vector         []float32               `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Metadata       *structpb.Struct        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Vectors        map[string]*FieldVector `protobuf:"bytes,5,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // one vector per field of a CFlat collection, replaces vector
 }
 
 func (x *ModifyDataset) Reset() {
 	*x = ModifyDataset{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[4]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -463,7 +734,7 @@ func (x *ModifyDataset) String() string {
 func (*ModifyDataset) ProtoMessage() {}
 
 func (x *ModifyDataset) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[4]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +747,7 @@ func (x *ModifyDataset) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModifyDataset.ProtoReflect.Descriptor instead.
 func (*ModifyDataset) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{4}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ModifyDataset) GetId() string {
@@ -507,6 +778,58 @@ func (x *ModifyDataset) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *ModifyDataset) GetVectors() map[string]*FieldVector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+type FieldVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vector []float32 `protobuf:"fixed32,1,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+}
+
+func (x *FieldVector) Reset() {
+	*x = FieldVector{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldVector) ProtoMessage() {}
+
+func (x *FieldVector) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldVector.ProtoReflect.Descriptor instead.
+func (*FieldVector) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FieldVector) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
 type CollectionName struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -518,7 +841,7 @@ type CollectionName struct {
 
 func (x *CollectionName) Reset() {
 	*x = CollectionName{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[5]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -530,7 +853,7 @@ func (x *CollectionName) String() string {
 func (*CollectionName) ProtoMessage() {}
 
 func (x *CollectionName) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[5]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +866,7 @@ func (x *CollectionName) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionName.ProtoReflect.Descriptor instead.
 func (*CollectionName) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{5}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CollectionName) GetCollectionName() string {
@@ -571,7 +894,7 @@ type DeleteDataset struct {
 
 func (x *DeleteDataset) Reset() {
 	*x = DeleteDataset{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[6]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -583,7 +906,7 @@ func (x *DeleteDataset) String() string {
 func (*DeleteDataset) ProtoMessage() {}
 
 func (x *DeleteDataset) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[6]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,7 +919,7 @@ func (x *DeleteDataset) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDataset.ProtoReflect.Descriptor instead.
 func (*DeleteDataset) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{6}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteDataset) GetId() string {
@@ -624,7 +947,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[7]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -636,7 +959,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[7]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,7 +972,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{7}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Response) GetStatus() bool {
@@ -677,7 +1000,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[8]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -689,7 +1012,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[8]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -702,7 +1025,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{8}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Error) GetErrorMessage() string {
@@ -729,11 +1052,33 @@ type SearchReq struct {
 	TopK           uint64            `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`
 	Filter         map[string]string `protobuf:"bytes,4,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	WithLatency    bool              `protobuf:"varint,5,opt,name=with_latency,json=withLatency,proto3" json:"with_latency,omitempty"`
+	GeoFilter      *GeoFilter        `protobuf:"bytes,6,opt,name=geo_filter,json=geoFilter,proto3" json:"geo_filter,omitempty"`
+	FieldQueries   []*FieldQuery     `protobuf:"bytes,7,rep,name=field_queries,json=fieldQueries,proto3" json:"field_queries,omitempty"` // CFlat collections are searched with these instead of vector
+	Diversify      *Diversify        `protobuf:"bytes,8,opt,name=diversify,proto3" json:"diversify,omitempty"`
+	// VectorSearch only, gives the results in groups by the value of a metadata
+	// field, up to group_size results of each of the best limit groups
+	GroupBy           string  `protobuf:"bytes,9,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	GroupSize         uint32  `protobuf:"varint,10,opt,name=group_size,json=groupSize,proto3" json:"group_size,omitempty"`                            // 0 is 1
+	Limit             uint32  `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`                                                     // 0 is topK
+	MinScoreThreshold float32 `protobuf:"fixed32,12,opt,name=min_score_threshold,json=minScoreThreshold,proto3" json:"min_score_threshold,omitempty"` // drops the results scoring under it
+	// VectorSearch only, gives every record within radius of vector in the
+	// distance of the collection, closest first and up to topK, or up to
+	// 10000 when topK is 0
+	Radius *float32 `protobuf:"fixed32,13,opt,name=radius,proto3,oneof" json:"radius,omitempty"`
+	// VectorSearch and FilterSearch, skips the first offset results, or
+	// continues after the page a next_page_token of the same query ended
+	Offset    uint32 `protobuf:"varint,14,opt,name=offset,proto3" json:"offset,omitempty"`
+	PageToken string `protobuf:"bytes,15,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// metadata of the results keeps only include_fields when set, less
+	// exclude_fields
+	IncludeFields []string `protobuf:"bytes,16,rep,name=include_fields,json=includeFields,proto3" json:"include_fields,omitempty"`
+	ExcludeFields []string `protobuf:"bytes,17,rep,name=exclude_fields,json=excludeFields,proto3" json:"exclude_fields,omitempty"`
+	WithVector    bool     `protobuf:"varint,18,opt,name=with_vector,json=withVector,proto3" json:"with_vector,omitempty"` // gives the vector of each result as it was written
 }
 
 func (x *SearchReq) Reset() {
 	*x = SearchReq{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[9]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -745,7 +1090,7 @@ func (x *SearchReq) String() string {
 func (*SearchReq) ProtoMessage() {}
 
 func (x *SearchReq) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[9]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -758,7 +1103,7 @@ func (x *SearchReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchReq.ProtoReflect.Descriptor instead.
 func (*SearchReq) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{9}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SearchReq) GetCollectionName() string {
@@ -796,20 +1141,527 @@ func (x *SearchReq) GetWithLatency() bool {
 	return false
 }
 
+func (x *SearchReq) GetGeoFilter() *GeoFilter {
+	if x != nil {
+		return x.GeoFilter
+	}
+	return nil
+}
+
+func (x *SearchReq) GetFieldQueries() []*FieldQuery {
+	if x != nil {
+		return x.FieldQueries
+	}
+	return nil
+}
+
+func (x *SearchReq) GetDiversify() *Diversify {
+	if x != nil {
+		return x.Diversify
+	}
+	return nil
+}
+
+func (x *SearchReq) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *SearchReq) GetGroupSize() uint32 {
+	if x != nil {
+		return x.GroupSize
+	}
+	return 0
+}
+
+func (x *SearchReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchReq) GetMinScoreThreshold() float32 {
+	if x != nil {
+		return x.MinScoreThreshold
+	}
+	return 0
+}
+
+func (x *SearchReq) GetRadius() float32 {
+	if x != nil && x.Radius != nil {
+		return *x.Radius
+	}
+	return 0
+}
+
+func (x *SearchReq) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchReq) GetIncludeFields() []string {
+	if x != nil {
+		return x.IncludeFields
+	}
+	return nil
+}
+
+func (x *SearchReq) GetExcludeFields() []string {
+	if x != nil {
+		return x.ExcludeFields
+	}
+	return nil
+}
+
+func (x *SearchReq) GetWithVector() bool {
+	if x != nil {
+		return x.WithVector
+	}
+	return false
+}
+
+// searches records like the positive examples and unlike the negative ones,
+// examples are record ids or raw vectors and the records never come back
+type RecommendReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName  string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Positive        []string          `protobuf:"bytes,2,rep,name=positive,proto3" json:"positive,omitempty"`
+	Negative        []string          `protobuf:"bytes,3,rep,name=negative,proto3" json:"negative,omitempty"`
+	PositiveVectors []*FieldVector    `protobuf:"bytes,4,rep,name=positive_vectors,json=positiveVectors,proto3" json:"positive_vectors,omitempty"`
+	NegativeVectors []*FieldVector    `protobuf:"bytes,5,rep,name=negative_vectors,json=negativeVectors,proto3" json:"negative_vectors,omitempty"`
+	Strategy        RecommendStrategy `protobuf:"varint,6,opt,name=strategy,proto3,enum=edgeproto.RecommendStrategy" json:"strategy,omitempty"`
+	TopK            uint64            `protobuf:"varint,7,opt,name=topK,proto3" json:"topK,omitempty"`
+	Filter          map[string]string `protobuf:"bytes,8,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RecommendReq) Reset() {
+	*x = RecommendReq{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendReq) ProtoMessage() {}
+
+func (x *RecommendReq) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendReq.ProtoReflect.Descriptor instead.
+func (*RecommendReq) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RecommendReq) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *RecommendReq) GetPositive() []string {
+	if x != nil {
+		return x.Positive
+	}
+	return nil
+}
+
+func (x *RecommendReq) GetNegative() []string {
+	if x != nil {
+		return x.Negative
+	}
+	return nil
+}
+
+func (x *RecommendReq) GetPositiveVectors() []*FieldVector {
+	if x != nil {
+		return x.PositiveVectors
+	}
+	return nil
+}
+
+func (x *RecommendReq) GetNegativeVectors() []*FieldVector {
+	if x != nil {
+		return x.NegativeVectors
+	}
+	return nil
+}
+
+func (x *RecommendReq) GetStrategy() RecommendStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return RecommendStrategy_AverageVector
+}
+
+func (x *RecommendReq) GetTopK() uint64 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *RecommendReq) GetFilter() map[string]string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// picks the results by maximal marginal relevance out of fetch_k candidates,
+// similar results are compared with the distance of the collection
+type Diversify struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lambda *float32 `protobuf:"fixed32,1,opt,name=lambda,proto3,oneof" json:"lambda,omitempty"`        // relevance against novelty in [0, 1], unset is 0.5
+	FetchK uint32   `protobuf:"varint,2,opt,name=fetch_k,json=fetchK,proto3" json:"fetch_k,omitempty"` // 0 is 4 times topK
+}
+
+func (x *Diversify) Reset() {
+	*x = Diversify{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diversify) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diversify) ProtoMessage() {}
+
+func (x *Diversify) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diversify.ProtoReflect.Descriptor instead.
+func (*Diversify) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Diversify) GetLambda() float32 {
+	if x != nil && x.Lambda != nil {
+		return *x.Lambda
+	}
+	return 0
+}
+
+func (x *Diversify) GetFetchK() uint32 {
+	if x != nil {
+		return x.FetchK
+	}
+	return 0
+}
+
+// scores one field of a CFlat record, the composite score is the weighted mean
+// of the field scores and a field scoring under min_score drops the record
+type FieldQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field    string    `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Vector   []float32 `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Weight   float32   `protobuf:"fixed32,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	MinScore float32   `protobuf:"fixed32,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+}
+
+func (x *FieldQuery) Reset() {
+	*x = FieldQuery{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldQuery) ProtoMessage() {}
+
+func (x *FieldQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldQuery.ProtoReflect.Descriptor instead.
+func (*FieldQuery) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FieldQuery) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldQuery) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *FieldQuery) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *FieldQuery) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+type GeoPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GeoPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type GeoBoundingBox struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopLeft     *GeoPoint `protobuf:"bytes,1,opt,name=top_left,json=topLeft,proto3" json:"top_left,omitempty"`
+	BottomRight *GeoPoint `protobuf:"bytes,2,opt,name=bottom_right,json=bottomRight,proto3" json:"bottom_right,omitempty"`
+}
+
+func (x *GeoBoundingBox) Reset() {
+	*x = GeoBoundingBox{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoBoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoBoundingBox) ProtoMessage() {}
+
+func (x *GeoBoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoBoundingBox.ProtoReflect.Descriptor instead.
+func (*GeoBoundingBox) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GeoBoundingBox) GetTopLeft() *GeoPoint {
+	if x != nil {
+		return x.TopLeft
+	}
+	return nil
+}
+
+func (x *GeoBoundingBox) GetBottomRight() *GeoPoint {
+	if x != nil {
+		return x.BottomRight
+	}
+	return nil
+}
+
+// radius (radius_meters > 0) or bounding box filter on a geo metadata field,
+// a geo field is a metadata object {"lat": .., "lon": ..}
+type GeoFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field          string          `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Center         *GeoPoint       `protobuf:"bytes,2,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusMeters   float64         `protobuf:"fixed64,3,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	BoundingBox    *GeoBoundingBox `protobuf:"bytes,4,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	SortByDistance bool            `protobuf:"varint,5,opt,name=sort_by_distance,json=sortByDistance,proto3" json:"sort_by_distance,omitempty"`
+}
+
+func (x *GeoFilter) Reset() {
+	*x = GeoFilter{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoFilter) ProtoMessage() {}
+
+func (x *GeoFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoFilter.ProtoReflect.Descriptor instead.
+func (*GeoFilter) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GeoFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GeoFilter) GetCenter() *GeoPoint {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *GeoFilter) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *GeoFilter) GetBoundingBox() *GeoBoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *GeoFilter) GetSortByDistance() bool {
+	if x != nil {
+		return x.SortByDistance
+	}
+	return false
+}
+
 type SearchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     bool          `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error      *Error        `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Candidates []*Candidates `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
-	Latency    string        `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	Status        bool          `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         *Error        `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Candidates    []*Candidates `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Latency       string        `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	Groups        []*Group      `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`                                      // group_by searches, candidates is empty then
+	NextPageToken string        `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // set while results are left after the page
 }
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[10]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -821,7 +1673,7 @@ func (x *SearchResponse) String() string {
 func (*SearchResponse) ProtoMessage() {}
 
 func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[10]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,7 +1686,7 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
 func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{10}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SearchResponse) GetStatus() bool {
@@ -865,6 +1717,74 @@ func (x *SearchResponse) GetLatency() string {
 	return ""
 }
 
+func (x *SearchResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// the results sharing one value of the group_by field, best first
+type Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value      string        `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Candidates []*Candidates `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Group) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Group) GetCandidates() []*Candidates {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
 type Candidates struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -873,11 +1793,15 @@ type Candidates struct {
 	Id       string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Metadata *structpb.Struct `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Score    float32          `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
+	// meters from the geo filter origin, set when a geo filter is applied
+	GeoDistance float64            `protobuf:"fixed64,4,opt,name=geo_distance,json=geoDistance,proto3" json:"geo_distance,omitempty"`
+	FieldScores map[string]float32 `protobuf:"bytes,5,rep,name=field_scores,json=fieldScores,proto3" json:"field_scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"` // CFlat only, the score of each queried field
+	Vector      []float32          `protobuf:"fixed32,6,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                                               // set for with_vector searches
 }
 
 func (x *Candidates) Reset() {
 	*x = Candidates{}
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[11]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1813,7 @@ func (x *Candidates) String() string {
 func (*Candidates) ProtoMessage() {}
 
 func (x *Candidates) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v2_edge_proto_msgTypes[11]
+	mi := &file_idl_proto_v2_edge_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1826,7 @@ func (x *Candidates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Candidates.ProtoReflect.Descriptor instead.
 func (*Candidates) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{11}
+	return file_idl_proto_v2_edge_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Candidates) GetId() string {
@@ -926,6 +1850,27 @@ func (x *Candidates) GetScore() float32 {
 	return 0
 }
 
+func (x *Candidates) GetGeoDistance() float64 {
+	if x != nil {
+		return x.GeoDistance
+	}
+	return 0
+}
+
+func (x *Candidates) GetFieldScores() map[string]float32 {
+	if x != nil {
+		return x.FieldScores
+	}
+	return nil
+}
+
+func (x *Candidates) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
 var File_idl_proto_v2_edge_proto protoreflect.FileDescriptor
 
 var file_idl_proto_v2_edge_proto_rawDesc = []byte{
@@ -935,7 +1880,7 @@ var file_idl_proto_v2_edge_proto_rawDesc = []byte{
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xb5, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27,
+	0xb5, 0x03, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27,
 	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
 	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61,
@@ -946,171 +1891,343 @@ var file_idl_proto_v2_edge_proto_rawDesc = []byte{
 	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74,
 	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a,
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x6d, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x03, 0x64, 0x69, 0x6d, 0x22, 0x8b, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35,
-	0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65,
-	0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdf, 0x01, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x5a, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x22, 0x95, 0x01, 0x0a, 0x0d, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x61,
-	0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06,
-	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
-	0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x56, 0x0a, 0x0e, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a,
+	0x28, 0x0d, 0x52, 0x03, 0x64, 0x69, 0x6d, 0x12, 0x33, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x0b,
+	0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e,
+	0x73, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0a, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x29, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c,
+	0x69, 0x73, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x73, 0x63, 0x61, 0x6e, 0x50,
+	0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x3b, 0x0a, 0x0d, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x64, 0x0a, 0x0b, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x64, 0x69, 0x6d, 0x12, 0x2f, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x60, 0x0a,
+	0x0a, 0x48, 0x6e, 0x73, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x66, 0x5f,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x65, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x66, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x66, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22,
+	0x8b, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdf, 0x01,
+	0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0x5a, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xaa, 0x02, 0x0a, 0x0d,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a,
 	0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77, 0x69, 0x74, 0x68, 0x53,
-	0x69, 0x7a, 0x65, 0x22, 0x48, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74,
-	0x61, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4a, 0x0a,
-	0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a, 0x05, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
-	0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xf8, 0x01, 0x0a,
-	0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x6f, 0x70, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x12,
-	0x38, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x20, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x74,
-	0x68, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0b, 0x77, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x1a, 0x39, 0x0a, 0x0b,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa1, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61,
-	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x67, 0x0a, 0x0a, 0x43,
-	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
-	0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14,
-	0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x2a, 0x25, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
-	0x45, 0x75, 0x63, 0x6c, 0x69, 0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x2a, 0x33, 0x0a, 0x0c, 0x51,
-	0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e,
-	0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10, 0x01, 0x12, 0x06,
-	0x0a, 0x02, 0x46, 0x38, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31, 0x36, 0x10, 0x03,
-	0x2a, 0x97, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d,
-	0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a,
-	0x09, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d,
-	0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48,
-	0x41, 0x52, 0x44, 0x5f, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x12,
-	0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e,
-	0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11,
-	0x0a, 0x0d, 0x4d, 0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
-	0x04, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x46, 0x55,
-	0x4e, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x32, 0xf8, 0x06, 0x0a, 0x07, 0x45,
-	0x64, 0x67, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
-	0x12, 0x4a, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1d, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x23, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x49, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1b,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x00, 0x12, 0x4a, 0x0a,
-	0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x33,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x3f, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x1a, 0x52, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x25, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22,
+	0x56, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69,
+	0x74, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77,
+	0x69, 0x74, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x48, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a,
+	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x22, 0xeb, 0x05, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74,
+	0x6f, 0x70, 0x4b, 0x12, 0x38, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a,
+	0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x33, 0x0a, 0x0a, 0x67, 0x65, 0x6f, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x3a, 0x0a, 0x0d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x71,
+	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65,
+	0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x32, 0x0a, 0x09, 0x64, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x66, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x66, 0x79, 0x52, 0x09, 0x64, 0x69, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x66, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x5f,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x69,
+	0x74, 0x68, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0xbb,
+	0x03, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x12,
+	0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x41, 0x0a, 0x10, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x0f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x41, 0x0a, 0x10, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x74, 0x6f, 0x70, 0x4b, 0x12, 0x3b, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x2e, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c, 0x0a, 0x09,
+	0x44, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x66, 0x79, 0x12, 0x1b, 0x0a, 0x06, 0x6c, 0x61, 0x6d,
+	0x62, 0x64, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x06, 0x6c, 0x61, 0x6d,
+	0x62, 0x64, 0x61, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x66, 0x65, 0x74, 0x63, 0x68, 0x4b, 0x42,
+	0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x61, 0x6d, 0x62, 0x64, 0x61, 0x22, 0x6f, 0x0a, 0x0a, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x2e, 0x0a, 0x08, 0x47,
+	0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x22, 0x78, 0x0a, 0x0e, 0x47,
+	0x65, 0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x2e, 0x0a,
+	0x08, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x6f, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x07, 0x74, 0x6f, 0x70, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x36, 0x0a,
+	0x0c, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d,
+	0x52, 0x69, 0x67, 0x68, 0x74, 0x22, 0xdb, 0x01, 0x0a, 0x09, 0x47, 0x65, 0x6f, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06,
+	0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73,
+	0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x72,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x6f, 0x72,
+	0x74, 0x5f, 0x62, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x44, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x22, 0xf3, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x28, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x54, 0x0a, 0x05, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65,
+	0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22,
+	0xad, 0x02, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6f,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x67, 0x65, 0x6f, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x0c,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x06, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x1a,
+	0x3e, 0x0a, 0x10, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a,
+	0x2a, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04,
+	0x46, 0x6c, 0x61, 0x74, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x6e, 0x73, 0x77, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x43, 0x46, 0x6c, 0x61, 0x74, 0x10, 0x02, 0x2a, 0x53, 0x0a, 0x08, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x6f, 0x73, 0x69, 0x6e,
+	0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75, 0x63, 0x6c, 0x69, 0x64, 0x65, 0x61, 0x6e,
+	0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x61, 0x6e, 0x68, 0x61, 0x74, 0x74, 0x61,
+	0x6e, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x48, 0x61, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x10, 0x04,
+	0x2a, 0x33, 0x0a, 0x0c, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31,
+	0x36, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x38, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42,
+	0x46, 0x31, 0x36, 0x10, 0x03, 0x2a, 0x97, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e,
+	0x41, 0x4c, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a,
+	0x35, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x67, 0x79, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x65, 0x73, 0x74, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x10, 0x01, 0x32, 0xbb, 0x07, 0x0a, 0x07, 0x45, 0x64, 0x67, 0x65, 0x52,
+	0x70, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x10,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x15, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1d, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x65,
+	0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x23, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
 	0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c,
 	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1b, 0x2e, 0x65, 0x64, 0x67,
 	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x11, 0x52, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x05, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x12, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x49,
-	0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x1a,
-	0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x12, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x64,
-	0x69, 0x66, 0x79, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x39, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x73, 0x65, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c,
-	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x65,
-	0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
-	0x65, 0x71, 0x1a, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x41, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
-	0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x48, 0x79, 0x62, 0x72, 0x69, 0x64, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x12, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x65, 0x64, 0x67, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x4c, 0x6f, 0x61,
+	0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1b, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x05,
+	0x46, 0x6c, 0x75, 0x73, 0x68, 0x12, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x12, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f,
+	0x64, 0x69, 0x66, 0x79, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x65,
+	0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a,
+	0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x19,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x1a, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41,
+	0x0a, 0x0c, 0x48, 0x79, 0x62, 0x72, 0x69, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x12, 0x17,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x65, 0x64, 0x67, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1125,74 +2242,111 @@ func file_idl_proto_v2_edge_proto_rawDescGZIP() []byte {
 	return file_idl_proto_v2_edge_proto_rawDescData
 }
 
-var file_idl_proto_v2_edge_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_idl_proto_v2_edge_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_idl_proto_v2_edge_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_idl_proto_v2_edge_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_idl_proto_v2_edge_proto_goTypes = []any{
-	(Distance)(0),                    // 0: edgeproto.Distance
-	(Quantization)(0),                // 1: edgeproto.Quantization
-	(ErrorCode)(0),                   // 2: edgeproto.ErrorCode
-	(*Collection)(nil),               // 3: edgeproto.Collection
-	(*CollectionResponse)(nil),       // 4: edgeproto.CollectionResponse
-	(*CollectionDetail)(nil),         // 5: edgeproto.CollectionDetail
-	(*DeleteCollectionResponse)(nil), // 6: edgeproto.DeleteCollectionResponse
-	(*ModifyDataset)(nil),            // 7: edgeproto.ModifyDataset
-	(*CollectionName)(nil),           // 8: edgeproto.CollectionName
-	(*DeleteDataset)(nil),            // 9: edgeproto.DeleteDataset
-	(*Response)(nil),                 // 10: edgeproto.Response
-	(*Error)(nil),                    // 11: edgeproto.Error
-	(*SearchReq)(nil),                // 12: edgeproto.SearchReq
-	(*SearchResponse)(nil),           // 13: edgeproto.SearchResponse
-	(*Candidates)(nil),               // 14: edgeproto.Candidates
-	nil,                              // 15: edgeproto.SearchReq.FilterEntry
-	(*structpb.Struct)(nil),          // 16: google.protobuf.Struct
-	(*emptypb.Empty)(nil),            // 17: google.protobuf.Empty
+	(IndexType)(0),                   // 0: edgeproto.IndexType
+	(Distance)(0),                    // 1: edgeproto.Distance
+	(Quantization)(0),                // 2: edgeproto.Quantization
+	(ErrorCode)(0),                   // 3: edgeproto.ErrorCode
+	(RecommendStrategy)(0),           // 4: edgeproto.RecommendStrategy
+	(*Collection)(nil),               // 5: edgeproto.Collection
+	(*VectorField)(nil),              // 6: edgeproto.VectorField
+	(*HnswParams)(nil),               // 7: edgeproto.HnswParams
+	(*CollectionResponse)(nil),       // 8: edgeproto.CollectionResponse
+	(*CollectionDetail)(nil),         // 9: edgeproto.CollectionDetail
+	(*DeleteCollectionResponse)(nil), // 10: edgeproto.DeleteCollectionResponse
+	(*ModifyDataset)(nil),            // 11: edgeproto.ModifyDataset
+	(*FieldVector)(nil),              // 12: edgeproto.FieldVector
+	(*CollectionName)(nil),           // 13: edgeproto.CollectionName
+	(*DeleteDataset)(nil),            // 14: edgeproto.DeleteDataset
+	(*Response)(nil),                 // 15: edgeproto.Response
+	(*Error)(nil),                    // 16: edgeproto.Error
+	(*SearchReq)(nil),                // 17: edgeproto.SearchReq
+	(*RecommendReq)(nil),             // 18: edgeproto.RecommendReq
+	(*Diversify)(nil),                // 19: edgeproto.Diversify
+	(*FieldQuery)(nil),               // 20: edgeproto.FieldQuery
+	(*GeoPoint)(nil),                 // 21: edgeproto.GeoPoint
+	(*GeoBoundingBox)(nil),           // 22: edgeproto.GeoBoundingBox
+	(*GeoFilter)(nil),                // 23: edgeproto.GeoFilter
+	(*SearchResponse)(nil),           // 24: edgeproto.SearchResponse
+	(*Group)(nil),                    // 25: edgeproto.Group
+	(*Candidates)(nil),               // 26: edgeproto.Candidates
+	nil,                              // 27: edgeproto.ModifyDataset.VectorsEntry
+	nil,                              // 28: edgeproto.SearchReq.FilterEntry
+	nil,                              // 29: edgeproto.RecommendReq.FilterEntry
+	nil,                              // 30: edgeproto.Candidates.FieldScoresEntry
+	(*structpb.Struct)(nil),          // 31: google.protobuf.Struct
+	(*emptypb.Empty)(nil),            // 32: google.protobuf.Empty
 }
 var file_idl_proto_v2_edge_proto_depIdxs = []int32{
-	0,  // 0: edgeproto.Collection.distance:type_name -> edgeproto.Distance
-	1,  // 1: edgeproto.Collection.quantization:type_name -> edgeproto.Quantization
-	3,  // 2: edgeproto.CollectionResponse.collection:type_name -> edgeproto.Collection
-	11, // 3: edgeproto.CollectionResponse.error:type_name -> edgeproto.Error
-	3,  // 4: edgeproto.CollectionDetail.collection:type_name -> edgeproto.Collection
-	11, // 5: edgeproto.CollectionDetail.error:type_name -> edgeproto.Error
-	11, // 6: edgeproto.DeleteCollectionResponse.error:type_name -> edgeproto.Error
-	16, // 7: edgeproto.ModifyDataset.metadata:type_name -> google.protobuf.Struct
-	11, // 8: edgeproto.Response.error:type_name -> edgeproto.Error
-	2,  // 9: edgeproto.Error.error_code:type_name -> edgeproto.ErrorCode
-	15, // 10: edgeproto.SearchReq.filter:type_name -> edgeproto.SearchReq.FilterEntry
-	11, // 11: edgeproto.SearchResponse.error:type_name -> edgeproto.Error
-	14, // 12: edgeproto.SearchResponse.candidates:type_name -> edgeproto.Candidates
-	16, // 13: edgeproto.Candidates.metadata:type_name -> google.protobuf.Struct
-	17, // 14: edgeproto.EdgeRpc.Ping:input_type -> google.protobuf.Empty
-	3,  // 15: edgeproto.EdgeRpc.CreateCollection:input_type -> edgeproto.Collection
-	8,  // 16: edgeproto.EdgeRpc.DeleteCollection:input_type -> edgeproto.CollectionName
-	8,  // 17: edgeproto.EdgeRpc.GetCollection:input_type -> edgeproto.CollectionName
-	8,  // 18: edgeproto.EdgeRpc.LoadCollection:input_type -> edgeproto.CollectionName
-	8,  // 19: edgeproto.EdgeRpc.ReleaseCollection:input_type -> edgeproto.CollectionName
-	8,  // 20: edgeproto.EdgeRpc.Flush:input_type -> edgeproto.CollectionName
-	7,  // 21: edgeproto.EdgeRpc.Insert:input_type -> edgeproto.ModifyDataset
-	7,  // 22: edgeproto.EdgeRpc.Update:input_type -> edgeproto.ModifyDataset
-	9,  // 23: edgeproto.EdgeRpc.Delete:input_type -> edgeproto.DeleteDataset
-	12, // 24: edgeproto.EdgeRpc.VectorSearch:input_type -> edgeproto.SearchReq
-	12, // 25: edgeproto.EdgeRpc.FilterSearch:input_type -> edgeproto.SearchReq
-	12, // 26: edgeproto.EdgeRpc.HybridSearch:input_type -> edgeproto.SearchReq
-	17, // 27: edgeproto.EdgeRpc.Ping:output_type -> google.protobuf.Empty
-	4,  // 28: edgeproto.EdgeRpc.CreateCollection:output_type -> edgeproto.CollectionResponse
-	6,  // 29: edgeproto.EdgeRpc.DeleteCollection:output_type -> edgeproto.DeleteCollectionResponse
-	5,  // 30: edgeproto.EdgeRpc.GetCollection:output_type -> edgeproto.CollectionDetail
-	5,  // 31: edgeproto.EdgeRpc.LoadCollection:output_type -> edgeproto.CollectionDetail
-	10, // 32: edgeproto.EdgeRpc.ReleaseCollection:output_type -> edgeproto.Response
-	10, // 33: edgeproto.EdgeRpc.Flush:output_type -> edgeproto.Response
-	10, // 34: edgeproto.EdgeRpc.Insert:output_type -> edgeproto.Response
-	10, // 35: edgeproto.EdgeRpc.Update:output_type -> edgeproto.Response
-	10, // 36: edgeproto.EdgeRpc.Delete:output_type -> edgeproto.Response
-	13, // 37: edgeproto.EdgeRpc.VectorSearch:output_type -> edgeproto.SearchResponse
-	13, // 38: edgeproto.EdgeRpc.FilterSearch:output_type -> edgeproto.SearchResponse
-	13, // 39: edgeproto.EdgeRpc.HybridSearch:output_type -> edgeproto.SearchResponse
-	27, // [27:40] is the sub-list for method output_type
-	14, // [14:27] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	1,  // 0: edgeproto.Collection.distance:type_name -> edgeproto.Distance
+	2,  // 1: edgeproto.Collection.quantization:type_name -> edgeproto.Quantization
+	0,  // 2: edgeproto.Collection.index_type:type_name -> edgeproto.IndexType
+	7,  // 3: edgeproto.Collection.hnsw_params:type_name -> edgeproto.HnswParams
+	6,  // 4: edgeproto.Collection.vector_fields:type_name -> edgeproto.VectorField
+	1,  // 5: edgeproto.VectorField.distance:type_name -> edgeproto.Distance
+	5,  // 6: edgeproto.CollectionResponse.collection:type_name -> edgeproto.Collection
+	16, // 7: edgeproto.CollectionResponse.error:type_name -> edgeproto.Error
+	5,  // 8: edgeproto.CollectionDetail.collection:type_name -> edgeproto.Collection
+	16, // 9: edgeproto.CollectionDetail.error:type_name -> edgeproto.Error
+	16, // 10: edgeproto.DeleteCollectionResponse.error:type_name -> edgeproto.Error
+	31, // 11: edgeproto.ModifyDataset.metadata:type_name -> google.protobuf.Struct
+	27, // 12: edgeproto.ModifyDataset.vectors:type_name -> edgeproto.ModifyDataset.VectorsEntry
+	16, // 13: edgeproto.Response.error:type_name -> edgeproto.Error
+	3,  // 14: edgeproto.Error.error_code:type_name -> edgeproto.ErrorCode
+	28, // 15: edgeproto.SearchReq.filter:type_name -> edgeproto.SearchReq.FilterEntry
+	23, // 16: edgeproto.SearchReq.geo_filter:type_name -> edgeproto.GeoFilter
+	20, // 17: edgeproto.SearchReq.field_queries:type_name -> edgeproto.FieldQuery
+	19, // 18: edgeproto.SearchReq.diversify:type_name -> edgeproto.Diversify
+	12, // 19: edgeproto.RecommendReq.positive_vectors:type_name -> edgeproto.FieldVector
+	12, // 20: edgeproto.RecommendReq.negative_vectors:type_name -> edgeproto.FieldVector
+	4,  // 21: edgeproto.RecommendReq.strategy:type_name -> edgeproto.RecommendStrategy
+	29, // 22: edgeproto.RecommendReq.filter:type_name -> edgeproto.RecommendReq.FilterEntry
+	21, // 23: edgeproto.GeoBoundingBox.top_left:type_name -> edgeproto.GeoPoint
+	21, // 24: edgeproto.GeoBoundingBox.bottom_right:type_name -> edgeproto.GeoPoint
+	21, // 25: edgeproto.GeoFilter.center:type_name -> edgeproto.GeoPoint
+	22, // 26: edgeproto.GeoFilter.bounding_box:type_name -> edgeproto.GeoBoundingBox
+	16, // 27: edgeproto.SearchResponse.error:type_name -> edgeproto.Error
+	26, // 28: edgeproto.SearchResponse.candidates:type_name -> edgeproto.Candidates
+	25, // 29: edgeproto.SearchResponse.groups:type_name -> edgeproto.Group
+	26, // 30: edgeproto.Group.candidates:type_name -> edgeproto.Candidates
+	31, // 31: edgeproto.Candidates.metadata:type_name -> google.protobuf.Struct
+	30, // 32: edgeproto.Candidates.field_scores:type_name -> edgeproto.Candidates.FieldScoresEntry
+	12, // 33: edgeproto.ModifyDataset.VectorsEntry.value:type_name -> edgeproto.FieldVector
+	32, // 34: edgeproto.EdgeRpc.Ping:input_type -> google.protobuf.Empty
+	5,  // 35: edgeproto.EdgeRpc.CreateCollection:input_type -> edgeproto.Collection
+	13, // 36: edgeproto.EdgeRpc.DeleteCollection:input_type -> edgeproto.CollectionName
+	13, // 37: edgeproto.EdgeRpc.GetCollection:input_type -> edgeproto.CollectionName
+	13, // 38: edgeproto.EdgeRpc.LoadCollection:input_type -> edgeproto.CollectionName
+	13, // 39: edgeproto.EdgeRpc.ReleaseCollection:input_type -> edgeproto.CollectionName
+	13, // 40: edgeproto.EdgeRpc.Flush:input_type -> edgeproto.CollectionName
+	11, // 41: edgeproto.EdgeRpc.Insert:input_type -> edgeproto.ModifyDataset
+	11, // 42: edgeproto.EdgeRpc.Update:input_type -> edgeproto.ModifyDataset
+	14, // 43: edgeproto.EdgeRpc.Delete:input_type -> edgeproto.DeleteDataset
+	17, // 44: edgeproto.EdgeRpc.VectorSearch:input_type -> edgeproto.SearchReq
+	17, // 45: edgeproto.EdgeRpc.FilterSearch:input_type -> edgeproto.SearchReq
+	17, // 46: edgeproto.EdgeRpc.HybridSearch:input_type -> edgeproto.SearchReq
+	18, // 47: edgeproto.EdgeRpc.Recommend:input_type -> edgeproto.RecommendReq
+	32, // 48: edgeproto.EdgeRpc.Ping:output_type -> google.protobuf.Empty
+	8,  // 49: edgeproto.EdgeRpc.CreateCollection:output_type -> edgeproto.CollectionResponse
+	10, // 50: edgeproto.EdgeRpc.DeleteCollection:output_type -> edgeproto.DeleteCollectionResponse
+	9,  // 51: edgeproto.EdgeRpc.GetCollection:output_type -> edgeproto.CollectionDetail
+	9,  // 52: edgeproto.EdgeRpc.LoadCollection:output_type -> edgeproto.CollectionDetail
+	15, // 53: edgeproto.EdgeRpc.ReleaseCollection:output_type -> edgeproto.Response
+	15, // 54: edgeproto.EdgeRpc.Flush:output_type -> edgeproto.Response
+	15, // 55: edgeproto.EdgeRpc.Insert:output_type -> edgeproto.Response
+	15, // 56: edgeproto.EdgeRpc.Update:output_type -> edgeproto.Response
+	15, // 57: edgeproto.EdgeRpc.Delete:output_type -> edgeproto.Response
+	24, // 58: edgeproto.EdgeRpc.VectorSearch:output_type -> edgeproto.SearchResponse
+	24, // 59: edgeproto.EdgeRpc.FilterSearch:output_type -> edgeproto.SearchResponse
+	24, // 60: edgeproto.EdgeRpc.HybridSearch:output_type -> edgeproto.SearchResponse
+	24, // 61: edgeproto.EdgeRpc.Recommend:output_type -> edgeproto.SearchResponse
+	48, // [48:62] is the sub-list for method output_type
+	34, // [34:48] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_idl_proto_v2_edge_proto_init() }
@@ -1200,13 +2354,15 @@ func file_idl_proto_v2_edge_proto_init() {
 	if File_idl_proto_v2_edge_proto != nil {
 		return
 	}
+	file_idl_proto_v2_edge_proto_msgTypes[12].OneofWrappers = []any{}
+	file_idl_proto_v2_edge_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_proto_v2_edge_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   13,
+			NumEnums:      5,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
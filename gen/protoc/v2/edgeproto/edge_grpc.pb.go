@@ -33,6 +33,7 @@ const (
 	EdgeRpc_VectorSearch_FullMethodName      = "/edgeproto.EdgeRpc/VectorSearch"
 	EdgeRpc_FilterSearch_FullMethodName      = "/edgeproto.EdgeRpc/FilterSearch"
 	EdgeRpc_HybridSearch_FullMethodName      = "/edgeproto.EdgeRpc/HybridSearch"
+	EdgeRpc_Recommend_FullMethodName         = "/edgeproto.EdgeRpc/Recommend"
 )
 
 // EdgeRpcClient is the client API for EdgeRpc service.
@@ -52,6 +53,7 @@ type EdgeRpcClient interface {
 	VectorSearch(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchResponse, error)
 	FilterSearch(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchResponse, error)
 	HybridSearch(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchResponse, error)
+	Recommend(ctx context.Context, in *RecommendReq, opts ...grpc.CallOption) (*SearchResponse, error)
 }
 
 type edgeRpcClient struct {
@@ -192,6 +194,16 @@ func (c *edgeRpcClient) HybridSearch(ctx context.Context, in *SearchReq, opts ..
 	return out, nil
 }
 
+func (c *edgeRpcClient) Recommend(ctx context.Context, in *RecommendReq, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, EdgeRpc_Recommend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EdgeRpcServer is the server API for EdgeRpc service.
 // All implementations should embed UnimplementedEdgeRpcServer
 // for forward compatibility.
@@ -209,6 +221,7 @@ type EdgeRpcServer interface {
 	VectorSearch(context.Context, *SearchReq) (*SearchResponse, error)
 	FilterSearch(context.Context, *SearchReq) (*SearchResponse, error)
 	HybridSearch(context.Context, *SearchReq) (*SearchResponse, error)
+	Recommend(context.Context, *RecommendReq) (*SearchResponse, error)
 }
 
 // UnimplementedEdgeRpcServer should be embedded to have
@@ -257,6 +270,9 @@ func (UnimplementedEdgeRpcServer) FilterSearch(context.Context, *SearchReq) (*Se
 func (UnimplementedEdgeRpcServer) HybridSearch(context.Context, *SearchReq) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HybridSearch not implemented")
 }
+func (UnimplementedEdgeRpcServer) Recommend(context.Context, *RecommendReq) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recommend not implemented")
+}
 func (UnimplementedEdgeRpcServer) testEmbeddedByValue() {}
 
 // UnsafeEdgeRpcServer may be embedded to opt out of forward compatibility for this service.
@@ -511,6 +527,24 @@ func _EdgeRpc_HybridSearch_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EdgeRpc_Recommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EdgeRpcServer).Recommend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EdgeRpc_Recommend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EdgeRpcServer).Recommend(ctx, req.(*RecommendReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EdgeRpc_ServiceDesc is the grpc.ServiceDesc for EdgeRpc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -570,6 +604,10 @@ var EdgeRpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HybridSearch",
 			Handler:    _EdgeRpc_HybridSearch_Handler,
 		},
+		{
+			MethodName: "Recommend",
+			Handler:    _EdgeRpc_Recommend_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "idl/proto/v2/edge.proto",
@@ -22,6 +22,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type RebuildState int32
+
+const (
+	RebuildState_Idle    RebuildState = 0
+	RebuildState_Running RebuildState = 1
+	RebuildState_Swapped RebuildState = 2
+	RebuildState_Failed  RebuildState = 3
+)
+
+// Enum value maps for RebuildState.
+var (
+	RebuildState_name = map[int32]string{
+		0: "Idle",
+		1: "Running",
+		2: "Swapped",
+		3: "Failed",
+	}
+	RebuildState_value = map[string]int32{
+		"Idle":    0,
+		"Running": 1,
+		"Swapped": 2,
+		"Failed":  3,
+	}
+)
+
+func (x RebuildState) Enum() *RebuildState {
+	p := new(RebuildState)
+	*p = x
+	return p
+}
+
+func (x RebuildState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RebuildState) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[0].Descriptor()
+}
+
+func (RebuildState) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[0]
+}
+
+func (x RebuildState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RebuildState.Descriptor instead.
+func (RebuildState) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{0}
+}
+
 type SearchAlgorithm int32
 
 const (
@@ -52,11 +104,11 @@ func (x SearchAlgorithm) String() string {
 }
 
 func (SearchAlgorithm) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[0].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[1].Descriptor()
 }
 
 func (SearchAlgorithm) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[0]
+	return &file_idl_proto_v3_core_proto_enumTypes[1]
 }
 
 func (x SearchAlgorithm) Number() protoreflect.EnumNumber {
@@ -65,14 +117,17 @@ func (x SearchAlgorithm) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SearchAlgorithm.Descriptor instead.
 func (SearchAlgorithm) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{0}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{1}
 }
 
 type Distance int32
 
 const (
-	Distance_Cosine    Distance = 0
-	Distance_Euclidean Distance = 1
+	Distance_Cosine       Distance = 0
+	Distance_Euclidean    Distance = 1
+	Distance_InnerProduct Distance = 2
+	Distance_Manhattan    Distance = 3
+	Distance_Hamming      Distance = 4
 )
 
 // Enum value maps for Distance.
@@ -80,10 +135,16 @@ var (
 	Distance_name = map[int32]string{
 		0: "Cosine",
 		1: "Euclidean",
+		2: "InnerProduct",
+		3: "Manhattan",
+		4: "Hamming",
 	}
 	Distance_value = map[string]int32{
-		"Cosine":    0,
-		"Euclidean": 1,
+		"Cosine":       0,
+		"Euclidean":    1,
+		"InnerProduct": 2,
+		"Manhattan":    3,
+		"Hamming":      4,
 	}
 )
 
@@ -98,11 +159,11 @@ func (x Distance) String() string {
 }
 
 func (Distance) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[1].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[2].Descriptor()
 }
 
 func (Distance) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[1]
+	return &file_idl_proto_v3_core_proto_enumTypes[2]
 }
 
 func (x Distance) Number() protoreflect.EnumNumber {
@@ -111,7 +172,111 @@ func (x Distance) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Distance.Descriptor instead.
 func (Distance) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{1}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{2}
+}
+
+type IndexType int32
+
+const (
+	IndexType_Hnsw   IndexType = 0
+	IndexType_Vamana IndexType = 1
+	IndexType_Ivf    IndexType = 2
+	IndexType_HnswPq IndexType = 3
+	IndexType_Flat   IndexType = 4
+)
+
+// Enum value maps for IndexType.
+var (
+	IndexType_name = map[int32]string{
+		0: "Hnsw",
+		1: "Vamana",
+		2: "Ivf",
+		3: "HnswPq",
+		4: "Flat",
+	}
+	IndexType_value = map[string]int32{
+		"Hnsw":   0,
+		"Vamana": 1,
+		"Ivf":    2,
+		"HnswPq": 3,
+		"Flat":   4,
+	}
+)
+
+func (x IndexType) Enum() *IndexType {
+	p := new(IndexType)
+	*p = x
+	return p
+}
+
+func (x IndexType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexType) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[3].Descriptor()
+}
+
+func (IndexType) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[3]
+}
+
+func (x IndexType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexType.Descriptor instead.
+func (IndexType) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+}
+
+type Analyzer int32
+
+const (
+	Analyzer_Standard   Analyzer = 0 // splits on anything but letters and digits, lowercased
+	Analyzer_Whitespace Analyzer = 1 // splits on whitespace, kept as it is
+	Analyzer_English    Analyzer = 2 // standard without english stopwords
+)
+
+// Enum value maps for Analyzer.
+var (
+	Analyzer_name = map[int32]string{
+		0: "Standard",
+		1: "Whitespace",
+		2: "English",
+	}
+	Analyzer_value = map[string]int32{
+		"Standard":   0,
+		"Whitespace": 1,
+		"English":    2,
+	}
+)
+
+func (x Analyzer) Enum() *Analyzer {
+	p := new(Analyzer)
+	*p = x
+	return p
+}
+
+func (x Analyzer) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Analyzer) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[4].Descriptor()
+}
+
+func (Analyzer) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[4]
+}
+
+func (x Analyzer) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Analyzer.Descriptor instead.
+func (Analyzer) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
 }
 
 type Quantization int32
@@ -156,11 +321,11 @@ func (x Quantization) String() string {
 }
 
 func (Quantization) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[2].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[5].Descriptor()
 }
 
 func (Quantization) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[2]
+	return &file_idl_proto_v3_core_proto_enumTypes[5]
 }
 
 func (x Quantization) Number() protoreflect.EnumNumber {
@@ -169,7 +334,7 @@ func (x Quantization) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Quantization.Descriptor instead.
 func (Quantization) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{2}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{5}
 }
 
 type ErrorCode int32
@@ -214,11 +379,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[3].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[6].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[3]
+	return &file_idl_proto_v3_core_proto_enumTypes[6]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -227,7 +392,7 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{6}
 }
 
 type IndexChangeTypes int32
@@ -263,11 +428,11 @@ func (x IndexChangeTypes) String() string {
 }
 
 func (IndexChangeTypes) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[4].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[7].Descriptor()
 }
 
 func (IndexChangeTypes) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[4]
+	return &file_idl_proto_v3_core_proto_enumTypes[7]
 }
 
 func (x IndexChangeTypes) Number() protoreflect.EnumNumber {
@@ -276,7 +441,102 @@ func (x IndexChangeTypes) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IndexChangeTypes.Descriptor instead.
 func (IndexChangeTypes) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{7}
+}
+
+type RecommendStrategy int32
+
+const (
+	// one search with 2 * mean(positive) - mean(negative), or mean(positive)
+	RecommendStrategy_AverageVector RecommendStrategy = 0
+	// one search per positive example, a result scores its best positive match,
+	// or minus its best negative match when a negative example is closer
+	RecommendStrategy_BestScore RecommendStrategy = 1
+)
+
+// Enum value maps for RecommendStrategy.
+var (
+	RecommendStrategy_name = map[int32]string{
+		0: "AverageVector",
+		1: "BestScore",
+	}
+	RecommendStrategy_value = map[string]int32{
+		"AverageVector": 0,
+		"BestScore":     1,
+	}
+)
+
+func (x RecommendStrategy) Enum() *RecommendStrategy {
+	p := new(RecommendStrategy)
+	*p = x
+	return p
+}
+
+func (x RecommendStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecommendStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[8].Descriptor()
+}
+
+func (RecommendStrategy) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[8]
+}
+
+func (x RecommendStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecommendStrategy.Descriptor instead.
+func (RecommendStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{8}
+}
+
+type Fusion int32
+
+const (
+	Fusion_WeightedSum Fusion = 0 // weighted mean of the 0-100 scores, sparse scores are scaled to the best match
+	Fusion_Rrf         Fusion = 1 // reciprocal rank fusion, sum of weight / (rrf_k + rank)
+)
+
+// Enum value maps for Fusion.
+var (
+	Fusion_name = map[int32]string{
+		0: "WeightedSum",
+		1: "Rrf",
+	}
+	Fusion_value = map[string]int32{
+		"WeightedSum": 0,
+		"Rrf":         1,
+	}
+)
+
+func (x Fusion) Enum() *Fusion {
+	p := new(Fusion)
+	*p = x
+	return p
+}
+
+func (x Fusion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Fusion) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[9].Descriptor()
+}
+
+func (Fusion) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[9]
+}
+
+func (x Fusion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Fusion.Descriptor instead.
+func (Fusion) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{9}
 }
 
 type CompXyDist struct {
@@ -390,11 +650,13 @@ type DatasetChange struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CollectionName   string           `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Vector           []float32        `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
-	Metadata         *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	IndexChangeTypes IndexChangeTypes `protobuf:"varint,5,opt,name=index_change_types,json=indexChangeTypes,proto3,enum=coreproto.IndexChangeTypes" json:"index_change_types,omitempty"`
+	Id               string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollectionName   string                  `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Vector           []float32               `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Metadata         *structpb.Struct        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	IndexChangeTypes IndexChangeTypes        `protobuf:"varint,5,opt,name=index_change_types,json=indexChangeTypes,proto3,enum=coreproto.IndexChangeTypes" json:"index_change_types,omitempty"`
+	Vectors          map[string]*FieldVector `protobuf:"bytes,6,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // vector of each field of a collection with vector_fields
+	SparseVector     *SparseVector           `protobuf:"bytes,7,opt,name=sparse_vector,json=sparseVector,proto3" json:"sparse_vector,omitempty"`
 }
 
 func (x *DatasetChange) Reset() {
@@ -462,6 +724,119 @@ func (x *DatasetChange) GetIndexChangeTypes() IndexChangeTypes {
 	return IndexChangeTypes_INSERT
 }
 
+func (x *DatasetChange) GetVectors() map[string]*FieldVector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+func (x *DatasetChange) GetSparseVector() *SparseVector {
+	if x != nil {
+		return x.SparseVector
+	}
+	return nil
+}
+
+// value of each dimension in indices, values must not be negative
+type SparseVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Indices []uint32  `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	Values  []float32 `protobuf:"fixed32,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SparseVector) Reset() {
+	*x = SparseVector{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SparseVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SparseVector) ProtoMessage() {}
+
+func (x *SparseVector) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SparseVector.ProtoReflect.Descriptor instead.
+func (*SparseVector) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SparseVector) GetIndices() []uint32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+func (x *SparseVector) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type FieldVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vector []float32 `protobuf:"fixed32,1,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+}
+
+func (x *FieldVector) Reset() {
+	*x = FieldVector{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldVector) ProtoMessage() {}
+
+func (x *FieldVector) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldVector.ProtoReflect.Descriptor instead.
+func (*FieldVector) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FieldVector) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
 type CollectionName struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -473,7 +848,7 @@ type CollectionName struct {
 
 func (x *CollectionName) Reset() {
 	*x = CollectionName{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -485,7 +860,7 @@ func (x *CollectionName) String() string {
 func (*CollectionName) ProtoMessage() {}
 
 func (x *CollectionName) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -498,7 +873,7 @@ func (x *CollectionName) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionName.ProtoReflect.Descriptor instead.
 func (*CollectionName) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CollectionName) GetCollectionName() string {
@@ -527,7 +902,7 @@ type CollectionResponse struct {
 
 func (x *CollectionResponse) Reset() {
 	*x = CollectionResponse{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -539,7 +914,7 @@ func (x *CollectionResponse) String() string {
 func (*CollectionResponse) ProtoMessage() {}
 
 func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +927,7 @@ func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionResponse.ProtoReflect.Descriptor instead.
 func (*CollectionResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CollectionResponse) GetStatus() bool {
@@ -581,16 +956,27 @@ type CollectionSpec struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName    string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	CollectionConfig  *HnswConfig  `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
-	VectorDimension   uint32       `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
-	Distance          Distance     `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
-	CompressionHelper Quantization `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	CollectionName    string        `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	CollectionConfig  *HnswConfig   `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
+	VectorDimension   uint32        `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance          Distance      `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
+	CompressionHelper Quantization  `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	IndexType         IndexType     `protobuf:"varint,6,opt,name=index_type,json=indexType,proto3,enum=coreproto.IndexType" json:"index_type,omitempty"`
+	VamanaConfig      *VamanaConfig `protobuf:"bytes,7,opt,name=vamana_config,json=vamanaConfig,proto3" json:"vamana_config,omitempty"`
+	IvfConfig         *IvfConfig    `protobuf:"bytes,8,opt,name=ivf_config,json=ivfConfig,proto3" json:"ivf_config,omitempty"`
+	HnswPqConfig      *HnswPqConfig `protobuf:"bytes,9,opt,name=hnsw_pq_config,json=hnswPqConfig,proto3" json:"hnsw_pq_config,omitempty"`
+	FlatConfig        *FlatConfig   `protobuf:"bytes,10,opt,name=flat_config,json=flatConfig,proto3" json:"flat_config,omitempty"`
+	MaxQueryTimeMs    uint32        `protobuf:"varint,11,opt,name=max_query_time_ms,json=maxQueryTimeMs,proto3" json:"max_query_time_ms,omitempty"` // searches past it fail with DEADLINE_EXCEEDED, 0 is no limit
+	// hnsw collections only, every field gets its own graph over the same records
+	// and vector_dimension is the sum of the field dimensions
+	VectorFields []*VectorField `protobuf:"bytes,12,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"`
+	// metadata fields analyzed for full text search, they keep their exact match filter
+	TextFields []*TextField `protobuf:"bytes,13,rep,name=text_fields,json=textFields,proto3" json:"text_fields,omitempty"`
 }
 
 func (x *CollectionSpec) Reset() {
 	*x = CollectionSpec{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -602,7 +988,7 @@ func (x *CollectionSpec) String() string {
 func (*CollectionSpec) ProtoMessage() {}
 
 func (x *CollectionSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +1001,7 @@ func (x *CollectionSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionSpec.ProtoReflect.Descriptor instead.
 func (*CollectionSpec) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{5}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CollectionSpec) GetCollectionName() string {
@@ -653,6 +1039,176 @@ func (x *CollectionSpec) GetCompressionHelper() Quantization {
 	return Quantization_None
 }
 
+func (x *CollectionSpec) GetIndexType() IndexType {
+	if x != nil {
+		return x.IndexType
+	}
+	return IndexType_Hnsw
+}
+
+func (x *CollectionSpec) GetVamanaConfig() *VamanaConfig {
+	if x != nil {
+		return x.VamanaConfig
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetIvfConfig() *IvfConfig {
+	if x != nil {
+		return x.IvfConfig
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetHnswPqConfig() *HnswPqConfig {
+	if x != nil {
+		return x.HnswPqConfig
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetFlatConfig() *FlatConfig {
+	if x != nil {
+		return x.FlatConfig
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetMaxQueryTimeMs() uint32 {
+	if x != nil {
+		return x.MaxQueryTimeMs
+	}
+	return 0
+}
+
+func (x *CollectionSpec) GetVectorFields() []*VectorField {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetTextFields() []*TextField {
+	if x != nil {
+		return x.TextFields
+	}
+	return nil
+}
+
+type VectorField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dim      uint32   `protobuf:"varint,2,opt,name=dim,proto3" json:"dim,omitempty"`
+	Distance Distance `protobuf:"varint,3,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
+}
+
+func (x *VectorField) Reset() {
+	*x = VectorField{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorField) ProtoMessage() {}
+
+func (x *VectorField) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorField.ProtoReflect.Descriptor instead.
+func (*VectorField) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VectorField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VectorField) GetDim() uint32 {
+	if x != nil {
+		return x.Dim
+	}
+	return 0
+}
+
+func (x *VectorField) GetDistance() Distance {
+	if x != nil {
+		return x.Distance
+	}
+	return Distance_Cosine
+}
+
+type TextField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Analyzer Analyzer `protobuf:"varint,2,opt,name=analyzer,proto3,enum=coreproto.Analyzer" json:"analyzer,omitempty"`
+}
+
+func (x *TextField) Reset() {
+	*x = TextField{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextField) ProtoMessage() {}
+
+func (x *TextField) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextField.ProtoReflect.Descriptor instead.
+func (*TextField) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TextField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TextField) GetAnalyzer() Analyzer {
+	if x != nil {
+		return x.Analyzer
+	}
+	return Analyzer_Standard
+}
+
 type HnswConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -667,11 +1223,13 @@ type HnswConfig struct {
 	MMax0                     int32           `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
 	HeuristicExtendCandidates bool            `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
 	HeuristicKeepPruned       bool            `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
+	// levels are drawn from the seed, the same inserts build the same graph
+	Seed *int64 `protobuf:"varint,10,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
 }
 
 func (x *HnswConfig) Reset() {
 	*x = HnswConfig{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -683,7 +1241,7 @@ func (x *HnswConfig) String() string {
 func (*HnswConfig) ProtoMessage() {}
 
 func (x *HnswConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,7 +1254,7 @@ func (x *HnswConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HnswConfig.ProtoReflect.Descriptor instead.
 func (*HnswConfig) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{6}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *HnswConfig) GetSearchAlgorithm() SearchAlgorithm {
@@ -734,59 +1292,1259 @@ func (x *HnswConfig) GetM() int32 {
 	return 0
 }
 
-func (x *HnswConfig) GetMMax() int32 {
-	if x != nil {
-		return x.MMax
-	}
-	return 0
+func (x *HnswConfig) GetMMax() int32 {
+	if x != nil {
+		return x.MMax
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetMMax0() int32 {
+	if x != nil {
+		return x.MMax0
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetHeuristicExtendCandidates() bool {
+	if x != nil {
+		return x.HeuristicExtendCandidates
+	}
+	return false
+}
+
+func (x *HnswConfig) GetHeuristicKeepPruned() bool {
+	if x != nil {
+		return x.HeuristicKeepPruned
+	}
+	return false
+}
+
+func (x *HnswConfig) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+// disk resident graph, full vectors stay on disk and pq codes in memory
+type VamanaConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxDegree      uint32  `protobuf:"varint,1,opt,name=max_degree,json=maxDegree,proto3" json:"max_degree,omitempty"`
+	BuildListSize  uint32  `protobuf:"varint,2,opt,name=build_list_size,json=buildListSize,proto3" json:"build_list_size,omitempty"`
+	SearchListSize uint32  `protobuf:"varint,3,opt,name=search_list_size,json=searchListSize,proto3" json:"search_list_size,omitempty"`
+	Alpha          float32 `protobuf:"fixed32,4,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	BeamWidth      uint32  `protobuf:"varint,5,opt,name=beam_width,json=beamWidth,proto3" json:"beam_width,omitempty"`
+	PqSubvectors   uint32  `protobuf:"varint,6,opt,name=pq_subvectors,json=pqSubvectors,proto3" json:"pq_subvectors,omitempty"`
+}
+
+func (x *VamanaConfig) Reset() {
+	*x = VamanaConfig{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VamanaConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VamanaConfig) ProtoMessage() {}
+
+func (x *VamanaConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VamanaConfig.ProtoReflect.Descriptor instead.
+func (*VamanaConfig) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VamanaConfig) GetMaxDegree() uint32 {
+	if x != nil {
+		return x.MaxDegree
+	}
+	return 0
+}
+
+func (x *VamanaConfig) GetBuildListSize() uint32 {
+	if x != nil {
+		return x.BuildListSize
+	}
+	return 0
+}
+
+func (x *VamanaConfig) GetSearchListSize() uint32 {
+	if x != nil {
+		return x.SearchListSize
+	}
+	return 0
+}
+
+func (x *VamanaConfig) GetAlpha() float32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *VamanaConfig) GetBeamWidth() uint32 {
+	if x != nil {
+		return x.BeamWidth
+	}
+	return 0
+}
+
+func (x *VamanaConfig) GetPqSubvectors() uint32 {
+	if x != nil {
+		return x.PqSubvectors
+	}
+	return 0
+}
+
+// inverted file, searches scan the nprobe lists closest to the query
+type IvfConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nlist     uint32 `protobuf:"varint,1,opt,name=nlist,proto3" json:"nlist,omitempty"`
+	Nprobe    uint32 `protobuf:"varint,2,opt,name=nprobe,proto3" json:"nprobe,omitempty"`
+	TrainSize uint32 `protobuf:"varint,3,opt,name=train_size,json=trainSize,proto3" json:"train_size,omitempty"`
+}
+
+func (x *IvfConfig) Reset() {
+	*x = IvfConfig{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IvfConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IvfConfig) ProtoMessage() {}
+
+func (x *IvfConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IvfConfig.ProtoReflect.Descriptor instead.
+func (*IvfConfig) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IvfConfig) GetNlist() uint32 {
+	if x != nil {
+		return x.Nlist
+	}
+	return 0
+}
+
+func (x *IvfConfig) GetNprobe() uint32 {
+	if x != nil {
+		return x.Nprobe
+	}
+	return 0
+}
+
+func (x *IvfConfig) GetTrainSize() uint32 {
+	if x != nil {
+		return x.TrainSize
+	}
+	return 0
+}
+
+// hnsw graph walked on product quantized codes, results are reranked on the full vectors
+type HnswPqConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	M                 uint32 `protobuf:"varint,1,opt,name=m,proto3" json:"m,omitempty"`
+	EfConstruction    uint32 `protobuf:"varint,2,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
+	Ef                uint32 `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
+	Centroids         uint32 `protobuf:"varint,4,opt,name=centroids,proto3" json:"centroids,omitempty"`
+	Subvectors        uint32 `protobuf:"varint,5,opt,name=subvectors,proto3" json:"subvectors,omitempty"`
+	TrainSize         uint32 `protobuf:"varint,6,opt,name=train_size,json=trainSize,proto3" json:"train_size,omitempty"`
+	OptimizedRotation bool   `protobuf:"varint,7,opt,name=optimized_rotation,json=optimizedRotation,proto3" json:"optimized_rotation,omitempty"`
+}
+
+func (x *HnswPqConfig) Reset() {
+	*x = HnswPqConfig{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HnswPqConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HnswPqConfig) ProtoMessage() {}
+
+func (x *HnswPqConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HnswPqConfig.ProtoReflect.Descriptor instead.
+func (*HnswPqConfig) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HnswPqConfig) GetM() uint32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *HnswPqConfig) GetEfConstruction() uint32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *HnswPqConfig) GetEf() uint32 {
+	if x != nil {
+		return x.Ef
+	}
+	return 0
+}
+
+func (x *HnswPqConfig) GetCentroids() uint32 {
+	if x != nil {
+		return x.Centroids
+	}
+	return 0
+}
+
+func (x *HnswPqConfig) GetSubvectors() uint32 {
+	if x != nil {
+		return x.Subvectors
+	}
+	return 0
+}
+
+func (x *HnswPqConfig) GetTrainSize() uint32 {
+	if x != nil {
+		return x.TrainSize
+	}
+	return 0
+}
+
+func (x *HnswPqConfig) GetOptimizedRotation() bool {
+	if x != nil {
+		return x.OptimizedRotation
+	}
+	return false
+}
+
+// exact scan over sharded vectors, compression_helper None, F16 or BF16 lowers the stored vectors
+type FlatConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shards           uint32 `protobuf:"varint,1,opt,name=shards,proto3" json:"shards,omitempty"`
+	PromoteThreshold uint32 `protobuf:"varint,2,opt,name=promote_threshold,json=promoteThreshold,proto3" json:"promote_threshold,omitempty"` // move to an hnsw graph at this size, 0 stays flat
+}
+
+func (x *FlatConfig) Reset() {
+	*x = FlatConfig{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlatConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlatConfig) ProtoMessage() {}
+
+func (x *FlatConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlatConfig.ProtoReflect.Descriptor instead.
+func (*FlatConfig) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FlatConfig) GetShards() uint32 {
+	if x != nil {
+		return x.Shards
+	}
+	return 0
+}
+
+func (x *FlatConfig) GetPromoteThreshold() uint32 {
+	if x != nil {
+		return x.PromoteThreshold
+	}
+	return 0
+}
+
+// only the set fields change, ef applies in place, any other field rebuilds
+// the hnsw graph in the background and swaps it in once built
+type AlterCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName            string           `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	SearchAlgorithm           *SearchAlgorithm `protobuf:"varint,2,opt,name=search_algorithm,json=searchAlgorithm,proto3,enum=coreproto.SearchAlgorithm,oneof" json:"search_algorithm,omitempty"`
+	LevelMultiplier           *float32         `protobuf:"fixed32,3,opt,name=level_multiplier,json=levelMultiplier,proto3,oneof" json:"level_multiplier,omitempty"`
+	Ef                        *int32           `protobuf:"varint,4,opt,name=ef,proto3,oneof" json:"ef,omitempty"`
+	EfConstruction            *int32           `protobuf:"varint,5,opt,name=ef_construction,json=efConstruction,proto3,oneof" json:"ef_construction,omitempty"`
+	M                         *int32           `protobuf:"varint,6,opt,name=m,proto3,oneof" json:"m,omitempty"`
+	MMax                      *int32           `protobuf:"varint,7,opt,name=m_max,json=mMax,proto3,oneof" json:"m_max,omitempty"`
+	MMax0                     *int32           `protobuf:"varint,8,opt,name=m_max0,json=mMax0,proto3,oneof" json:"m_max0,omitempty"`
+	HeuristicExtendCandidates *bool            `protobuf:"varint,9,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3,oneof" json:"heuristic_extend_candidates,omitempty"`
+	HeuristicKeepPruned       *bool            `protobuf:"varint,10,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3,oneof" json:"heuristic_keep_pruned,omitempty"`
+}
+
+func (x *AlterCollectionRequest) Reset() {
+	*x = AlterCollectionRequest{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlterCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlterCollectionRequest) ProtoMessage() {}
+
+func (x *AlterCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlterCollectionRequest.ProtoReflect.Descriptor instead.
+func (*AlterCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AlterCollectionRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *AlterCollectionRequest) GetSearchAlgorithm() SearchAlgorithm {
+	if x != nil && x.SearchAlgorithm != nil {
+		return *x.SearchAlgorithm
+	}
+	return SearchAlgorithm_Simple
+}
+
+func (x *AlterCollectionRequest) GetLevelMultiplier() float32 {
+	if x != nil && x.LevelMultiplier != nil {
+		return *x.LevelMultiplier
+	}
+	return 0
+}
+
+func (x *AlterCollectionRequest) GetEf() int32 {
+	if x != nil && x.Ef != nil {
+		return *x.Ef
+	}
+	return 0
+}
+
+func (x *AlterCollectionRequest) GetEfConstruction() int32 {
+	if x != nil && x.EfConstruction != nil {
+		return *x.EfConstruction
+	}
+	return 0
+}
+
+func (x *AlterCollectionRequest) GetM() int32 {
+	if x != nil && x.M != nil {
+		return *x.M
+	}
+	return 0
+}
+
+func (x *AlterCollectionRequest) GetMMax() int32 {
+	if x != nil && x.MMax != nil {
+		return *x.MMax
+	}
+	return 0
+}
+
+func (x *AlterCollectionRequest) GetMMax0() int32 {
+	if x != nil && x.MMax0 != nil {
+		return *x.MMax0
+	}
+	return 0
+}
+
+func (x *AlterCollectionRequest) GetHeuristicExtendCandidates() bool {
+	if x != nil && x.HeuristicExtendCandidates != nil {
+		return *x.HeuristicExtendCandidates
+	}
+	return false
+}
+
+func (x *AlterCollectionRequest) GetHeuristicKeepPruned() bool {
+	if x != nil && x.HeuristicKeepPruned != nil {
+		return *x.HeuristicKeepPruned
+	}
+	return false
+}
+
+type AlterCollectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status           bool             `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	CollectionConfig *HnswConfig      `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"` // config once the alter is done
+	Rebuild          *RebuildProgress `protobuf:"bytes,3,opt,name=rebuild,proto3" json:"rebuild,omitempty"`                                           // unset when the alter applied in place
+	Error            *Error           `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AlterCollectionResponse) Reset() {
+	*x = AlterCollectionResponse{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlterCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlterCollectionResponse) ProtoMessage() {}
+
+func (x *AlterCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlterCollectionResponse.ProtoReflect.Descriptor instead.
+func (*AlterCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AlterCollectionResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *AlterCollectionResponse) GetCollectionConfig() *HnswConfig {
+	if x != nil {
+		return x.CollectionConfig
+	}
+	return nil
+}
+
+func (x *AlterCollectionResponse) GetRebuild() *RebuildProgress {
+	if x != nil {
+		return x.Rebuild
+	}
+	return nil
+}
+
+func (x *AlterCollectionResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type RebuildProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State        RebuildState `protobuf:"varint,1,opt,name=state,proto3,enum=coreproto.RebuildState" json:"state,omitempty"`
+	Done         uint64       `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	Total        uint64       `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	ErrorMessage string       `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *RebuildProgress) Reset() {
+	*x = RebuildProgress{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildProgress) ProtoMessage() {}
+
+func (x *RebuildProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildProgress.ProtoReflect.Descriptor instead.
+func (*RebuildProgress) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RebuildProgress) GetState() RebuildState {
+	if x != nil {
+		return x.State
+	}
+	return RebuildState_Idle
+}
+
+func (x *RebuildProgress) GetDone() uint64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *RebuildProgress) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RebuildProgress) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ResponseWithMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Error   *Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ResponseWithMessage) Reset() {
+	*x = ResponseWithMessage{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseWithMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseWithMessage) ProtoMessage() {}
+
+func (x *ResponseWithMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseWithMessage.ProtoReflect.Descriptor instead.
+func (*ResponseWithMessage) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ResponseWithMessage) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *ResponseWithMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseWithMessage) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error  *Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Response) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *Response) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorMessage string    `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorCode    ErrorCode `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3,enum=coreproto.ErrorCode" json:"error_code,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Error) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Error) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNDEFINED
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName    string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Vector            []float32         `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	TopK              uint64            `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`
+	MinScoreThreshold float32           `protobuf:"fixed32,4,opt,name=min_score_threshold,json=minScoreThreshold,proto3" json:"min_score_threshold,omitempty"` // drops the results scoring under it
+	Filter            map[string]string `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	WithLatency       bool              `protobuf:"varint,6,opt,name=with_latency,json=withLatency,proto3" json:"with_latency,omitempty"`
+	GeoFilter         *GeoFilter        `protobuf:"bytes,7,opt,name=geo_filter,json=geoFilter,proto3" json:"geo_filter,omitempty"`
+	Nprobe            uint32            `protobuf:"varint,8,opt,name=nprobe,proto3" json:"nprobe,omitempty"` // ivf collections, 0 uses the collection nprobe
+	// searches the named fields of a collection with vector_fields instead of vector,
+	// several fields are fused into one ranking
+	FieldQueries []*FieldQuery `protobuf:"bytes,9,rep,name=field_queries,json=fieldQueries,proto3" json:"field_queries,omitempty"`
+	Fusion       Fusion        `protobuf:"varint,10,opt,name=fusion,proto3,enum=coreproto.Fusion" json:"fusion,omitempty"`
+	RrfK         uint32        `protobuf:"varint,11,opt,name=rrf_k,json=rrfK,proto3" json:"rrf_k,omitempty"` // rrf rank constant, 0 is 60
+	// dot product search on the sparse vectors, with vector or field_queries
+	// set the dense and sparse rankings are fused by fusion
+	SparseVector *SparseVector `protobuf:"bytes,12,opt,name=sparse_vector,json=sparseVector,proto3" json:"sparse_vector,omitempty"`
+	DenseWeight  float32       `protobuf:"fixed32,13,opt,name=dense_weight,json=denseWeight,proto3" json:"dense_weight,omitempty"` // both 0 weighs dense and sparse equally
+	SparseWeight float32       `protobuf:"fixed32,14,opt,name=sparse_weight,json=sparseWeight,proto3" json:"sparse_weight,omitempty"`
+	// HybridSearch only, bm25 keyword search on a text field fused with the
+	// vector ranking by rrf when there is one
+	TextQuery *TextQuery `protobuf:"bytes,15,opt,name=text_query,json=textQuery,proto3" json:"text_query,omitempty"`
+	Diversify *Diversify `protobuf:"bytes,16,opt,name=diversify,proto3" json:"diversify,omitempty"`
+	// VectorSearch only, gives the results in groups by the value of a metadata
+	// field, up to group_size results of each of the best limit groups
+	GroupBy   string `protobuf:"bytes,17,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	GroupSize uint32 `protobuf:"varint,18,opt,name=group_size,json=groupSize,proto3" json:"group_size,omitempty"` // 0 is 1
+	Limit     uint32 `protobuf:"varint,19,opt,name=limit,proto3" json:"limit,omitempty"`                          // 0 is topK
+	// VectorSearch only, gives every record within radius of vector in the
+	// distance of the collection, closest first and up to topK, or up to
+	// 10000 when topK is 0
+	Radius *float32 `protobuf:"fixed32,20,opt,name=radius,proto3,oneof" json:"radius,omitempty"`
+	// VectorSearch and FilterSearch, skips the first offset results, or
+	// continues after the page a next_page_token of the same query ended
+	Offset    uint32 `protobuf:"varint,21,opt,name=offset,proto3" json:"offset,omitempty"`
+	PageToken string `protobuf:"bytes,22,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// metadata of the results keeps only include_fields when set, less
+	// exclude_fields
+	IncludeFields []string `protobuf:"bytes,23,rep,name=include_fields,json=includeFields,proto3" json:"include_fields,omitempty"`
+	ExcludeFields []string `protobuf:"bytes,24,rep,name=exclude_fields,json=excludeFields,proto3" json:"exclude_fields,omitempty"`
+	WithVector    bool     `protobuf:"varint,25,opt,name=with_vector,json=withVector,proto3" json:"with_vector,omitempty"` // gives the vector of each result as it was written
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetTopK() uint64 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetMinScoreThreshold() float32 {
+	if x != nil {
+		return x.MinScoreThreshold
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetFilter() map[string]string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetWithLatency() bool {
+	if x != nil {
+		return x.WithLatency
+	}
+	return false
+}
+
+func (x *SearchRequest) GetGeoFilter() *GeoFilter {
+	if x != nil {
+		return x.GeoFilter
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetNprobe() uint32 {
+	if x != nil {
+		return x.Nprobe
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetFieldQueries() []*FieldQuery {
+	if x != nil {
+		return x.FieldQueries
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetFusion() Fusion {
+	if x != nil {
+		return x.Fusion
+	}
+	return Fusion_WeightedSum
+}
+
+func (x *SearchRequest) GetRrfK() uint32 {
+	if x != nil {
+		return x.RrfK
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetSparseVector() *SparseVector {
+	if x != nil {
+		return x.SparseVector
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetDenseWeight() float32 {
+	if x != nil {
+		return x.DenseWeight
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetSparseWeight() float32 {
+	if x != nil {
+		return x.SparseWeight
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetTextQuery() *TextQuery {
+	if x != nil {
+		return x.TextQuery
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetDiversify() *Diversify {
+	if x != nil {
+		return x.Diversify
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetGroupSize() uint32 {
+	if x != nil {
+		return x.GroupSize
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetRadius() float32 {
+	if x != nil && x.Radius != nil {
+		return *x.Radius
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetIncludeFields() []string {
+	if x != nil {
+		return x.IncludeFields
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExcludeFields() []string {
+	if x != nil {
+		return x.ExcludeFields
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetWithVector() bool {
+	if x != nil {
+		return x.WithVector
+	}
+	return false
+}
+
+// searches records like the positive examples and unlike the negative ones,
+// examples are record ids or raw vectors and the records never come back
+type RecommendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName  string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Positive        []string          `protobuf:"bytes,2,rep,name=positive,proto3" json:"positive,omitempty"`
+	Negative        []string          `protobuf:"bytes,3,rep,name=negative,proto3" json:"negative,omitempty"`
+	PositiveVectors []*FieldVector    `protobuf:"bytes,4,rep,name=positive_vectors,json=positiveVectors,proto3" json:"positive_vectors,omitempty"`
+	NegativeVectors []*FieldVector    `protobuf:"bytes,5,rep,name=negative_vectors,json=negativeVectors,proto3" json:"negative_vectors,omitempty"`
+	Strategy        RecommendStrategy `protobuf:"varint,6,opt,name=strategy,proto3,enum=coreproto.RecommendStrategy" json:"strategy,omitempty"`
+	TopK            uint64            `protobuf:"varint,7,opt,name=topK,proto3" json:"topK,omitempty"`
+	Filter          map[string]string `protobuf:"bytes,8,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RecommendRequest) Reset() {
+	*x = RecommendRequest{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendRequest) ProtoMessage() {}
+
+func (x *RecommendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendRequest.ProtoReflect.Descriptor instead.
+func (*RecommendRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RecommendRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *RecommendRequest) GetPositive() []string {
+	if x != nil {
+		return x.Positive
+	}
+	return nil
+}
+
+func (x *RecommendRequest) GetNegative() []string {
+	if x != nil {
+		return x.Negative
+	}
+	return nil
+}
+
+func (x *RecommendRequest) GetPositiveVectors() []*FieldVector {
+	if x != nil {
+		return x.PositiveVectors
+	}
+	return nil
+}
+
+func (x *RecommendRequest) GetNegativeVectors() []*FieldVector {
+	if x != nil {
+		return x.NegativeVectors
+	}
+	return nil
+}
+
+func (x *RecommendRequest) GetStrategy() RecommendStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return RecommendStrategy_AverageVector
+}
+
+func (x *RecommendRequest) GetTopK() uint64 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *RecommendRequest) GetFilter() map[string]string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// picks the results by maximal marginal relevance out of fetch_k candidates,
+// similar results are compared with the distance of the collection
+type Diversify struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lambda *float32 `protobuf:"fixed32,1,opt,name=lambda,proto3,oneof" json:"lambda,omitempty"`        // relevance against novelty in [0, 1], unset is 0.5
+	FetchK uint32   `protobuf:"varint,2,opt,name=fetch_k,json=fetchK,proto3" json:"fetch_k,omitempty"` // 0 is 4 times topK
+}
+
+func (x *Diversify) Reset() {
+	*x = Diversify{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diversify) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diversify) ProtoMessage() {}
+
+func (x *Diversify) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diversify.ProtoReflect.Descriptor instead.
+func (*Diversify) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Diversify) GetLambda() float32 {
+	if x != nil && x.Lambda != nil {
+		return *x.Lambda
+	}
+	return 0
+}
+
+func (x *Diversify) GetFetchK() uint32 {
+	if x != nil {
+		return x.FetchK
+	}
+	return 0
+}
+
+// terms, "quoted phrases" and prefix* terms, a record matches any of them
+// unless match_all is set
+type TextQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field    string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Query    string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	MatchAll bool   `protobuf:"varint,3,opt,name=match_all,json=matchAll,proto3" json:"match_all,omitempty"`
+}
+
+func (x *TextQuery) Reset() {
+	*x = TextQuery{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextQuery) ProtoMessage() {}
+
+func (x *TextQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextQuery.ProtoReflect.Descriptor instead.
+func (*TextQuery) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *HnswConfig) GetMMax0() int32 {
+func (x *TextQuery) GetField() string {
 	if x != nil {
-		return x.MMax0
+		return x.Field
 	}
-	return 0
+	return ""
 }
 
-func (x *HnswConfig) GetHeuristicExtendCandidates() bool {
+func (x *TextQuery) GetQuery() string {
 	if x != nil {
-		return x.HeuristicExtendCandidates
+		return x.Query
 	}
-	return false
+	return ""
 }
 
-func (x *HnswConfig) GetHeuristicKeepPruned() bool {
+func (x *TextQuery) GetMatchAll() bool {
 	if x != nil {
-		return x.HeuristicKeepPruned
+		return x.MatchAll
 	}
 	return false
 }
 
-type ResponseWithMessage struct {
+type FieldQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status  bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Error   *Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Field  string    `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Vector []float32 `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Weight float32   `protobuf:"fixed32,3,opt,name=weight,proto3" json:"weight,omitempty"` // all 0 weighs the fields equally
 }
 
-func (x *ResponseWithMessage) Reset() {
-	*x = ResponseWithMessage{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
+func (x *FieldQuery) Reset() {
+	*x = FieldQuery{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResponseWithMessage) String() string {
+func (x *FieldQuery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseWithMessage) ProtoMessage() {}
+func (*FieldQuery) ProtoMessage() {}
 
-func (x *ResponseWithMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
+func (x *FieldQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,56 +2555,56 @@ func (x *ResponseWithMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseWithMessage.ProtoReflect.Descriptor instead.
-func (*ResponseWithMessage) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use FieldQuery.ProtoReflect.Descriptor instead.
+func (*FieldQuery) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *ResponseWithMessage) GetStatus() bool {
+func (x *FieldQuery) GetField() string {
 	if x != nil {
-		return x.Status
+		return x.Field
 	}
-	return false
+	return ""
 }
 
-func (x *ResponseWithMessage) GetMessage() string {
+func (x *FieldQuery) GetVector() []float32 {
 	if x != nil {
-		return x.Message
+		return x.Vector
 	}
-	return ""
+	return nil
 }
 
-func (x *ResponseWithMessage) GetError() *Error {
+func (x *FieldQuery) GetWeight() float32 {
 	if x != nil {
-		return x.Error
+		return x.Weight
 	}
-	return nil
+	return 0
 }
 
-type Response struct {
+type GeoPoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error  *Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Response) String() string {
+func (x *GeoPoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*GeoPoint) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -857,49 +2615,49 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *Response) GetStatus() bool {
+func (x *GeoPoint) GetLat() float64 {
 	if x != nil {
-		return x.Status
+		return x.Lat
 	}
-	return false
+	return 0
 }
 
-func (x *Response) GetError() *Error {
+func (x *GeoPoint) GetLon() float64 {
 	if x != nil {
-		return x.Error
+		return x.Lon
 	}
-	return nil
+	return 0
 }
 
-type Error struct {
+type GeoBoundingBox struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ErrorMessage string    `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	ErrorCode    ErrorCode `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3,enum=coreproto.ErrorCode" json:"error_code,omitempty"`
+	TopLeft     *GeoPoint `protobuf:"bytes,1,opt,name=top_left,json=topLeft,proto3" json:"top_left,omitempty"`
+	BottomRight *GeoPoint `protobuf:"bytes,2,opt,name=bottom_right,json=bottomRight,proto3" json:"bottom_right,omitempty"`
 }
 
-func (x *Error) Reset() {
-	*x = Error{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+func (x *GeoBoundingBox) Reset() {
+	*x = GeoBoundingBox{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Error) String() string {
+func (x *GeoBoundingBox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Error) ProtoMessage() {}
+func (*GeoBoundingBox) ProtoMessage() {}
 
-func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+func (x *GeoBoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,53 +2668,54 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Error.ProtoReflect.Descriptor instead.
-func (*Error) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GeoBoundingBox.ProtoReflect.Descriptor instead.
+func (*GeoBoundingBox) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *Error) GetErrorMessage() string {
+func (x *GeoBoundingBox) GetTopLeft() *GeoPoint {
 	if x != nil {
-		return x.ErrorMessage
+		return x.TopLeft
 	}
-	return ""
+	return nil
 }
 
-func (x *Error) GetErrorCode() ErrorCode {
+func (x *GeoBoundingBox) GetBottomRight() *GeoPoint {
 	if x != nil {
-		return x.ErrorCode
+		return x.BottomRight
 	}
-	return ErrorCode_UNDEFINED
+	return nil
 }
 
-type SearchRequest struct {
+// radius (radius_meters > 0) or bounding box filter on a geo metadata field,
+// a geo field is a metadata object {"lat": .., "lon": ..}
+type GeoFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName    string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Vector            []float32         `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
-	TopK              uint64            `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`
-	MinScoreThreshold float32           `protobuf:"fixed32,4,opt,name=min_score_threshold,json=minScoreThreshold,proto3" json:"min_score_threshold,omitempty"`
-	Filter            map[string]string `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	WithLatency       bool              `protobuf:"varint,6,opt,name=with_latency,json=withLatency,proto3" json:"with_latency,omitempty"`
+	Field          string          `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Center         *GeoPoint       `protobuf:"bytes,2,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusMeters   float64         `protobuf:"fixed64,3,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	BoundingBox    *GeoBoundingBox `protobuf:"bytes,4,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	SortByDistance bool            `protobuf:"varint,5,opt,name=sort_by_distance,json=sortByDistance,proto3" json:"sort_by_distance,omitempty"`
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
+func (x *GeoFilter) Reset() {
+	*x = GeoFilter{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *GeoFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*GeoFilter) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
+func (x *GeoFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -967,49 +2726,42 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GeoFilter.ProtoReflect.Descriptor instead.
+func (*GeoFilter) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *SearchRequest) GetCollectionName() string {
+func (x *GeoFilter) GetField() string {
 	if x != nil {
-		return x.CollectionName
+		return x.Field
 	}
 	return ""
 }
 
-func (x *SearchRequest) GetVector() []float32 {
+func (x *GeoFilter) GetCenter() *GeoPoint {
 	if x != nil {
-		return x.Vector
+		return x.Center
 	}
 	return nil
 }
 
-func (x *SearchRequest) GetTopK() uint64 {
-	if x != nil {
-		return x.TopK
-	}
-	return 0
-}
-
-func (x *SearchRequest) GetMinScoreThreshold() float32 {
+func (x *GeoFilter) GetRadiusMeters() float64 {
 	if x != nil {
-		return x.MinScoreThreshold
+		return x.RadiusMeters
 	}
 	return 0
 }
 
-func (x *SearchRequest) GetFilter() map[string]string {
+func (x *GeoFilter) GetBoundingBox() *GeoBoundingBox {
 	if x != nil {
-		return x.Filter
+		return x.BoundingBox
 	}
 	return nil
 }
 
-func (x *SearchRequest) GetWithLatency() bool {
+func (x *GeoFilter) GetSortByDistance() bool {
 	if x != nil {
-		return x.WithLatency
+		return x.SortByDistance
 	}
 	return false
 }
@@ -1022,11 +2774,19 @@ type Candidates struct {
 	Id       string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Metadata *structpb.Struct `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Score    float32          `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
+	// meters from the geo filter origin, set when a geo filter is applied
+	GeoDistance float64 `protobuf:"fixed64,4,opt,name=geo_distance,json=geoDistance,proto3" json:"geo_distance,omitempty"`
+	// 0-100 score of each queried field, set for field_queries searches
+	FieldScores map[string]float32 `protobuf:"bytes,5,rep,name=field_scores,json=fieldScores,proto3" json:"field_scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+	SparseScore float32            `protobuf:"fixed32,6,opt,name=sparse_score,json=sparseScore,proto3" json:"sparse_score,omitempty"` // dot product, set for sparse_vector searches
+	DenseScore  float32            `protobuf:"fixed32,7,opt,name=dense_score,json=denseScore,proto3" json:"dense_score,omitempty"`    // 0-100, set when dense and sparse rankings are fused
+	TextScore   float32            `protobuf:"fixed32,8,opt,name=text_score,json=textScore,proto3" json:"text_score,omitempty"`       // bm25, set for text_query searches
+	Vector      []float32          `protobuf:"fixed32,9,rep,packed,name=vector,proto3" json:"vector,omitempty"`                       // set for with_vector searches
 }
 
 func (x *Candidates) Reset() {
 	*x = Candidates{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1038,7 +2798,7 @@ func (x *Candidates) String() string {
 func (*Candidates) ProtoMessage() {}
 
 func (x *Candidates) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,7 +2811,7 @@ func (x *Candidates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Candidates.ProtoReflect.Descriptor instead.
 func (*Candidates) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{11}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Candidates) GetId() string {
@@ -1075,20 +2835,64 @@ func (x *Candidates) GetScore() float32 {
 	return 0
 }
 
+func (x *Candidates) GetGeoDistance() float64 {
+	if x != nil {
+		return x.GeoDistance
+	}
+	return 0
+}
+
+func (x *Candidates) GetFieldScores() map[string]float32 {
+	if x != nil {
+		return x.FieldScores
+	}
+	return nil
+}
+
+func (x *Candidates) GetSparseScore() float32 {
+	if x != nil {
+		return x.SparseScore
+	}
+	return 0
+}
+
+func (x *Candidates) GetDenseScore() float32 {
+	if x != nil {
+		return x.DenseScore
+	}
+	return 0
+}
+
+func (x *Candidates) GetTextScore() float32 {
+	if x != nil {
+		return x.TextScore
+	}
+	return 0
+}
+
+func (x *Candidates) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
 type SearchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     bool          `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error      *Error        `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Candidates []*Candidates `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
-	Latency    string        `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	Status        bool          `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         *Error        `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Candidates    []*Candidates `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Latency       string        `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	Groups        []*Group      `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`                                      // group_by searches, candidates is empty then
+	NextPageToken string        `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // set while results are left after the page
 }
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1100,7 +2904,7 @@ func (x *SearchResponse) String() string {
 func (*SearchResponse) ProtoMessage() {}
 
 func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,7 +2917,7 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
 func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{12}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SearchResponse) GetStatus() bool {
@@ -1144,6 +2948,74 @@ func (x *SearchResponse) GetLatency() string {
 	return ""
 }
 
+func (x *SearchResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// the results sharing one value of the group_by field, best first
+type Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value      string        `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Candidates []*Candidates `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Group) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Group) GetCandidates() []*Candidates {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
 type CollectionMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1156,7 +3028,7 @@ type CollectionMsg struct {
 
 func (x *CollectionMsg) Reset() {
 	*x = CollectionMsg{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1168,7 +3040,7 @@ func (x *CollectionMsg) String() string {
 func (*CollectionMsg) ProtoMessage() {}
 
 func (x *CollectionMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,7 +3053,7 @@ func (x *CollectionMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionMsg.ProtoReflect.Descriptor instead.
 func (*CollectionMsg) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{13}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CollectionMsg) GetStatus() bool {
@@ -1210,18 +3082,27 @@ type CollectionInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName    string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	CollectionConfig  *HnswConfig  `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
-	VectorDimension   uint32       `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
-	Distance          Distance     `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
-	CompressionHelper Quantization `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
-	CollectionSize    string       `protobuf:"bytes,6,opt,name=collection_size,json=collectionSize,proto3" json:"collection_size,omitempty"`
-	CollectionLength  uint64       `protobuf:"varint,7,opt,name=collection_length,json=collectionLength,proto3" json:"collection_length,omitempty"`
+	CollectionName    string           `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	CollectionConfig  *HnswConfig      `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
+	VectorDimension   uint32           `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance          Distance         `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
+	CompressionHelper Quantization     `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	CollectionSize    string           `protobuf:"bytes,6,opt,name=collection_size,json=collectionSize,proto3" json:"collection_size,omitempty"`
+	CollectionLength  uint64           `protobuf:"varint,7,opt,name=collection_length,json=collectionLength,proto3" json:"collection_length,omitempty"`
+	IndexType         IndexType        `protobuf:"varint,8,opt,name=index_type,json=indexType,proto3,enum=coreproto.IndexType" json:"index_type,omitempty"`
+	VamanaConfig      *VamanaConfig    `protobuf:"bytes,9,opt,name=vamana_config,json=vamanaConfig,proto3" json:"vamana_config,omitempty"`
+	IvfConfig         *IvfConfig       `protobuf:"bytes,10,opt,name=ivf_config,json=ivfConfig,proto3" json:"ivf_config,omitempty"`
+	HnswPqConfig      *HnswPqConfig    `protobuf:"bytes,11,opt,name=hnsw_pq_config,json=hnswPqConfig,proto3" json:"hnsw_pq_config,omitempty"`
+	FlatConfig        *FlatConfig      `protobuf:"bytes,12,opt,name=flat_config,json=flatConfig,proto3" json:"flat_config,omitempty"`
+	Rebuild           *RebuildProgress `protobuf:"bytes,13,opt,name=rebuild,proto3" json:"rebuild,omitempty"` // last rebuild started by AlterCollection
+	MaxQueryTimeMs    uint32           `protobuf:"varint,14,opt,name=max_query_time_ms,json=maxQueryTimeMs,proto3" json:"max_query_time_ms,omitempty"`
+	VectorFields      []*VectorField   `protobuf:"bytes,15,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"`
+	TextFields        []*TextField     `protobuf:"bytes,16,rep,name=text_fields,json=textFields,proto3" json:"text_fields,omitempty"`
 }
 
 func (x *CollectionInfo) Reset() {
 	*x = CollectionInfo{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1233,7 +3114,7 @@ func (x *CollectionInfo) String() string {
 func (*CollectionInfo) ProtoMessage() {}
 
 func (x *CollectionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1246,7 +3127,7 @@ func (x *CollectionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionInfo.ProtoReflect.Descriptor instead.
 func (*CollectionInfo) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{14}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *CollectionInfo) GetCollectionName() string {
@@ -1298,6 +3179,69 @@ func (x *CollectionInfo) GetCollectionLength() uint64 {
 	return 0
 }
 
+func (x *CollectionInfo) GetIndexType() IndexType {
+	if x != nil {
+		return x.IndexType
+	}
+	return IndexType_Hnsw
+}
+
+func (x *CollectionInfo) GetVamanaConfig() *VamanaConfig {
+	if x != nil {
+		return x.VamanaConfig
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetIvfConfig() *IvfConfig {
+	if x != nil {
+		return x.IvfConfig
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetHnswPqConfig() *HnswPqConfig {
+	if x != nil {
+		return x.HnswPqConfig
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetFlatConfig() *FlatConfig {
+	if x != nil {
+		return x.FlatConfig
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetRebuild() *RebuildProgress {
+	if x != nil {
+		return x.Rebuild
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetMaxQueryTimeMs() uint32 {
+	if x != nil {
+		return x.MaxQueryTimeMs
+	}
+	return 0
+}
+
+func (x *CollectionInfo) GetVectorFields() []*VectorField {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetTextFields() []*TextField {
+	if x != nil {
+		return x.TextFields
+	}
+	return nil
+}
+
 var File_idl_proto_v3_core_proto protoreflect.FileDescriptor
 
 var file_idl_proto_v3_core_proto_rawDesc = []byte{
@@ -1315,7 +3259,7 @@ var file_idl_proto_v3_core_proto_rawDesc = []byte{
 	0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69,
 	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x04, 0x64, 0x69, 0x73, 0x74, 0x22, 0x1e, 0x0a, 0x06,
 	0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xe0, 0x01, 0x0a,
+	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xb3, 0x03, 0x0a,
 	0x0d, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e,
 	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27,
 	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
@@ -1329,125 +3273,42 @@ var file_idl_proto_v3_core_proto_rawDesc = []byte{
 	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x64,
 	0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x10, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22,
-	0x56, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69,
-	0x74, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77,
-	0x69, 0x74, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x52,
-	0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa1, 0x02,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63,
-	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a,
-	0x10, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44,
-	0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52,
-	0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x12, 0x63, 0x6f, 0x6d,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11,
-	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65,
-	0x72, 0x22, 0xe5, 0x02, 0x0a, 0x0a, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x45, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72,
-	0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67,
-	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x0f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c,
-	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65, 0x76, 0x65, 0x6c,
-	0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69,
-	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
-	0x65, 0x66, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65, 0x66, 0x43,
-	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x6d,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6d, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x5f, 0x6d,
-	0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d, 0x4d, 0x61, 0x78, 0x12, 0x15,
-	0x0a, 0x06, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x30, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x6d, 0x4d, 0x61, 0x78, 0x30, 0x12, 0x3e, 0x0a, 0x1b, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74,
-	0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x68, 0x65, 0x75, 0x72,
-	0x69, 0x73, 0x74, 0x69, 0x63, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x43, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74,
-	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x4b,
-	0x65, 0x65, 0x70, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x13, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26,
-	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
-	0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xb0, 0x02, 0x0a, 0x0d, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x6f, 0x70, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b,
-	0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x68,
-	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d,
-	0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
-	0x12, 0x3c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21,
-	0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x67, 0x0a, 0x0a,
-	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64,
-	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
-	0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x7e, 0x0a, 0x0d, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12,
+	0x3f, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x12, 0x3c, 0x0a, 0x0d, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x52, 0x0c, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x1a, 0x52,
+	0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x40, 0x0a, 0x0c, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x22, 0x25, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x56, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77, 0x69, 0x74, 0x68, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
 	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66,
-	0x6f, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65,
+	0x63, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xf7, 0x02, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x0a, 0x0f,
+	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdf, 0x05, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x12, 0x27, 0x0a, 0x0f,
 	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
@@ -1464,93 +3325,541 @@ var file_idl_proto_v3_core_proto_rawDesc = []byte{
 	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75,
 	0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x12, 0x27, 0x0a,
-	0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x2a, 0x2c, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67,
-	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
-	0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x10,
-	0x01, 0x2a, 0x25, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a, 0x0a,
-	0x06, 0x43, 0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75, 0x63,
-	0x6c, 0x69, 0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x2a, 0x43, 0x0a, 0x0c, 0x51, 0x75, 0x61, 0x6e,
-	0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65,
-	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x46,
-	0x38, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31, 0x36, 0x10, 0x03, 0x12, 0x06, 0x0a,
-	0x02, 0x50, 0x51, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x42, 0x51, 0x10, 0x05, 0x2a, 0x97, 0x01,
-	0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55,
-	0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x50,
-	0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d,
-	0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44,
-	0x5f, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19,
-	0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48,
-	0x41, 0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4d,
-	0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x17,
-	0x0a, 0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x5f,
-	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x36, 0x0a, 0x10, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x49,
-	0x4e, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54,
-	0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x32,
-	0xfd, 0x06, 0x0a, 0x07, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x50,
-	0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x53, 0x70, 0x65, 0x63, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
-	0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x66, 0x12, 0x19, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
-	0x67, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
-	0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x11,
-	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1e, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x39,
-	0x0a, 0x06, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x12, 0x33, 0x0a,
+	0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x0c, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x33, 0x0a, 0x0a, 0x69, 0x76, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x49, 0x76, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x69, 0x76, 0x66, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a, 0x0e, 0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70, 0x71,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e, 0x73, 0x77, 0x50, 0x71,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x36, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x74, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x0a, 0x66, 0x6c, 0x61, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x11,
+	0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52,
+	0x0a, 0x74, 0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x64, 0x0a, 0x0b, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x69, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x64, 0x69, 0x6d,
+	0x12, 0x2f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x22, 0x50, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x72, 0x52, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79,
+	0x7a, 0x65, 0x72, 0x22, 0x87, 0x03, 0x0a, 0x0a, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x45, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x0f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x0f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70,
+	0x6c, 0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x65, 0x66, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65,
+	0x66, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a,
+	0x01, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6d, 0x12, 0x13, 0x0a, 0x05, 0x6d,
+	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d, 0x4d, 0x61, 0x78,
+	0x12, 0x15, 0x0a, 0x06, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x30, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6d, 0x4d, 0x61, 0x78, 0x30, 0x12, 0x3e, 0x0a, 0x1b, 0x68, 0x65, 0x75, 0x72, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x68, 0x65,
+	0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x43, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x68, 0x65, 0x75, 0x72, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x4b, 0x65, 0x65, 0x70, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x04, 0x73,
+	0x65, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x04, 0x73, 0x65, 0x65,
+	0x64, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x22, 0xd9, 0x01,
+	0x0a, 0x0c, 0x56, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x26, 0x0a,
+	0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x65, 0x61, 0x6d, 0x5f, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x65, 0x61, 0x6d, 0x57,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x71, 0x5f, 0x73, 0x75, 0x62, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x71, 0x53,
+	0x75, 0x62, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x58, 0x0a, 0x09, 0x49, 0x76, 0x66,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6e, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0xe1, 0x01, 0x0a, 0x0c, 0x48, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x01, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x65, 0x66, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x65,
+	0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x65, 0x66, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x65, 0x6e, 0x74, 0x72, 0x6f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x63, 0x65, 0x6e, 0x74, 0x72, 0x6f, 0x69, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x62,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73,
+	0x75, 0x62, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x51, 0x0a, 0x0a, 0x46, 0x6c, 0x61, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x2b, 0x0a,
+	0x11, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74,
+	0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0xe1, 0x04, 0x0a, 0x16, 0x41,
+	0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4a,
+	0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x0f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x13, 0x0a, 0x02, 0x65, 0x66,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x02, 0x65, 0x66, 0x88, 0x01, 0x01, 0x12,
+	0x2c, 0x0a, 0x0f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x0e, 0x65, 0x66, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a,
+	0x01, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x04, 0x52, 0x01, 0x6d, 0x88, 0x01, 0x01,
+	0x12, 0x18, 0x0a, 0x05, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x05, 0x52, 0x04, 0x6d, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x06, 0x6d, 0x5f,
+	0x6d, 0x61, 0x78, 0x30, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x48, 0x06, 0x52, 0x05, 0x6d, 0x4d,
+	0x61, 0x78, 0x30, 0x88, 0x01, 0x01, 0x12, 0x43, 0x0a, 0x1b, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x48, 0x07, 0x52, 0x19, 0x68,
+	0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a, 0x15, 0x68,
+	0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72,
+	0x75, 0x6e, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x48, 0x08, 0x52, 0x13, 0x68, 0x65,
+	0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x4b, 0x65, 0x65, 0x70, 0x50, 0x72, 0x75, 0x6e, 0x65,
+	0x64, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x42, 0x05,
+	0x0a, 0x03, 0x5f, 0x65, 0x66, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x6d, 0x42,
+	0x08, 0x0a, 0x06, 0x5f, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6d, 0x5f,
+	0x6d, 0x61, 0x78, 0x30, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x22, 0xd3,
+	0x01, 0x0a, 0x17, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x6f, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x86, 0x08, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70,
+	0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x12, 0x2e, 0x0a,
+	0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x3c, 0x0a,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x77,
+	0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x33,
+	0x0a, 0x0a, 0x67, 0x65, 0x6f, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
+	0x65, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x66, 0x75, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x75, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x72, 0x66, 0x5f, 0x6b, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x72, 0x72, 0x66, 0x4b, 0x12, 0x3c, 0x0a, 0x0d, 0x73, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x64, 0x65, 0x6e,
+	0x73, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x0c, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x33, 0x0a,
+	0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65,
+	0x78, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x32, 0x0a, 0x09, 0x64, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x66, 0x79, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69, 0x66, 0x79, 0x52, 0x09, 0x64, 0x69, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x66, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x62, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42,
+	0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x17, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69, 0x74, 0x68,
+	0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77,
+	0x69, 0x74, 0x68, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22,
+	0xc3, 0x03, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x12, 0x41, 0x0a, 0x10, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x41, 0x0a, 0x10, 0x6e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0f, 0x6e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x08, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x12, 0x3f, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c, 0x0a, 0x09, 0x44, 0x69, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x66, 0x79, 0x12, 0x1b, 0x0a, 0x06, 0x6c, 0x61, 0x6d, 0x62, 0x64, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x02, 0x48, 0x00, 0x52, 0x06, 0x6c, 0x61, 0x6d, 0x62, 0x64, 0x61, 0x88, 0x01, 0x01, 0x12,
+	0x17, 0x0a, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x66, 0x65, 0x74, 0x63, 0x68, 0x4b, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x61, 0x6d,
+	0x62, 0x64, 0x61, 0x22, 0x54, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6c, 0x6c, 0x22, 0x52, 0x0a, 0x0a, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x2e, 0x0a,
+	0x08, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x22, 0x78, 0x0a,
+	0x0e, 0x47, 0x65, 0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12,
+	0x2e, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x07, 0x74, 0x6f, 0x70, 0x4c, 0x65, 0x66, 0x74, 0x12,
+	0x36, 0x0a, 0x0c, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x62, 0x6f, 0x74, 0x74,
+	0x6f, 0x6d, 0x52, 0x69, 0x67, 0x68, 0x74, 0x22, 0xdb, 0x01, 0x0a, 0x09, 0x47, 0x65, 0x6f, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x63,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x64, 0x69,
+	0x75, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0c, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a,
+	0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x28, 0x0a, 0x10, 0x73,
+	0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x44, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x90, 0x03, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6f, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x67, 0x65, 0x6f, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0b, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x0b, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x64, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x03, 0x28, 0x02,
+	0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x1a, 0x3e, 0x0a, 0x10, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf3, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x63,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x28, 0x0a, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x54,
+	0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x35, 0x0a,
+	0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x22, 0x7e, 0x0a, 0x0d, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0xeb, 0x06, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64,
+	0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x2f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x46, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x33,
+	0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x0c, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x33, 0x0a, 0x0a, 0x69, 0x76, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x49, 0x76, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x69, 0x76, 0x66,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a, 0x0e, 0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70,
+	0x71, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e, 0x73, 0x77, 0x50,
+	0x71, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x36, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x74, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x0a, 0x66, 0x6c, 0x61, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a,
+	0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x72, 0x65, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x12, 0x29, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x3b,
+	0x0a, 0x0d, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0c, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x74,
+	0x65, 0x78, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65, 0x78,
+	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x2a, 0x3e, 0x0a, 0x0c, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x64, 0x6c, 0x65, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x77, 0x61,
+	0x70, 0x70, 0x65, 0x64, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x10, 0x03, 0x2a, 0x2c, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x10,
+	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x10, 0x01,
+	0x2a, 0x53, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a, 0x0a, 0x06,
+	0x43, 0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75, 0x63, 0x6c,
+	0x69, 0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x6e, 0x6e, 0x65, 0x72,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x61, 0x6e,
+	0x68, 0x61, 0x74, 0x74, 0x61, 0x6e, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x48, 0x61, 0x6d, 0x6d,
+	0x69, 0x6e, 0x67, 0x10, 0x04, 0x2a, 0x40, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x6e, 0x73, 0x77, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x56, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x76, 0x66, 0x10,
+	0x02, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x10, 0x03, 0x12, 0x08, 0x0a,
+	0x04, 0x46, 0x6c, 0x61, 0x74, 0x10, 0x04, 0x2a, 0x35, 0x0a, 0x08, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x7a, 0x65, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x68, 0x69, 0x74, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x6e, 0x67, 0x6c, 0x69, 0x73, 0x68, 0x10, 0x02, 0x2a, 0x43,
+	0x0a, 0x0c, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08,
+	0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10,
+	0x01, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x38, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31,
+	0x36, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x50, 0x51, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x42,
+	0x51, 0x10, 0x05, 0x2a, 0x97, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12,
+	0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c,
+	0x5f, 0x46, 0x55, 0x4e, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x36, 0x0a,
+	0x10, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4e, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a,
+	0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c,
+	0x45, 0x54, 0x45, 0x10, 0x02, 0x2a, 0x35, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x64, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x0d, 0x0a,
+	0x09, 0x42, 0x65, 0x73, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x10, 0x01, 0x2a, 0x22, 0x0a, 0x06,
+	0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x65, 0x64, 0x53, 0x75, 0x6d, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x72, 0x66, 0x10, 0x01,
+	0x32, 0xa0, 0x08, 0x0a, 0x07, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38, 0x0a, 0x04,
+	0x50, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x66, 0x12, 0x19, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x73, 0x67, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x12, 0x50, 0x0a,
+	0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1e, 0x2e,
 	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18,
-	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73,
-	0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x45, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
-	0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a,
-	0x0c, 0x48, 0x79, 0x62, 0x72, 0x69, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e,
+	0x73, 0x65, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12,
+	0x5a, 0x0a, 0x0f, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
+	0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x49,
+	0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a,
+	0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x39, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c,
+	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
 	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x44,
-	0x69, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x22, 0x00, 0x42,
-	0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x48, 0x79,
+	0x62, 0x72, 0x69, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x45, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x12, 0x1b,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x44, 0x69, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x1a, 0x11,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x58, 0x79, 0x44, 0x69, 0x73,
+	0x74, 0x22, 0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1565,86 +3874,159 @@ func file_idl_proto_v3_core_proto_rawDescGZIP() []byte {
 	return file_idl_proto_v3_core_proto_rawDescData
 }
 
-var file_idl_proto_v3_core_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_idl_proto_v3_core_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_idl_proto_v3_core_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_idl_proto_v3_core_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_idl_proto_v3_core_proto_goTypes = []any{
-	(SearchAlgorithm)(0),        // 0: coreproto.SearchAlgorithm
-	(Distance)(0),               // 1: coreproto.Distance
-	(Quantization)(0),           // 2: coreproto.Quantization
-	(ErrorCode)(0),              // 3: coreproto.ErrorCode
-	(IndexChangeTypes)(0),       // 4: coreproto.IndexChangeTypes
-	(*CompXyDist)(nil),          // 5: coreproto.CompXyDist
-	(*XyDist)(nil),              // 6: coreproto.XyDist
-	(*DatasetChange)(nil),       // 7: coreproto.DatasetChange
-	(*CollectionName)(nil),      // 8: coreproto.CollectionName
-	(*CollectionResponse)(nil),  // 9: coreproto.CollectionResponse
-	(*CollectionSpec)(nil),      // 10: coreproto.CollectionSpec
-	(*HnswConfig)(nil),          // 11: coreproto.HnswConfig
-	(*ResponseWithMessage)(nil), // 12: coreproto.ResponseWithMessage
-	(*Response)(nil),            // 13: coreproto.Response
-	(*Error)(nil),               // 14: coreproto.Error
-	(*SearchRequest)(nil),       // 15: coreproto.SearchRequest
-	(*Candidates)(nil),          // 16: coreproto.Candidates
-	(*SearchResponse)(nil),      // 17: coreproto.SearchResponse
-	(*CollectionMsg)(nil),       // 18: coreproto.CollectionMsg
-	(*CollectionInfo)(nil),      // 19: coreproto.CollectionInfo
-	nil,                         // 20: coreproto.SearchRequest.FilterEntry
-	(*structpb.Struct)(nil),     // 21: google.protobuf.Struct
-	(*emptypb.Empty)(nil),       // 22: google.protobuf.Empty
+	(RebuildState)(0),               // 0: coreproto.RebuildState
+	(SearchAlgorithm)(0),            // 1: coreproto.SearchAlgorithm
+	(Distance)(0),                   // 2: coreproto.Distance
+	(IndexType)(0),                  // 3: coreproto.IndexType
+	(Analyzer)(0),                   // 4: coreproto.Analyzer
+	(Quantization)(0),               // 5: coreproto.Quantization
+	(ErrorCode)(0),                  // 6: coreproto.ErrorCode
+	(IndexChangeTypes)(0),           // 7: coreproto.IndexChangeTypes
+	(RecommendStrategy)(0),          // 8: coreproto.RecommendStrategy
+	(Fusion)(0),                     // 9: coreproto.Fusion
+	(*CompXyDist)(nil),              // 10: coreproto.CompXyDist
+	(*XyDist)(nil),                  // 11: coreproto.XyDist
+	(*DatasetChange)(nil),           // 12: coreproto.DatasetChange
+	(*SparseVector)(nil),            // 13: coreproto.SparseVector
+	(*FieldVector)(nil),             // 14: coreproto.FieldVector
+	(*CollectionName)(nil),          // 15: coreproto.CollectionName
+	(*CollectionResponse)(nil),      // 16: coreproto.CollectionResponse
+	(*CollectionSpec)(nil),          // 17: coreproto.CollectionSpec
+	(*VectorField)(nil),             // 18: coreproto.VectorField
+	(*TextField)(nil),               // 19: coreproto.TextField
+	(*HnswConfig)(nil),              // 20: coreproto.HnswConfig
+	(*VamanaConfig)(nil),            // 21: coreproto.VamanaConfig
+	(*IvfConfig)(nil),               // 22: coreproto.IvfConfig
+	(*HnswPqConfig)(nil),            // 23: coreproto.HnswPqConfig
+	(*FlatConfig)(nil),              // 24: coreproto.FlatConfig
+	(*AlterCollectionRequest)(nil),  // 25: coreproto.AlterCollectionRequest
+	(*AlterCollectionResponse)(nil), // 26: coreproto.AlterCollectionResponse
+	(*RebuildProgress)(nil),         // 27: coreproto.RebuildProgress
+	(*ResponseWithMessage)(nil),     // 28: coreproto.ResponseWithMessage
+	(*Response)(nil),                // 29: coreproto.Response
+	(*Error)(nil),                   // 30: coreproto.Error
+	(*SearchRequest)(nil),           // 31: coreproto.SearchRequest
+	(*RecommendRequest)(nil),        // 32: coreproto.RecommendRequest
+	(*Diversify)(nil),               // 33: coreproto.Diversify
+	(*TextQuery)(nil),               // 34: coreproto.TextQuery
+	(*FieldQuery)(nil),              // 35: coreproto.FieldQuery
+	(*GeoPoint)(nil),                // 36: coreproto.GeoPoint
+	(*GeoBoundingBox)(nil),          // 37: coreproto.GeoBoundingBox
+	(*GeoFilter)(nil),               // 38: coreproto.GeoFilter
+	(*Candidates)(nil),              // 39: coreproto.Candidates
+	(*SearchResponse)(nil),          // 40: coreproto.SearchResponse
+	(*Group)(nil),                   // 41: coreproto.Group
+	(*CollectionMsg)(nil),           // 42: coreproto.CollectionMsg
+	(*CollectionInfo)(nil),          // 43: coreproto.CollectionInfo
+	nil,                             // 44: coreproto.DatasetChange.VectorsEntry
+	nil,                             // 45: coreproto.SearchRequest.FilterEntry
+	nil,                             // 46: coreproto.RecommendRequest.FilterEntry
+	nil,                             // 47: coreproto.Candidates.FieldScoresEntry
+	(*structpb.Struct)(nil),         // 48: google.protobuf.Struct
+	(*emptypb.Empty)(nil),           // 49: google.protobuf.Empty
 }
 var file_idl_proto_v3_core_proto_depIdxs = []int32{
-	1,  // 0: coreproto.CompXyDist.dist:type_name -> coreproto.Distance
-	21, // 1: coreproto.DatasetChange.metadata:type_name -> google.protobuf.Struct
-	4,  // 2: coreproto.DatasetChange.index_change_types:type_name -> coreproto.IndexChangeTypes
-	10, // 3: coreproto.CollectionResponse.spec:type_name -> coreproto.CollectionSpec
-	14, // 4: coreproto.CollectionResponse.error:type_name -> coreproto.Error
-	11, // 5: coreproto.CollectionSpec.collection_config:type_name -> coreproto.HnswConfig
-	1,  // 6: coreproto.CollectionSpec.distance:type_name -> coreproto.Distance
-	2,  // 7: coreproto.CollectionSpec.compression_helper:type_name -> coreproto.Quantization
-	0,  // 8: coreproto.HnswConfig.search_algorithm:type_name -> coreproto.SearchAlgorithm
-	14, // 9: coreproto.ResponseWithMessage.error:type_name -> coreproto.Error
-	14, // 10: coreproto.Response.error:type_name -> coreproto.Error
-	3,  // 11: coreproto.Error.error_code:type_name -> coreproto.ErrorCode
-	20, // 12: coreproto.SearchRequest.filter:type_name -> coreproto.SearchRequest.FilterEntry
-	21, // 13: coreproto.Candidates.metadata:type_name -> google.protobuf.Struct
-	14, // 14: coreproto.SearchResponse.error:type_name -> coreproto.Error
-	16, // 15: coreproto.SearchResponse.candidates:type_name -> coreproto.Candidates
-	19, // 16: coreproto.CollectionMsg.info:type_name -> coreproto.CollectionInfo
-	14, // 17: coreproto.CollectionMsg.error:type_name -> coreproto.Error
-	11, // 18: coreproto.CollectionInfo.collection_config:type_name -> coreproto.HnswConfig
-	1,  // 19: coreproto.CollectionInfo.distance:type_name -> coreproto.Distance
-	2,  // 20: coreproto.CollectionInfo.compression_helper:type_name -> coreproto.Quantization
-	22, // 21: coreproto.CoreRpc.Ping:input_type -> google.protobuf.Empty
-	10, // 22: coreproto.CoreRpc.CreateCollection:input_type -> coreproto.CollectionSpec
-	8,  // 23: coreproto.CoreRpc.DropCollection:input_type -> coreproto.CollectionName
-	8,  // 24: coreproto.CoreRpc.CollectionInfof:input_type -> coreproto.CollectionName
-	8,  // 25: coreproto.CoreRpc.LoadCollection:input_type -> coreproto.CollectionName
-	8,  // 26: coreproto.CoreRpc.ReleaseCollection:input_type -> coreproto.CollectionName
-	7,  // 27: coreproto.CoreRpc.Insert:input_type -> coreproto.DatasetChange
-	7,  // 28: coreproto.CoreRpc.Update:input_type -> coreproto.DatasetChange
-	7,  // 29: coreproto.CoreRpc.Delete:input_type -> coreproto.DatasetChange
-	15, // 30: coreproto.CoreRpc.VectorSearch:input_type -> coreproto.SearchRequest
-	15, // 31: coreproto.CoreRpc.FilterSearch:input_type -> coreproto.SearchRequest
-	15, // 32: coreproto.CoreRpc.HybridSearch:input_type -> coreproto.SearchRequest
-	5,  // 33: coreproto.CoreRpc.CompareDist:input_type -> coreproto.CompXyDist
-	22, // 34: coreproto.CoreRpc.Ping:output_type -> google.protobuf.Empty
-	9,  // 35: coreproto.CoreRpc.CreateCollection:output_type -> coreproto.CollectionResponse
-	13, // 36: coreproto.CoreRpc.DropCollection:output_type -> coreproto.Response
-	18, // 37: coreproto.CoreRpc.CollectionInfof:output_type -> coreproto.CollectionMsg
-	18, // 38: coreproto.CoreRpc.LoadCollection:output_type -> coreproto.CollectionMsg
-	12, // 39: coreproto.CoreRpc.ReleaseCollection:output_type -> coreproto.ResponseWithMessage
-	13, // 40: coreproto.CoreRpc.Insert:output_type -> coreproto.Response
-	13, // 41: coreproto.CoreRpc.Update:output_type -> coreproto.Response
-	13, // 42: coreproto.CoreRpc.Delete:output_type -> coreproto.Response
-	17, // 43: coreproto.CoreRpc.VectorSearch:output_type -> coreproto.SearchResponse
-	17, // 44: coreproto.CoreRpc.FilterSearch:output_type -> coreproto.SearchResponse
-	17, // 45: coreproto.CoreRpc.HybridSearch:output_type -> coreproto.SearchResponse
-	6,  // 46: coreproto.CoreRpc.CompareDist:output_type -> coreproto.XyDist
-	34, // [34:47] is the sub-list for method output_type
-	21, // [21:34] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	2,  // 0: coreproto.CompXyDist.dist:type_name -> coreproto.Distance
+	48, // 1: coreproto.DatasetChange.metadata:type_name -> google.protobuf.Struct
+	7,  // 2: coreproto.DatasetChange.index_change_types:type_name -> coreproto.IndexChangeTypes
+	44, // 3: coreproto.DatasetChange.vectors:type_name -> coreproto.DatasetChange.VectorsEntry
+	13, // 4: coreproto.DatasetChange.sparse_vector:type_name -> coreproto.SparseVector
+	17, // 5: coreproto.CollectionResponse.spec:type_name -> coreproto.CollectionSpec
+	30, // 6: coreproto.CollectionResponse.error:type_name -> coreproto.Error
+	20, // 7: coreproto.CollectionSpec.collection_config:type_name -> coreproto.HnswConfig
+	2,  // 8: coreproto.CollectionSpec.distance:type_name -> coreproto.Distance
+	5,  // 9: coreproto.CollectionSpec.compression_helper:type_name -> coreproto.Quantization
+	3,  // 10: coreproto.CollectionSpec.index_type:type_name -> coreproto.IndexType
+	21, // 11: coreproto.CollectionSpec.vamana_config:type_name -> coreproto.VamanaConfig
+	22, // 12: coreproto.CollectionSpec.ivf_config:type_name -> coreproto.IvfConfig
+	23, // 13: coreproto.CollectionSpec.hnsw_pq_config:type_name -> coreproto.HnswPqConfig
+	24, // 14: coreproto.CollectionSpec.flat_config:type_name -> coreproto.FlatConfig
+	18, // 15: coreproto.CollectionSpec.vector_fields:type_name -> coreproto.VectorField
+	19, // 16: coreproto.CollectionSpec.text_fields:type_name -> coreproto.TextField
+	2,  // 17: coreproto.VectorField.distance:type_name -> coreproto.Distance
+	4,  // 18: coreproto.TextField.analyzer:type_name -> coreproto.Analyzer
+	1,  // 19: coreproto.HnswConfig.search_algorithm:type_name -> coreproto.SearchAlgorithm
+	1,  // 20: coreproto.AlterCollectionRequest.search_algorithm:type_name -> coreproto.SearchAlgorithm
+	20, // 21: coreproto.AlterCollectionResponse.collection_config:type_name -> coreproto.HnswConfig
+	27, // 22: coreproto.AlterCollectionResponse.rebuild:type_name -> coreproto.RebuildProgress
+	30, // 23: coreproto.AlterCollectionResponse.error:type_name -> coreproto.Error
+	0,  // 24: coreproto.RebuildProgress.state:type_name -> coreproto.RebuildState
+	30, // 25: coreproto.ResponseWithMessage.error:type_name -> coreproto.Error
+	30, // 26: coreproto.Response.error:type_name -> coreproto.Error
+	6,  // 27: coreproto.Error.error_code:type_name -> coreproto.ErrorCode
+	45, // 28: coreproto.SearchRequest.filter:type_name -> coreproto.SearchRequest.FilterEntry
+	38, // 29: coreproto.SearchRequest.geo_filter:type_name -> coreproto.GeoFilter
+	35, // 30: coreproto.SearchRequest.field_queries:type_name -> coreproto.FieldQuery
+	9,  // 31: coreproto.SearchRequest.fusion:type_name -> coreproto.Fusion
+	13, // 32: coreproto.SearchRequest.sparse_vector:type_name -> coreproto.SparseVector
+	34, // 33: coreproto.SearchRequest.text_query:type_name -> coreproto.TextQuery
+	33, // 34: coreproto.SearchRequest.diversify:type_name -> coreproto.Diversify
+	14, // 35: coreproto.RecommendRequest.positive_vectors:type_name -> coreproto.FieldVector
+	14, // 36: coreproto.RecommendRequest.negative_vectors:type_name -> coreproto.FieldVector
+	8,  // 37: coreproto.RecommendRequest.strategy:type_name -> coreproto.RecommendStrategy
+	46, // 38: coreproto.RecommendRequest.filter:type_name -> coreproto.RecommendRequest.FilterEntry
+	36, // 39: coreproto.GeoBoundingBox.top_left:type_name -> coreproto.GeoPoint
+	36, // 40: coreproto.GeoBoundingBox.bottom_right:type_name -> coreproto.GeoPoint
+	36, // 41: coreproto.GeoFilter.center:type_name -> coreproto.GeoPoint
+	37, // 42: coreproto.GeoFilter.bounding_box:type_name -> coreproto.GeoBoundingBox
+	48, // 43: coreproto.Candidates.metadata:type_name -> google.protobuf.Struct
+	47, // 44: coreproto.Candidates.field_scores:type_name -> coreproto.Candidates.FieldScoresEntry
+	30, // 45: coreproto.SearchResponse.error:type_name -> coreproto.Error
+	39, // 46: coreproto.SearchResponse.candidates:type_name -> coreproto.Candidates
+	41, // 47: coreproto.SearchResponse.groups:type_name -> coreproto.Group
+	39, // 48: coreproto.Group.candidates:type_name -> coreproto.Candidates
+	43, // 49: coreproto.CollectionMsg.info:type_name -> coreproto.CollectionInfo
+	30, // 50: coreproto.CollectionMsg.error:type_name -> coreproto.Error
+	20, // 51: coreproto.CollectionInfo.collection_config:type_name -> coreproto.HnswConfig
+	2,  // 52: coreproto.CollectionInfo.distance:type_name -> coreproto.Distance
+	5,  // 53: coreproto.CollectionInfo.compression_helper:type_name -> coreproto.Quantization
+	3,  // 54: coreproto.CollectionInfo.index_type:type_name -> coreproto.IndexType
+	21, // 55: coreproto.CollectionInfo.vamana_config:type_name -> coreproto.VamanaConfig
+	22, // 56: coreproto.CollectionInfo.ivf_config:type_name -> coreproto.IvfConfig
+	23, // 57: coreproto.CollectionInfo.hnsw_pq_config:type_name -> coreproto.HnswPqConfig
+	24, // 58: coreproto.CollectionInfo.flat_config:type_name -> coreproto.FlatConfig
+	27, // 59: coreproto.CollectionInfo.rebuild:type_name -> coreproto.RebuildProgress
+	18, // 60: coreproto.CollectionInfo.vector_fields:type_name -> coreproto.VectorField
+	19, // 61: coreproto.CollectionInfo.text_fields:type_name -> coreproto.TextField
+	14, // 62: coreproto.DatasetChange.VectorsEntry.value:type_name -> coreproto.FieldVector
+	49, // 63: coreproto.CoreRpc.Ping:input_type -> google.protobuf.Empty
+	17, // 64: coreproto.CoreRpc.CreateCollection:input_type -> coreproto.CollectionSpec
+	15, // 65: coreproto.CoreRpc.DropCollection:input_type -> coreproto.CollectionName
+	15, // 66: coreproto.CoreRpc.CollectionInfof:input_type -> coreproto.CollectionName
+	15, // 67: coreproto.CoreRpc.LoadCollection:input_type -> coreproto.CollectionName
+	15, // 68: coreproto.CoreRpc.ReleaseCollection:input_type -> coreproto.CollectionName
+	25, // 69: coreproto.CoreRpc.AlterCollection:input_type -> coreproto.AlterCollectionRequest
+	12, // 70: coreproto.CoreRpc.Insert:input_type -> coreproto.DatasetChange
+	12, // 71: coreproto.CoreRpc.Update:input_type -> coreproto.DatasetChange
+	12, // 72: coreproto.CoreRpc.Delete:input_type -> coreproto.DatasetChange
+	31, // 73: coreproto.CoreRpc.VectorSearch:input_type -> coreproto.SearchRequest
+	31, // 74: coreproto.CoreRpc.FilterSearch:input_type -> coreproto.SearchRequest
+	31, // 75: coreproto.CoreRpc.HybridSearch:input_type -> coreproto.SearchRequest
+	32, // 76: coreproto.CoreRpc.Recommend:input_type -> coreproto.RecommendRequest
+	10, // 77: coreproto.CoreRpc.CompareDist:input_type -> coreproto.CompXyDist
+	49, // 78: coreproto.CoreRpc.Ping:output_type -> google.protobuf.Empty
+	16, // 79: coreproto.CoreRpc.CreateCollection:output_type -> coreproto.CollectionResponse
+	29, // 80: coreproto.CoreRpc.DropCollection:output_type -> coreproto.Response
+	42, // 81: coreproto.CoreRpc.CollectionInfof:output_type -> coreproto.CollectionMsg
+	42, // 82: coreproto.CoreRpc.LoadCollection:output_type -> coreproto.CollectionMsg
+	28, // 83: coreproto.CoreRpc.ReleaseCollection:output_type -> coreproto.ResponseWithMessage
+	26, // 84: coreproto.CoreRpc.AlterCollection:output_type -> coreproto.AlterCollectionResponse
+	29, // 85: coreproto.CoreRpc.Insert:output_type -> coreproto.Response
+	29, // 86: coreproto.CoreRpc.Update:output_type -> coreproto.Response
+	29, // 87: coreproto.CoreRpc.Delete:output_type -> coreproto.Response
+	40, // 88: coreproto.CoreRpc.VectorSearch:output_type -> coreproto.SearchResponse
+	40, // 89: coreproto.CoreRpc.FilterSearch:output_type -> coreproto.SearchResponse
+	40, // 90: coreproto.CoreRpc.HybridSearch:output_type -> coreproto.SearchResponse
+	40, // 91: coreproto.CoreRpc.Recommend:output_type -> coreproto.SearchResponse
+	11, // 92: coreproto.CoreRpc.CompareDist:output_type -> coreproto.XyDist
+	78, // [78:93] is the sub-list for method output_type
+	63, // [63:78] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_idl_proto_v3_core_proto_init() }
@@ -1652,13 +4034,17 @@ func file_idl_proto_v3_core_proto_init() {
 	if File_idl_proto_v3_core_proto != nil {
 		return
 	}
+	file_idl_proto_v3_core_proto_msgTypes[10].OneofWrappers = []any{}
+	file_idl_proto_v3_core_proto_msgTypes[15].OneofWrappers = []any{}
+	file_idl_proto_v3_core_proto_msgTypes[21].OneofWrappers = []any{}
+	file_idl_proto_v3_core_proto_msgTypes[23].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_proto_v3_core_proto_rawDesc,
-			NumEnums:      5,
-			NumMessages:   16,
+			NumEnums:      10,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
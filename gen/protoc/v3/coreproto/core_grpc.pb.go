@@ -26,12 +26,14 @@ const (
 	CoreRpc_CollectionInfof_FullMethodName   = "/coreproto.CoreRpc/CollectionInfof"
 	CoreRpc_LoadCollection_FullMethodName    = "/coreproto.CoreRpc/LoadCollection"
 	CoreRpc_ReleaseCollection_FullMethodName = "/coreproto.CoreRpc/ReleaseCollection"
+	CoreRpc_AlterCollection_FullMethodName   = "/coreproto.CoreRpc/AlterCollection"
 	CoreRpc_Insert_FullMethodName            = "/coreproto.CoreRpc/Insert"
 	CoreRpc_Update_FullMethodName            = "/coreproto.CoreRpc/Update"
 	CoreRpc_Delete_FullMethodName            = "/coreproto.CoreRpc/Delete"
 	CoreRpc_VectorSearch_FullMethodName      = "/coreproto.CoreRpc/VectorSearch"
 	CoreRpc_FilterSearch_FullMethodName      = "/coreproto.CoreRpc/FilterSearch"
 	CoreRpc_HybridSearch_FullMethodName      = "/coreproto.CoreRpc/HybridSearch"
+	CoreRpc_Recommend_FullMethodName         = "/coreproto.CoreRpc/Recommend"
 	CoreRpc_CompareDist_FullMethodName       = "/coreproto.CoreRpc/CompareDist"
 )
 
@@ -45,12 +47,14 @@ type CoreRpcClient interface {
 	CollectionInfof(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*CollectionMsg, error)
 	LoadCollection(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*CollectionMsg, error)
 	ReleaseCollection(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*ResponseWithMessage, error)
+	AlterCollection(ctx context.Context, in *AlterCollectionRequest, opts ...grpc.CallOption) (*AlterCollectionResponse, error)
 	Insert(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error)
 	Update(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error)
 	Delete(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error)
 	VectorSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	FilterSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	HybridSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	CompareDist(ctx context.Context, in *CompXyDist, opts ...grpc.CallOption) (*XyDist, error)
 }
 
@@ -122,6 +126,16 @@ func (c *coreRpcClient) ReleaseCollection(ctx context.Context, in *CollectionNam
 	return out, nil
 }
 
+func (c *coreRpcClient) AlterCollection(ctx context.Context, in *AlterCollectionRequest, opts ...grpc.CallOption) (*AlterCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlterCollectionResponse)
+	err := c.cc.Invoke(ctx, CoreRpc_AlterCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreRpcClient) Insert(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
@@ -182,6 +196,16 @@ func (c *coreRpcClient) HybridSearch(ctx context.Context, in *SearchRequest, opt
 	return out, nil
 }
 
+func (c *coreRpcClient) Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, CoreRpc_Recommend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreRpcClient) CompareDist(ctx context.Context, in *CompXyDist, opts ...grpc.CallOption) (*XyDist, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(XyDist)
@@ -202,12 +226,14 @@ type CoreRpcServer interface {
 	CollectionInfof(context.Context, *CollectionName) (*CollectionMsg, error)
 	LoadCollection(context.Context, *CollectionName) (*CollectionMsg, error)
 	ReleaseCollection(context.Context, *CollectionName) (*ResponseWithMessage, error)
+	AlterCollection(context.Context, *AlterCollectionRequest) (*AlterCollectionResponse, error)
 	Insert(context.Context, *DatasetChange) (*Response, error)
 	Update(context.Context, *DatasetChange) (*Response, error)
 	Delete(context.Context, *DatasetChange) (*Response, error)
 	VectorSearch(context.Context, *SearchRequest) (*SearchResponse, error)
 	FilterSearch(context.Context, *SearchRequest) (*SearchResponse, error)
 	HybridSearch(context.Context, *SearchRequest) (*SearchResponse, error)
+	Recommend(context.Context, *RecommendRequest) (*SearchResponse, error)
 	CompareDist(context.Context, *CompXyDist) (*XyDist, error)
 }
 
@@ -236,6 +262,9 @@ func (UnimplementedCoreRpcServer) LoadCollection(context.Context, *CollectionNam
 func (UnimplementedCoreRpcServer) ReleaseCollection(context.Context, *CollectionName) (*ResponseWithMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseCollection not implemented")
 }
+func (UnimplementedCoreRpcServer) AlterCollection(context.Context, *AlterCollectionRequest) (*AlterCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AlterCollection not implemented")
+}
 func (UnimplementedCoreRpcServer) Insert(context.Context, *DatasetChange) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Insert not implemented")
 }
@@ -254,6 +283,9 @@ func (UnimplementedCoreRpcServer) FilterSearch(context.Context, *SearchRequest)
 func (UnimplementedCoreRpcServer) HybridSearch(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HybridSearch not implemented")
 }
+func (UnimplementedCoreRpcServer) Recommend(context.Context, *RecommendRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recommend not implemented")
+}
 func (UnimplementedCoreRpcServer) CompareDist(context.Context, *CompXyDist) (*XyDist, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CompareDist not implemented")
 }
@@ -385,6 +417,24 @@ func _CoreRpc_ReleaseCollection_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoreRpc_AlterCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreRpcServer).AlterCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreRpc_AlterCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreRpcServer).AlterCollection(ctx, req.(*AlterCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CoreRpc_Insert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DatasetChange)
 	if err := dec(in); err != nil {
@@ -493,6 +543,24 @@ func _CoreRpc_HybridSearch_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoreRpc_Recommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreRpcServer).Recommend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreRpc_Recommend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreRpcServer).Recommend(ctx, req.(*RecommendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CoreRpc_CompareDist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CompXyDist)
 	if err := dec(in); err != nil {
@@ -542,6 +610,10 @@ var CoreRpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseCollection",
 			Handler:    _CoreRpc_ReleaseCollection_Handler,
 		},
+		{
+			MethodName: "AlterCollection",
+			Handler:    _CoreRpc_AlterCollection_Handler,
+		},
 		{
 			MethodName: "Insert",
 			Handler:    _CoreRpc_Insert_Handler,
@@ -566,6 +638,10 @@ var CoreRpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HybridSearch",
 			Handler:    _CoreRpc_HybridSearch_Handler,
 		},
+		{
+			MethodName: "Recommend",
+			Handler:    _CoreRpc_Recommend_Handler,
+		},
 		{
 			MethodName: "CompareDist",
 			Handler:    _CoreRpc_CompareDist_Handler,
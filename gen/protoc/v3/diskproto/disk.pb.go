@@ -27,19 +27,42 @@ type Collection struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName            string  `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	LevelMultiplier           float32 `protobuf:"fixed32,2,opt,name=level_multiplier,json=levelMultiplier,proto3" json:"level_multiplier,omitempty"`
-	Ef                        int32   `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
-	EfConstruction            int32   `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
-	M                         int32   `protobuf:"varint,5,opt,name=m,proto3" json:"m,omitempty"`
-	MMax                      int32   `protobuf:"varint,6,opt,name=m_max,json=mMax,proto3" json:"m_max,omitempty"`
-	MMax0                     int32   `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
-	HeuristicExtendCandidates bool    `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
-	HeuristicKeepPruned       bool    `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
-	SearchAlgorithm           string  `protobuf:"bytes,10,opt,name=search_algorithm,json=searchAlgorithm,proto3" json:"search_algorithm,omitempty"`
-	VectorDimension           uint32  `protobuf:"varint,11,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
-	Distance                  string  `protobuf:"bytes,12,opt,name=distance,proto3" json:"distance,omitempty"`
-	Quantization              string  `protobuf:"bytes,13,opt,name=quantization,proto3" json:"quantization,omitempty"`
+	CollectionName            string         `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	LevelMultiplier           float32        `protobuf:"fixed32,2,opt,name=level_multiplier,json=levelMultiplier,proto3" json:"level_multiplier,omitempty"`
+	Ef                        int32          `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
+	EfConstruction            int32          `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
+	M                         int32          `protobuf:"varint,5,opt,name=m,proto3" json:"m,omitempty"`
+	MMax                      int32          `protobuf:"varint,6,opt,name=m_max,json=mMax,proto3" json:"m_max,omitempty"`
+	MMax0                     int32          `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
+	HeuristicExtendCandidates bool           `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
+	HeuristicKeepPruned       bool           `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
+	SearchAlgorithm           string         `protobuf:"bytes,10,opt,name=search_algorithm,json=searchAlgorithm,proto3" json:"search_algorithm,omitempty"`
+	VectorDimension           uint32         `protobuf:"varint,11,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance                  string         `protobuf:"bytes,12,opt,name=distance,proto3" json:"distance,omitempty"`
+	Quantization              string         `protobuf:"bytes,13,opt,name=quantization,proto3" json:"quantization,omitempty"`
+	IndexType                 string         `protobuf:"bytes,14,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`
+	VamanaMaxDegree           uint32         `protobuf:"varint,15,opt,name=vamana_max_degree,json=vamanaMaxDegree,proto3" json:"vamana_max_degree,omitempty"`
+	VamanaBuildListSize       uint32         `protobuf:"varint,16,opt,name=vamana_build_list_size,json=vamanaBuildListSize,proto3" json:"vamana_build_list_size,omitempty"`
+	VamanaSearchListSize      uint32         `protobuf:"varint,17,opt,name=vamana_search_list_size,json=vamanaSearchListSize,proto3" json:"vamana_search_list_size,omitempty"`
+	VamanaAlpha               float32        `protobuf:"fixed32,18,opt,name=vamana_alpha,json=vamanaAlpha,proto3" json:"vamana_alpha,omitempty"`
+	VamanaBeamWidth           uint32         `protobuf:"varint,19,opt,name=vamana_beam_width,json=vamanaBeamWidth,proto3" json:"vamana_beam_width,omitempty"`
+	VamanaPqSubvectors        uint32         `protobuf:"varint,20,opt,name=vamana_pq_subvectors,json=vamanaPqSubvectors,proto3" json:"vamana_pq_subvectors,omitempty"`
+	IvfNlist                  uint32         `protobuf:"varint,21,opt,name=ivf_nlist,json=ivfNlist,proto3" json:"ivf_nlist,omitempty"`
+	IvfNprobe                 uint32         `protobuf:"varint,22,opt,name=ivf_nprobe,json=ivfNprobe,proto3" json:"ivf_nprobe,omitempty"`
+	IvfTrainSize              uint32         `protobuf:"varint,23,opt,name=ivf_train_size,json=ivfTrainSize,proto3" json:"ivf_train_size,omitempty"`
+	HnswPqM                   uint32         `protobuf:"varint,24,opt,name=hnsw_pq_m,json=hnswPqM,proto3" json:"hnsw_pq_m,omitempty"`
+	HnswPqEfConstruction      uint32         `protobuf:"varint,25,opt,name=hnsw_pq_ef_construction,json=hnswPqEfConstruction,proto3" json:"hnsw_pq_ef_construction,omitempty"`
+	HnswPqEf                  uint32         `protobuf:"varint,26,opt,name=hnsw_pq_ef,json=hnswPqEf,proto3" json:"hnsw_pq_ef,omitempty"`
+	HnswPqCentroids           uint32         `protobuf:"varint,27,opt,name=hnsw_pq_centroids,json=hnswPqCentroids,proto3" json:"hnsw_pq_centroids,omitempty"`
+	HnswPqSubvectors          uint32         `protobuf:"varint,28,opt,name=hnsw_pq_subvectors,json=hnswPqSubvectors,proto3" json:"hnsw_pq_subvectors,omitempty"`
+	HnswPqTrainSize           uint32         `protobuf:"varint,29,opt,name=hnsw_pq_train_size,json=hnswPqTrainSize,proto3" json:"hnsw_pq_train_size,omitempty"`
+	HnswPqOptimizedRotation   bool           `protobuf:"varint,30,opt,name=hnsw_pq_optimized_rotation,json=hnswPqOptimizedRotation,proto3" json:"hnsw_pq_optimized_rotation,omitempty"`
+	FlatShards                uint32         `protobuf:"varint,31,opt,name=flat_shards,json=flatShards,proto3" json:"flat_shards,omitempty"`
+	FlatPromoteThreshold      uint32         `protobuf:"varint,32,opt,name=flat_promote_threshold,json=flatPromoteThreshold,proto3" json:"flat_promote_threshold,omitempty"`
+	HnswSeed                  *int64         `protobuf:"varint,33,opt,name=hnsw_seed,json=hnswSeed,proto3,oneof" json:"hnsw_seed,omitempty"`
+	MaxQueryTimeMs            uint32         `protobuf:"varint,34,opt,name=max_query_time_ms,json=maxQueryTimeMs,proto3" json:"max_query_time_ms,omitempty"`
+	VectorFields              []*VectorField `protobuf:"bytes,35,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"`
+	TextFields                []*TextField   `protobuf:"bytes,36,rep,name=text_fields,json=textFields,proto3" json:"text_fields,omitempty"`
 }
 
 func (x *Collection) Reset() {
@@ -163,6 +186,281 @@ func (x *Collection) GetQuantization() string {
 	return ""
 }
 
+func (x *Collection) GetIndexType() string {
+	if x != nil {
+		return x.IndexType
+	}
+	return ""
+}
+
+func (x *Collection) GetVamanaMaxDegree() uint32 {
+	if x != nil {
+		return x.VamanaMaxDegree
+	}
+	return 0
+}
+
+func (x *Collection) GetVamanaBuildListSize() uint32 {
+	if x != nil {
+		return x.VamanaBuildListSize
+	}
+	return 0
+}
+
+func (x *Collection) GetVamanaSearchListSize() uint32 {
+	if x != nil {
+		return x.VamanaSearchListSize
+	}
+	return 0
+}
+
+func (x *Collection) GetVamanaAlpha() float32 {
+	if x != nil {
+		return x.VamanaAlpha
+	}
+	return 0
+}
+
+func (x *Collection) GetVamanaBeamWidth() uint32 {
+	if x != nil {
+		return x.VamanaBeamWidth
+	}
+	return 0
+}
+
+func (x *Collection) GetVamanaPqSubvectors() uint32 {
+	if x != nil {
+		return x.VamanaPqSubvectors
+	}
+	return 0
+}
+
+func (x *Collection) GetIvfNlist() uint32 {
+	if x != nil {
+		return x.IvfNlist
+	}
+	return 0
+}
+
+func (x *Collection) GetIvfNprobe() uint32 {
+	if x != nil {
+		return x.IvfNprobe
+	}
+	return 0
+}
+
+func (x *Collection) GetIvfTrainSize() uint32 {
+	if x != nil {
+		return x.IvfTrainSize
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqM() uint32 {
+	if x != nil {
+		return x.HnswPqM
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqEfConstruction() uint32 {
+	if x != nil {
+		return x.HnswPqEfConstruction
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqEf() uint32 {
+	if x != nil {
+		return x.HnswPqEf
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqCentroids() uint32 {
+	if x != nil {
+		return x.HnswPqCentroids
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqSubvectors() uint32 {
+	if x != nil {
+		return x.HnswPqSubvectors
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqTrainSize() uint32 {
+	if x != nil {
+		return x.HnswPqTrainSize
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswPqOptimizedRotation() bool {
+	if x != nil {
+		return x.HnswPqOptimizedRotation
+	}
+	return false
+}
+
+func (x *Collection) GetFlatShards() uint32 {
+	if x != nil {
+		return x.FlatShards
+	}
+	return 0
+}
+
+func (x *Collection) GetFlatPromoteThreshold() uint32 {
+	if x != nil {
+		return x.FlatPromoteThreshold
+	}
+	return 0
+}
+
+func (x *Collection) GetHnswSeed() int64 {
+	if x != nil && x.HnswSeed != nil {
+		return *x.HnswSeed
+	}
+	return 0
+}
+
+func (x *Collection) GetMaxQueryTimeMs() uint32 {
+	if x != nil {
+		return x.MaxQueryTimeMs
+	}
+	return 0
+}
+
+func (x *Collection) GetVectorFields() []*VectorField {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+func (x *Collection) GetTextFields() []*TextField {
+	if x != nil {
+		return x.TextFields
+	}
+	return nil
+}
+
+type VectorField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dim      uint32 `protobuf:"varint,2,opt,name=dim,proto3" json:"dim,omitempty"`
+	Distance string `protobuf:"bytes,3,opt,name=distance,proto3" json:"distance,omitempty"`
+}
+
+func (x *VectorField) Reset() {
+	*x = VectorField{}
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorField) ProtoMessage() {}
+
+func (x *VectorField) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorField.ProtoReflect.Descriptor instead.
+func (*VectorField) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_disk_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VectorField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VectorField) GetDim() uint32 {
+	if x != nil {
+		return x.Dim
+	}
+	return 0
+}
+
+func (x *VectorField) GetDistance() string {
+	if x != nil {
+		return x.Distance
+	}
+	return ""
+}
+
+type TextField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Analyzer string `protobuf:"bytes,2,opt,name=analyzer,proto3" json:"analyzer,omitempty"`
+}
+
+func (x *TextField) Reset() {
+	*x = TextField{}
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextField) ProtoMessage() {}
+
+func (x *TextField) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextField.ProtoReflect.Descriptor instead.
+func (*TextField) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_disk_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TextField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TextField) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
 type Dataset struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -172,11 +470,12 @@ type Dataset struct {
 	UserSpecificId     string           `protobuf:"bytes,2,opt,name=user_specific_id,json=userSpecificId,proto3" json:"user_specific_id,omitempty"`
 	Vector             []float32        `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
 	Metadata           *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	SparseVector       *SparseVector    `protobuf:"bytes,5,opt,name=sparse_vector,json=sparseVector,proto3" json:"sparse_vector,omitempty"`
 }
 
 func (x *Dataset) Reset() {
 	*x = Dataset{}
-	mi := &file_idl_proto_v3_disk_proto_msgTypes[1]
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -188,7 +487,7 @@ func (x *Dataset) String() string {
 func (*Dataset) ProtoMessage() {}
 
 func (x *Dataset) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_disk_proto_msgTypes[1]
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +500,7 @@ func (x *Dataset) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Dataset.ProtoReflect.Descriptor instead.
 func (*Dataset) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_disk_proto_rawDescGZIP(), []int{1}
+	return file_idl_proto_v3_disk_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Dataset) GetCollectionUniqueId() uint64 {
@@ -232,6 +531,66 @@ func (x *Dataset) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *Dataset) GetSparseVector() *SparseVector {
+	if x != nil {
+		return x.SparseVector
+	}
+	return nil
+}
+
+type SparseVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Indices []uint32  `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	Values  []float32 `protobuf:"fixed32,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SparseVector) Reset() {
+	*x = SparseVector{}
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SparseVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SparseVector) ProtoMessage() {}
+
+func (x *SparseVector) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SparseVector.ProtoReflect.Descriptor instead.
+func (*SparseVector) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_disk_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SparseVector) GetIndices() []uint32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+func (x *SparseVector) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 var File_idl_proto_v3_disk_proto protoreflect.FileDescriptor
 
 var file_idl_proto_v3_disk_proto_rawDesc = []byte{
@@ -239,7 +598,7 @@ var file_idl_proto_v3_disk_proto_rawDesc = []byte{
 	0x69, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xdd, 0x03, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x74, 0x6f, 0x22, 0xd2, 0x0b, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
 	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65,
@@ -269,19 +628,100 @@ var file_idl_proto_v3_disk_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x22,
 	0x0a, 0x0c, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0xb2, 0x01, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x30,
-	0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x69,
-	0x71, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x49, 0x64,
-	0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
-	0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x72,
-	0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x64, 0x69, 0x73,
-	0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x2a, 0x0a, 0x11, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x76, 0x61,
+	0x6d, 0x61, 0x6e, 0x61, 0x4d, 0x61, 0x78, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x33, 0x0a,
+	0x16, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x76,
+	0x61, 0x6d, 0x61, 0x6e, 0x61, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x35, 0x0a, 0x17, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x5f, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x14, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6d,
+	0x61, 0x6e, 0x61, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x18, 0x12, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x0b, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x2a, 0x0a, 0x11,
+	0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x5f, 0x62, 0x65, 0x61, 0x6d, 0x5f, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x42,
+	0x65, 0x61, 0x6d, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x30, 0x0a, 0x14, 0x76, 0x61, 0x6d, 0x61,
+	0x6e, 0x61, 0x5f, 0x70, 0x71, 0x5f, 0x73, 0x75, 0x62, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x76, 0x61, 0x6d, 0x61, 0x6e, 0x61, 0x50, 0x71,
+	0x53, 0x75, 0x62, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x76,
+	0x66, 0x5f, 0x6e, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69,
+	0x76, 0x66, 0x4e, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x76, 0x66, 0x5f, 0x6e,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x76, 0x66,
+	0x4e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x76, 0x66, 0x5f, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x69, 0x76, 0x66, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x09,
+	0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70, 0x71, 0x5f, 0x6d, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x4d, 0x12, 0x35, 0x0a, 0x17, 0x68, 0x6e, 0x73, 0x77,
+	0x5f, 0x70, 0x71, 0x5f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x68, 0x6e, 0x73, 0x77, 0x50,
+	0x71, 0x45, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1c, 0x0a, 0x0a, 0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70, 0x71, 0x5f, 0x65, 0x66, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x45, 0x66, 0x12, 0x2a, 0x0a,
+	0x11, 0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70, 0x71, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x6f, 0x69,
+	0x64, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71,
+	0x43, 0x65, 0x6e, 0x74, 0x72, 0x6f, 0x69, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x6e, 0x73,
+	0x77, 0x5f, 0x70, 0x71, 0x5f, 0x73, 0x75, 0x62, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18,
+	0x1c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x53, 0x75, 0x62,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x68, 0x6e, 0x73, 0x77, 0x5f,
+	0x70, 0x71, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x1d, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71, 0x54, 0x72, 0x61, 0x69, 0x6e,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x3b, 0x0a, 0x1a, 0x68, 0x6e, 0x73, 0x77, 0x5f, 0x70, 0x71, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x68, 0x6e, 0x73, 0x77, 0x50, 0x71,
+	0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73,
+	0x18, 0x1f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x66, 0x6c, 0x61, 0x74, 0x53, 0x68, 0x61, 0x72,
+	0x64, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x66, 0x6c, 0x61, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x20, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x14, 0x66, 0x6c, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x20, 0x0a, 0x09, 0x68, 0x6e, 0x73, 0x77,
+	0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x21, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x68,
+	0x6e, 0x73, 0x77, 0x53, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x11, 0x6d, 0x61,
+	0x78, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18,
+	0x22, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
+	0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x23, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64,
+	0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x18, 0x24, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x69, 0x73, 0x6b, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0a, 0x74,
+	0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x68, 0x6e,
+	0x73, 0x77, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x0b, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x64, 0x69, 0x6d, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x3b, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6e, 0x61,
+	0x6c, 0x79, 0x7a, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x6e, 0x61,
+	0x6c, 0x79, 0x7a, 0x65, 0x72, 0x22, 0xf0, 0x01, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x12, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x71, 0x75,
+	0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x70, 0x65, 0x63,
+	0x69, 0x66, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x75,
+	0x73, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a, 0x0d, 0x73, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x64, 0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x73, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x40, 0x0a, 0x0c, 0x53, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69,
+	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x02, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f,
+	0x64, 0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -296,19 +736,25 @@ func file_idl_proto_v3_disk_proto_rawDescGZIP() []byte {
 	return file_idl_proto_v3_disk_proto_rawDescData
 }
 
-var file_idl_proto_v3_disk_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_idl_proto_v3_disk_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_idl_proto_v3_disk_proto_goTypes = []any{
 	(*Collection)(nil),      // 0: diskproto.Collection
-	(*Dataset)(nil),         // 1: diskproto.Dataset
-	(*structpb.Struct)(nil), // 2: google.protobuf.Struct
+	(*VectorField)(nil),     // 1: diskproto.VectorField
+	(*TextField)(nil),       // 2: diskproto.TextField
+	(*Dataset)(nil),         // 3: diskproto.Dataset
+	(*SparseVector)(nil),    // 4: diskproto.SparseVector
+	(*structpb.Struct)(nil), // 5: google.protobuf.Struct
 }
 var file_idl_proto_v3_disk_proto_depIdxs = []int32{
-	2, // 0: diskproto.Dataset.metadata:type_name -> google.protobuf.Struct
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 0: diskproto.Collection.vector_fields:type_name -> diskproto.VectorField
+	2, // 1: diskproto.Collection.text_fields:type_name -> diskproto.TextField
+	5, // 2: diskproto.Dataset.metadata:type_name -> google.protobuf.Struct
+	4, // 3: diskproto.Dataset.sparse_vector:type_name -> diskproto.SparseVector
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_idl_proto_v3_disk_proto_init() }
@@ -316,13 +762,14 @@ func file_idl_proto_v3_disk_proto_init() {
 	if File_idl_proto_v3_disk_proto != nil {
 		return
 	}
+	file_idl_proto_v3_disk_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_proto_v3_disk_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,81 @@
+package performance_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/sjy-dv/nnv/core/vectorindex"
+	"github.com/sjy-dv/nnv/pkg/distance"
+)
+
+const (
+	hnswBenchSize = 10_000
+	hnswBenchDim  = 128
+)
+
+func buildHnswIndex(size, dim int) (*vectorindex.Hnsw, [][]float32) {
+	index := vectorindex.NewHnsw(uint(dim), distance.NewEuclidean())
+	vectors := make([][]float32, size)
+	for i := range vectors {
+		vectors[i] = generateRandomVector(dim)
+		index.Insert(uint64(i), vectors[i], vectorindex.Metadata{}, index.RandomLevel())
+	}
+	return index, vectors
+}
+
+func BenchmarkHnswInsert(b *testing.B) {
+	runtime.GC()
+	var mBefore, mAfter runtime.MemStats
+	runtime.ReadMemStats(&mBefore)
+	b.N = hnswBenchSize
+	b.ReportAllocs()
+	index := vectorindex.NewHnsw(hnswBenchDim, distance.NewEuclidean())
+	for i := 0; i < b.N; i++ {
+		index.Insert(uint64(i), generateRandomVector(hnswBenchDim), vectorindex.Metadata{}, index.RandomLevel())
+	}
+	b.StopTimer()
+	runtime.GC()
+	runtime.ReadMemStats(&mAfter)
+	memUsedMB := float64(mAfter.HeapAlloc-mBefore.HeapAlloc) / (1024 * 1024)
+	b.Logf("Estimated memory used by index: %.2f mb, heap objects: %d\n", memUsedMB, mAfter.HeapObjects-mBefore.HeapObjects)
+	runtime.KeepAlive(index)
+}
+
+// 10k x 128d, euclidean, default config
+// map[*hnswVertex]float32 edges, per level mutex, vector per vertex
+// BenchmarkHnswInsert    	   10000	   1399458 ns/op	  216143 B/op	    1061 allocs/op
+//     performance_hnsw_test.go:41: Estimated memory used by index: 15.62 mb, heap objects: 103610
+// vector arena, uint32 neighbour lists, striped locks
+// BenchmarkHnswInsert    	   10000	    526274 ns/op	   50074 B/op	    1789 allocs/op
+//     performance_hnsw_test.go:41: Estimated memory used by index: 9.19 mb, heap objects: 11447
+
+func BenchmarkHnswSearch(b *testing.B) {
+	index, vectors := buildHnswIndex(hnswBenchSize, hnswBenchDim)
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Search(context.Background(), vectors[i%len(vectors)], 10)
+	}
+}
+
+// map edges:   BenchmarkHnswSearch    	    5000	    204042 ns/op	   23625 B/op	     109 allocs/op
+// arena edges: BenchmarkHnswSearch    	    5000	     77492 ns/op	    5701 B/op	     196 allocs/op
+
+func BenchmarkHnswSearchParallel(b *testing.B) {
+	index, vectors := buildHnswIndex(hnswBenchSize, hnswBenchDim)
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			index.Search(context.Background(), vectors[i%len(vectors)], 10)
+			i++
+		}
+	})
+}
+
+// map edges:   BenchmarkHnswSearchParallel 	    5000	    167416 ns/op	   23500 B/op	     109 allocs/op
+// arena edges: BenchmarkHnswSearchParallel 	    5000	     65967 ns/op	    5681 B/op	     195 allocs/op
@@ -101,3 +101,52 @@ func (this *Cosine) String() string {
 func (this *Cosine) Type() string {
 	return "cosine-dot"
 }
+
+// InnerProduct ranks by the negated dot product (maximum inner product search),
+// vectors are not normalized so magnitudes are kept.
+type InnerProduct struct{ space }
+
+func NewInnerProduct() Space {
+	return &InnerProduct{newSpace()}
+}
+
+func (this *InnerProduct) Distance(a, b []float32) float32 {
+	var dot float32
+	for i := 0; i < len(a); i++ {
+		dot += a[i] * b[i]
+	}
+	return -dot
+}
+
+func (this *InnerProduct) String() string {
+	return "inner-product"
+}
+
+func (this *InnerProduct) Type() string {
+	return "inner-product"
+}
+
+// Hamming counts the positions where two binary (0/1) vectors differ.
+type Hamming struct{ space }
+
+func NewHamming() Space {
+	return &Hamming{newSpace()}
+}
+
+func (this *Hamming) Distance(a, b []float32) float32 {
+	var distance float32
+	for i := 0; i < len(a); i++ {
+		if (a[i] > 0.5) != (b[i] > 0.5) {
+			distance++
+		}
+	}
+	return distance
+}
+
+func (this *Hamming) String() string {
+	return "hamming"
+}
+
+func (this *Hamming) Type() string {
+	return "hamming"
+}
@@ -1,11 +1,11 @@
 package distancepq
 
 import (
-	"math"
 	"math/bits"
 
 	"github.com/sjy-dv/nnv/edge"
 	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
 )
 
 type FloatDistFunc func(x, y []float32) float32
@@ -24,22 +24,10 @@ func cosineDistance(x, y []float32) float32 {
 	return 1 - dotProductImpl(x, y)
 }
 
-const degToRad = math.Pi / 180
-
-// Earth radius in meters
-const earthRadius = 6371000
-
-// Computes the haversine distance between two points on the Earth's surface. It
-// assumes [lat, long] coordinates in degrees.
-// Formula credit: https://scikit-learn.org/stable/modules/generated/sklearn.metrics.pairwise.haversine_distances.html
+// Computes the haversine distance in meters between two points on the Earth's
+// surface. It assumes [lat, long] coordinates in degrees.
 func haversineDistance(x, y []float32) float32 {
-	latx, lonx, laty, lony := float64(x[0])*degToRad, float64(x[1])*degToRad, float64(y[0])*degToRad, float64(y[1])*degToRad
-	dlat, dlon := latx-laty, lonx-lony
-	// Please see the formula in the link above for more details.
-	sinDlat, sinDlon := math.Sin(dlat/2), math.Sin(dlon/2)
-	a := sinDlat*sinDlat + math.Cos(latx)*math.Cos(laty)*sinDlon*sinDlon
-	c := 2 * math.Asin(math.Sqrt(a))
-	return float32(earthRadius * c)
+	return float32(gomath.Haversine(float64(x[0]), float64(x[1]), float64(y[0]), float64(y[1])))
 }
 
 func hammingDistance(x, y []uint64) float32 {
@@ -66,6 +54,12 @@ func jaccardDistance(x, y []uint64) float32 {
 	return 1 - float32(intersection)/float32(union)
 }
 
+// Returns the squared euclidean distance function, asm accelerated when supported.
+// Unlike the euclidean distance it is additive over subvectors.
+func SquaredEuclidean() FloatDistFunc {
+	return euclideanDistance
+}
+
 // Returns floating distance function by name.
 func GetFloatDistanceFn(name string) FloatDistFunc {
 	switch name {
@@ -73,6 +67,12 @@ func GetFloatDistanceFn(name string) FloatDistFunc {
 		return distance.NewEuclidean().Distance
 	case edge.COSINE:
 		return distance.NewCosine().Distance
+	case edge.INNER_PRODUCT:
+		return dotProductDistance
+	case edge.MANHATTAN:
+		return distance.NewManhattan().Distance
+	case edge.HAMMING:
+		return distance.NewHamming().Distance
 	default:
 		return distance.NewEuclidean().Distance
 	}
@@ -100,3 +100,21 @@ func MaxInt(values ...int) int {
 	}
 	return max
 }
+
+const degToRad = math.Pi / 180
+
+// Earth radius in meters
+const EarthRadius = 6371000
+
+// Haversine returns the great-circle distance in meters between two [lat, lon]
+// points given in degrees.
+// Formula credit: https://scikit-learn.org/stable/modules/generated/sklearn.metrics.pairwise.haversine_distances.html
+func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	latx, lonx, laty, lony := lat1*degToRad, lon1*degToRad, lat2*degToRad, lon2*degToRad
+	dlat, dlon := latx-laty, lonx-lony
+	// Please see the formula in the link above for more details.
+	sinDlat, sinDlon := math.Sin(dlat/2), math.Sin(dlon/2)
+	a := sinDlat*sinDlat + math.Cos(latx)*math.Cos(laty)*sinDlon*sinDlon
+	c := 2 * math.Asin(math.Sqrt(math.Min(1, a)))
+	return EarthRadius * c
+}
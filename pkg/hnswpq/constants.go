@@ -3,4 +3,8 @@ package hnswpq
 var (
 	pointPQSetErr = "failed to set PQ point: %v"
 	pointPQGetErr = "failed to get PQ point: %v"
+
+	retrainInProgressErr = "codebook retraining is already in progress: %s"
+	retrainSampleErr     = "not enough points to retrain codebook: %d < %d"
+	codebookSizeErr      = "codebook %s holds %d values, expected %d"
 )
@@ -83,7 +83,13 @@ func (xx *HnswPQs) DropCollection(collectionName string) error {
 // when commitId is zero, reuse empty node space
 func (xx *HnswPQs) Insert(collectionName string, commitID uint64, vec gomath.Vector) error {
 
+	xx.Collections[collectionName].PQ.codebookLock.RLock()
+	defer xx.Collections[collectionName].PQ.codebookLock.RUnlock()
+
+	vec = xx.Collections[collectionName].PQ.prepare(vec)
 	centroidIds := xx.Collections[collectionName].PQ.encode(vec)
+	// query side of the ADC lives in the (rotated) code space
+	query := xx.Collections[collectionName].PQ.project(vec)
 	node := Node{
 		Vectors:   vec,
 		Layer:     int(math.Floor(-math.Log(rand.Float64()) * xx.Collections[collectionName].Ml)),
@@ -118,7 +124,7 @@ func (xx *HnswPQs) Insert(collectionName string, commitID uint64, vec gomath.Vec
 	xx.Collections[collectionName].PQ.Dirty(nodeId)
 
 	curObj := &xx.Collections[collectionName].NodeList.Nodes[xx.Collections[collectionName].Ep]
-	curDist := xx.Collections[collectionName].PQ.DistanceFromCentroidIDs(vec, curObj.Centroids)
+	curDist := xx.Collections[collectionName].PQ.DistanceFromCentroidIDs(query, curObj.Centroids)
 
 	heapCandidates := &queue.PriorityQueue{Order: false, Items: make([]*queue.Item, 0)}
 	heap.Init(heapCandidates)
@@ -126,7 +132,7 @@ func (xx *HnswPQs) Insert(collectionName string, commitID uint64, vec gomath.Vec
 
 	for level := min(int(node.Layer), int(xx.Collections[collectionName].MaxLevel)); level >= 0; level-- {
 		err := xx.Collections[collectionName].searchLayer(
-			vec,
+			query,
 			&queue.Item{Distance: curDist, NodeID: curObj.Id},
 			heapCandidates,
 			int(xx.Collections[collectionName].Efconstruction),
@@ -169,6 +175,9 @@ func (xx *HnswPQs) Insert(collectionName string, commitID uint64, vec gomath.Vec
 
 func (xx *HnswPQs) Search(collectionName string, vec gomath.Vector, topCandidates *queue.PriorityQueue, K int, efSearch int) error {
 	pq := xx.Collections[collectionName].PQ
+	pq.codebookLock.RLock()
+	defer pq.codebookLock.RUnlock()
+	vec = pq.project(pq.prepare(vec))
 
 	distFn := func(q []float32, centroids []uint8) float32 {
 		return pq.DistanceFromCentroidIDs(q, centroids)
@@ -203,6 +212,8 @@ func (xx *HnswPQs) Search(collectionName string, vec gomath.Vector, topCandidate
 
 	for _, item := range heapCandidates.Items {
 		if !duplicator[item.NodeID] {
+			// monotonic, the heap order is preserved
+			item.Distance = pq.ToDistance(item.Distance)
 			heap.Push(topCandidates, item)
 			duplicator[item.NodeID] = true
 			continue
@@ -224,6 +235,11 @@ func (xx *HnswPQs) Search(collectionName string, vec gomath.Vector, topCandidate
 	return nil
 }
 
+// Distance returns the distance.Space type of the distances reported by Search.
+func (xx *HnswPQs) Distance(collectionName string) string {
+	return xx.Collections[collectionName].PQ.DistanceType()
+}
+
 func (xx *HnswPQs) FailAppointNode(collectioName string, failID uint64) error {
 	xx.Collections[collectioName].hlock.Lock()
 	defer xx.Collections[collectioName].hlock.Unlock()
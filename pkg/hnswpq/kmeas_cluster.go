@@ -60,7 +60,8 @@ func (km *KMeans) Fit(X [][]float32) {
 	km.Centroids = make([][]float32, km.K)
 	randId := rand.Intn(len(X))
 	alreadyCentroid[randId] = struct{}{}
-	km.Centroids[0] = X[randId][km.Offset : km.Offset+km.VectorLen]
+	// centroids are copied, the update stage must not write into the samples
+	km.Centroids[0] = cloneSubVector(X[randId][km.Offset : km.Offset+km.VectorLen])
 	// For the remainder find the furthest point from the existing centroids
 	for i := 1; i < km.K; i++ {
 		furthestDist := float32(0)
@@ -79,7 +80,7 @@ func (km *KMeans) Fit(X [][]float32) {
 				furthestId = j
 			}
 		}
-		km.Centroids[i] = X[furthestId][km.Offset : km.Offset+km.VectorLen]
+		km.Centroids[i] = cloneSubVector(X[furthestId][km.Offset : km.Offset+km.VectorLen])
 	}
 	logger.Debug().Dur("duration", time.Since(startTime)).Msg("initialising centroids")
 	// ---------------------------
@@ -148,3 +149,9 @@ func (km *KMeans) Fit(X [][]float32) {
 	}
 	logger.Debug().Dur("duration", time.Since(startTime)).Msg("fitting KMeans")
 }
+
+func cloneSubVector(v []float32) []float32 {
+	out := make([]float32, len(v))
+	copy(out, v)
+	return out
+}
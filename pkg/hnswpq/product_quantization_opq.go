@@ -0,0 +1,438 @@
+package hnswpq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"slices"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+/* Optimized product quantization (Ge et al. 2013) learns an orthogonal rotation R
+ * which is applied before the vectors are split into subvectors. A fixed split
+ * keeps correlated dimensions in the same subspace and wastes centroids, the
+ * rotation spreads the variance so every subquantizer carries its share.
+ *
+ * The non-parametric solution alternates two steps:
+ *   1. with R fixed, fit the subspace codebooks on RX and reconstruct Y
+ *   2. with Y fixed, R = argmin ||RX - Y|| which is the orthogonal Procrustes
+ *      problem, solved by the polar factor of M = sum y x^T
+ * Because R is orthogonal, euclidean distances (and so the cosine mapping) are
+ * preserved. */
+
+const (
+	defaultRotationIterations = 5
+	// k-means iterations used while the rotation is still moving
+	rotationKMeansIter = 20
+	codebookKMeansIter = 100
+	polarMaxIter       = 30
+)
+
+type pqCodebook struct {
+	rotation      []float32
+	flatCentroids []float32
+	centroidDists []float32
+}
+
+// trainCodebook learns the (optionally rotated) codebook for the given prepared
+// vectors and returns it with the codes of every vector.
+func (pq *productQuantizer) trainCodebook(vectors [][]float32) (*pqCodebook, [][]uint8) {
+	var rotation []float32
+	if pq.params.OptimizedRotation {
+		rotation = pq.learnRotation(vectors)
+		vectors = rotateAll(rotation, vectors)
+	}
+	flatCentroids, centroidDists, codes := pq.fitSubspaces(vectors, codebookKMeansIter)
+	return &pqCodebook{
+		rotation:      rotation,
+		flatCentroids: flatCentroids,
+		centroidDists: centroidDists,
+	}, codes
+}
+
+func (pq *productQuantizer) learnRotation(vectors [][]float32) []float32 {
+	dim := pq.originalVectorLen
+	iterations := pq.params.RotationIterations
+	if iterations <= 0 {
+		iterations = defaultRotationIterations
+	}
+	rotation := identity(dim)
+	for it := 0; it < iterations; it++ {
+		rotated := rotateAll(rotation, vectors)
+		flatCentroids, _, codes := pq.fitSubspaces(rotated, rotationKMeansIter)
+
+		// M = sum y x^T, accumulated in float64
+		m := make([]float64, dim*dim)
+		y := make([]float32, dim)
+		for n, x := range vectors {
+			for i := 0; i < pq.params.NumSubVectors; i++ {
+				start, end := pq.flatCentroidSlice(i, int(codes[n][i]))
+				copy(y[i*pq.subVectorLen:(i+1)*pq.subVectorLen], flatCentroids[start:end])
+			}
+			for r := 0; r < dim; r++ {
+				if y[r] == 0 {
+					continue
+				}
+				yr := float64(y[r])
+				row := m[r*dim : (r+1)*dim]
+				for c := 0; c < dim; c++ {
+					row[c] += yr * float64(x[c])
+				}
+			}
+		}
+		polar, ok := polarFactor(m, dim)
+		if !ok {
+			log.Warn().Int("iteration", it).Msg("opq rotation did not converge, keeping previous rotation")
+			break
+		}
+		for i := range rotation {
+			rotation[i] = float32(polar[i])
+		}
+	}
+	return rotation
+}
+
+// rotate returns R*v, the vector itself when no rotation is learned.
+func rotate(rotation []float32, vector []float32) []float32 {
+	if len(rotation) == 0 {
+		return vector
+	}
+	dim := len(vector)
+	out := make(gomath.Vector, dim)
+	for i := 0; i < dim; i++ {
+		row := rotation[i*dim : (i+1)*dim]
+		var sum float32
+		for j, v := range vector {
+			sum += row[j] * v
+		}
+		out[i] = sum
+	}
+	return out
+}
+
+func rotateAll(rotation []float32, vectors [][]float32) [][]float32 {
+	if len(rotation) == 0 {
+		return vectors
+	}
+	out := make([][]float32, len(vectors))
+	for i, v := range vectors {
+		out[i] = rotate(rotation, v)
+	}
+	return out
+}
+
+func identity(dim int) []float32 {
+	m := make([]float32, dim*dim)
+	for i := 0; i < dim; i++ {
+		m[i*dim+i] = 1
+	}
+	return m
+}
+
+// polarFactor returns the orthogonal factor U of M = U*P using the scaled
+// Newton iteration Z <- (g*Z + (g*Z)^-T) / 2, false when it does not converge
+// within polarMaxIter.
+func polarFactor(m []float64, dim int) ([]float64, bool) {
+	z := append([]float64(nil), m...)
+	for it := 0; it < polarMaxIter; it++ {
+		inv, ok := invert(z, dim)
+		if !ok {
+			// rank deficient M (e.g. duplicate samples), regularize and retry
+			for i := 0; i < dim; i++ {
+				z[i*dim+i] += 1e-6
+			}
+			if inv, ok = invert(z, dim); !ok {
+				return nil, false
+			}
+		}
+		// Higham's scaling keeps the iteration from stalling on badly scaled M
+		g := math.Sqrt(frobenius(inv) / frobenius(z))
+		next := make([]float64, dim*dim)
+		var delta float64
+		for r := 0; r < dim; r++ {
+			for c := 0; c < dim; c++ {
+				v := 0.5 * (g*z[r*dim+c] + inv[c*dim+r]/g)
+				d := v - z[r*dim+c]
+				delta += d * d
+				next[r*dim+c] = v
+			}
+		}
+		z = next
+		if delta < 1e-12*float64(dim) {
+			return z, true
+		}
+	}
+	return nil, false
+}
+
+func frobenius(m []float64) float64 {
+	var sum float64
+	for _, v := range m {
+		sum += v * v
+	}
+	return math.Sqrt(sum)
+}
+
+// invert computes the inverse with Gauss-Jordan elimination and partial pivoting.
+func invert(m []float64, dim int) ([]float64, bool) {
+	a := append([]float64(nil), m...)
+	inv := make([]float64, dim*dim)
+	for i := 0; i < dim; i++ {
+		inv[i*dim+i] = 1
+	}
+	for col := 0; col < dim; col++ {
+		pivot := col
+		for r := col + 1; r < dim; r++ {
+			if math.Abs(a[r*dim+col]) > math.Abs(a[pivot*dim+col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot*dim+col]) < 1e-12 {
+			return nil, false
+		}
+		if pivot != col {
+			for c := 0; c < dim; c++ {
+				a[col*dim+c], a[pivot*dim+c] = a[pivot*dim+c], a[col*dim+c]
+				inv[col*dim+c], inv[pivot*dim+c] = inv[pivot*dim+c], inv[col*dim+c]
+			}
+		}
+		p := 1 / a[col*dim+col]
+		for c := 0; c < dim; c++ {
+			a[col*dim+c] *= p
+			inv[col*dim+c] *= p
+		}
+		for r := 0; r < dim; r++ {
+			if r == col {
+				continue
+			}
+			f := a[r*dim+col]
+			if f == 0 {
+				continue
+			}
+			for c := 0; c < dim; c++ {
+				a[r*dim+c] -= f * a[col*dim+c]
+				inv[r*dim+c] -= f * inv[col*dim+c]
+			}
+		}
+	}
+	return inv, true
+}
+
+// RetrainCodebook refits the codebook on a reservoir sample of the cached points and
+// re-encodes every point off to the side. Searches keep using the old codes until
+// the new ones are swapped in. The returned channel receives the result once.
+func (xx *HnswPQs) RetrainCodebook(collectionName string, sampleSize int) <-chan error {
+	result := make(chan error, 1)
+	xx.gLock.RLock()
+	index, ok := xx.Collections[collectionName]
+	xx.gLock.RUnlock()
+	if !ok {
+		result <- fmt.Errorf("collection %s not found", collectionName)
+		return result
+	}
+	pq := index.PQ
+	if !atomic.CompareAndSwapInt32(&pq.retraining, 0, 1) {
+		result <- fmt.Errorf(retrainInProgressErr, collectionName)
+		return result
+	}
+	pq.trackDirty()
+	go func() {
+		defer atomic.StoreInt32(&pq.retraining, 0)
+		defer pq.takeDirty()
+		defer func() {
+			if r := recover(); r != nil {
+				result <- fmt.Errorf("codebook retraining panic: %v", r)
+			}
+		}()
+		codebook, codes, err := pq.retrain(sampleSize)
+		if err != nil {
+			result <- err
+			return
+		}
+		index.swapCodebook(codebook, codes)
+		log.Debug().Str("collection", collectionName).Int("points", len(codes)).Msg("codebook retrained")
+		result <- nil
+	}()
+	return result
+}
+
+// retrain trains a new codebook and encodes the current points with it without
+// touching the codebook in use.
+func (pq *productQuantizer) retrain(sampleSize int) (*pqCodebook, map[uint64][]uint8, error) {
+	if sampleSize <= 0 {
+		sampleSize = pq.params.TriggerThreshold
+	}
+	sample := make([][]float32, 0, sampleSize)
+	seen := 0
+	err := pq.caches.ForEach(func(id uint64, point *productQuantizedPoint) error {
+		if len(sample) < sampleSize {
+			sample = append(sample, point.Vector)
+		} else if j := rand.Intn(seen + 1); j < sampleSize {
+			sample[j] = point.Vector
+		}
+		seen++
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("collect vectors in cache memory fails : %v", err)
+	}
+	if len(sample) < pq.params.NumCentroids {
+		return nil, nil, fmt.Errorf(retrainSampleErr, len(sample), pq.params.NumCentroids)
+	}
+	codebook, _ := pq.trainCodebook(sample)
+
+	codes := make(map[uint64][]uint8, seen)
+	err = pq.caches.ForEach(func(id uint64, point *productQuantizedPoint) error {
+		codes[id] = pq.encodeWith(codebook.rotation, codebook.flatCentroids, point.Vector)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode vectors in cache memory fails : %v", err)
+	}
+	return codebook, codes, nil
+}
+
+func (pq *productQuantizer) trackDirty() {
+	pq.dirtyLock.Lock()
+	pq.dirty = make(map[uint64]struct{})
+	pq.dirtyLock.Unlock()
+}
+
+func (pq *productQuantizer) markDirty(id uint64) {
+	pq.dirtyLock.Lock()
+	if pq.dirty != nil {
+		pq.dirty[id] = struct{}{}
+	}
+	pq.dirtyLock.Unlock()
+}
+
+// takeDirty ends the tracking and gives the points Set since it started.
+func (pq *productQuantizer) takeDirty() map[uint64]struct{} {
+	pq.dirtyLock.Lock()
+	defer pq.dirtyLock.Unlock()
+	dirty := pq.dirty
+	pq.dirty = nil
+	return dirty
+}
+
+// swapCodebook installs the retrained codebook, points inserted while retraining
+// are encoded here. So are points Set again after the snapshot was encoded,
+// their code in codes is of the old vector.
+func (xx *Hnsw) swapCodebook(codebook *pqCodebook, codes map[uint64][]uint8) {
+	pq := xx.PQ
+	pq.codebookLock.Lock()
+	defer pq.codebookLock.Unlock()
+
+	// Set runs under the read lock, nothing is marked past this point
+	dirty := pq.takeDirty()
+	codeOf := func(id uint64, vector []float32) []uint8 {
+		if _, changed := dirty[id]; !changed {
+			if code, ok := codes[id]; ok {
+				return code
+			}
+		}
+		return pq.encodeWith(codebook.rotation, codebook.flatCentroids, vector)
+	}
+
+	pq.caches.cLock.Lock()
+	for id, item := range pq.caches.caches {
+		item.value.CentroidIds = codeOf(id, item.value.Vector)
+		item.value.isDirty = true
+		item.IsDirty = true
+	}
+	pq.rotation = codebook.rotation
+	pq.flatCentroids = codebook.flatCentroids
+	pq.centroidDists = codebook.centroidDists
+	pq.isFit = true
+	pq.isPreTrain = false
+	pq.caches.cLock.Unlock()
+
+	xx.NodeList.lock.Lock()
+	for i := range xx.NodeList.Nodes {
+		node := &xx.NodeList.Nodes[i]
+		if node.IsEmpty || node.Vectors == nil {
+			continue
+		}
+		if i == 0 {
+			// genesis node is not cached
+			node.Centroids = pq.encode(node.Vectors)
+			continue
+		}
+		node.Centroids = codeOf(node.Id, node.Vectors)
+	}
+	xx.NodeList.lock.Unlock()
+}
+
+/* CommitCodebook writes the codebook of a collection, the learned rotation, the
+ * centroids and their distance table. A retrained codebook is fit on the
+ * collection, LoadCodebook brings it back instead of fitting again. */
+func (xx *HnswPQs) CommitCodebook(collectionName string, w io.Writer) error {
+	xx.gLock.RLock()
+	index, ok := xx.Collections[collectionName]
+	xx.gLock.RUnlock()
+	if !ok {
+		return fmt.Errorf("collection %s not found", collectionName)
+	}
+	pq := index.PQ
+	pq.codebookLock.RLock()
+	defer pq.codebookLock.RUnlock()
+	for _, part := range [][]float32{pq.rotation, pq.flatCentroids, pq.centroidDists} {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(part))); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCodebook swaps in a committed codebook, the cached points and the nodes
+// are encoded with it.
+func (xx *HnswPQs) LoadCodebook(collectionName string, r io.Reader) error {
+	xx.gLock.RLock()
+	index, ok := xx.Collections[collectionName]
+	xx.gLock.RUnlock()
+	if !ok {
+		return fmt.Errorf("collection %s not found", collectionName)
+	}
+	pq := index.PQ
+	dim := pq.originalVectorLen
+	centroids := pq.params.NumCentroids * pq.params.NumSubVectors
+	parts := make([][]float32, 3)
+	for i, part := range []struct {
+		name  string
+		sizes []int
+	}{
+		// no rotation is learned without OptimizedRotation
+		{"rotation", []int{0, dim * dim}},
+		{"centroids", []int{centroids * pq.subVectorLen}},
+		{"centroid distances", []int{centroids * pq.params.NumCentroids}},
+	} {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		if !slices.Contains(part.sizes, int(size)) {
+			return fmt.Errorf(codebookSizeErr, part.name, size, part.sizes[len(part.sizes)-1])
+		}
+		if size == 0 {
+			continue
+		}
+		parts[i] = make([]float32, size)
+		if err := binary.Read(r, binary.BigEndian, parts[i]); err != nil {
+			return err
+		}
+	}
+	index.swapCodebook(&pqCodebook{
+		rotation:      parts[0],
+		flatCentroids: parts[1],
+		centroidDists: parts[2],
+	}, nil)
+	return nil
+}
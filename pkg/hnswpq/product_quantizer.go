@@ -6,7 +6,9 @@ import (
 	"math"
 	"sync"
 
+	"github.com/sjy-dv/nnv/core/vectorindex"
 	"github.com/sjy-dv/nnv/edge"
+	"github.com/sjy-dv/nnv/pkg/distance"
 	"github.com/sjy-dv/nnv/pkg/distancepq"
 	"github.com/sjy-dv/nnv/pkg/gomath"
 	"github.com/sjy-dv/nnv/pkg/models"
@@ -22,6 +24,20 @@ type productQuantizer struct {
 	caches        *ProductQuantizerCache
 	centroidDists []float32
 	flatCentroids []float32
+	// rotation is the learned OPQ rotation (row-major dim x dim), nil when disabled
+	rotation []float32
+	// codebookLock is held for writing while a retrained codebook is swapped in
+	codebookLock sync.RWMutex
+	retraining   int32
+	// dirty holds the points Set while a retrain encodes its snapshot, nil
+	// when no retrain runs
+	dirty     map[uint64]struct{}
+	dirtyLock sync.Mutex
+
+	// normalize is set for cosine collections, every vector (stored or queried)
+	// is scaled to unit length so the squared euclidean ADC can be mapped back
+	// to a cosine distance.
+	normalize bool
 
 	//
 	isFit      bool
@@ -84,14 +100,22 @@ func newProductQuantizer(distFnName string, params models.ProductQuantizerParame
 
 	// ---
 
+	// So for cosine we normalize on ingest and query, train and encode with the
+	// squared euclidean distance (which is additive over subvectors) and map the
+	// ADC result back with ||x-y||^2 = 2 * (1 - cos(x, y)).
+	normalize := false
 	if distFnName == edge.COSINE {
 		distFnName = edge.EUCLIDEAN
+		normalize = true
 	}
 
 	if params.NumCentroids > 256 {
 		return nil, errors.New("There can be no more than 256 centroids.")
 	}
 	distFn := distancepq.GetFloatDistanceFn(distFnName)
+	if normalize {
+		distFn = distancepq.SquaredEuclidean()
+	}
 
 	pq := &productQuantizer{
 		params:            params,
@@ -102,6 +126,7 @@ func newProductQuantizer(distFnName string, params models.ProductQuantizerParame
 		centroidDists:     make([]float32, 0),
 		flatCentroids:     make([]float32, 0),
 		caches:            newCachePQ(),
+		normalize:         normalize,
 	}
 	// if alraedy centroid info => load
 
@@ -120,20 +145,58 @@ func (pq *productQuantizer) SubVectorLen() int {
 	return pq.subVectorLen
 }
 
-func (pq productQuantizer) centroidDistIdx(subvector, centroidX, centroidY int) int {
+// prepare returns the vector in the space the quantizer was trained on.
+func (pq *productQuantizer) prepare(vector gomath.Vector) gomath.Vector {
+	if pq.normalize {
+		return vectorindex.Normalize(vector)
+	}
+	return vector
+}
+
+// ToDistance converts a raw ADC distance into the distance reported to callers.
+// For cosine collections this is the cosine distance (1 - cos) in [0, 2], the same
+// scale distance.Cosine produces, so scores can be normalized like non-quantized collections.
+func (pq *productQuantizer) ToDistance(adc float32) float32 {
+	if !pq.normalize {
+		return adc
+	}
+	return float32(math.Min(2, math.Max(0, float64(adc)/2)))
+}
+
+// DistanceType reports the distance.Space type the returned distances are expressed in.
+func (pq *productQuantizer) DistanceType() string {
+	if pq.normalize {
+		return distance.NewCosine().Type()
+	}
+	return distance.NewEuclidean().Type()
+}
+
+// project rotates a prepared vector into the space the codebooks were trained on.
+func (pq *productQuantizer) project(vector gomath.Vector) gomath.Vector {
+	return rotate(pq.rotation, vector)
+}
+
+func (pq *productQuantizer) centroidDistIdx(subvector, centroidX, centroidY int) int {
 	return subvector*pq.params.NumCentroids*pq.params.NumCentroids + centroidX*pq.params.NumCentroids + centroidY
 }
 
-func (pq productQuantizer) flatCentroidSlice(subvector, centroid int) (start, end int) {
+func (pq *productQuantizer) flatCentroidSlice(subvector, centroid int) (start, end int) {
 	start = subvector*pq.params.NumCentroids*pq.subVectorLen + centroid*pq.subVectorLen
 	end = start + pq.subVectorLen
 	return
 }
 
-func (pq productQuantizer) encode(vector []float32) []uint8 {
-	if len(pq.flatCentroids) == 0 {
+func (pq *productQuantizer) encode(vector []float32) []uint8 {
+	return pq.encodeWith(pq.rotation, pq.flatCentroids, vector)
+}
+
+// encodeWith encodes the vector against the given rotation and centroids, which
+// lets a retrained codebook encode points before it is swapped in.
+func (pq *productQuantizer) encodeWith(rotation, flatCentroids []float32, vector []float32) []uint8 {
+	if len(flatCentroids) == 0 {
 		return nil
 	}
+	vector = rotate(rotation, vector)
 	/* We will now find the closest centroid for each subvector. */
 	encoded := make([]uint8, pq.params.NumSubVectors)
 	for i := 0; i < pq.params.NumSubVectors; i++ {
@@ -143,7 +206,7 @@ func (pq productQuantizer) encode(vector []float32) []uint8 {
 		closestCentroidId := 0
 		for j := 0; j < pq.params.NumCentroids; j++ {
 			sliceStart, sliceEnd := pq.flatCentroidSlice(i, j)
-			centroid := pq.flatCentroids[sliceStart:sliceEnd]
+			centroid := flatCentroids[sliceStart:sliceEnd]
 			dist := pq.distFn(subVector, centroid)
 			if dist < closestCentroidDistance {
 				closestCentroidDistance = dist
@@ -156,12 +219,14 @@ func (pq productQuantizer) encode(vector []float32) []uint8 {
 }
 
 func (pq *productQuantizer) Set(id uint64, vector gomath.Vector) (*productQuantizedPoint, error) {
+	vector = pq.prepare(vector)
 	point := &productQuantizedPoint{
 		id:          id,
 		Vector:      vector,
 		CentroidIds: pq.encode(vector),
 	}
 	pq.caches.Put(id, point)
+	pq.markDirty(id)
 	return point, nil
 }
 
@@ -179,6 +244,7 @@ func (pq *productQuantizer) Dirty(id uint64) {
 }
 
 func (pq *productQuantizer) DistanceFromFloat(x []float32) PointIdDistFn {
+	x = pq.prepare(x)
 	if len(pq.flatCentroids) == 0 {
 		return func(y *productQuantizedPoint) float32 {
 
@@ -186,6 +252,7 @@ func (pq *productQuantizer) DistanceFromFloat(x []float32) PointIdDistFn {
 		}
 	}
 
+	x = pq.project(x)
 	dists := make([]float32, pq.params.NumSubVectors*pq.params.NumCentroids)
 	for i := 0; i < pq.params.NumSubVectors; i++ {
 		subvector := x[i*pq.subVectorLen : (i+1)*pq.subVectorLen]
@@ -220,6 +287,8 @@ func (pq *productQuantizer) DistanceFromPoint(x *productQuantizedPoint) PointIdD
 	}
 }
 
+// DistanceFromCentroidIDs expects queryVec to be already prepared (normalized for cosine)
+// and projected into the code space.
 func (pq *productQuantizer) DistanceFromCentroidIDs(queryVec []float32, centroidIDs []uint8) float32 {
 	var dist float32
 	for i := 0; i < pq.NumSubVectors(); i++ {
@@ -251,7 +320,6 @@ func (pq *productQuantizer) Fit() error {
 	err := pq.caches.ForEach(func(id uint64, point *productQuantizedPoint) error {
 		allVectors = append(allVectors, point.Vector)
 		allPoints = append(allPoints, point)
-		point.CentroidIds = make([]uint8, pq.params.NumSubVectors)
 		point.isDirty = true
 		return nil
 	})
@@ -261,8 +329,28 @@ func (pq *productQuantizer) Fit() error {
 	//avoid overfitting
 	// allVectors = allVectors[:int(float32(itemCount)*0.2)]
 	// allPoints = allPoints[:int(float32(itemCount)*0.2)]
-	pq.flatCentroids = make([]float32, pq.params.NumCentroids*pq.params.NumSubVectors*pq.subVectorLen)
-	pq.centroidDists = make([]float32, pq.params.NumCentroids*pq.params.NumCentroids*pq.params.NumSubVectors)
+	cb, codes := pq.trainCodebook(allVectors)
+	for j := 0; j < len(allPoints); j++ {
+		allPoints[j].CentroidIds = codes[j]
+	}
+	pq.rotation = cb.rotation
+	pq.flatCentroids = cb.flatCentroids
+	pq.centroidDists = cb.centroidDists
+	pq.isFit = true
+	pq.isPreTrain = false
+	return nil
+}
+
+// fitSubspaces runs k-means on every subvector slice of the given (already rotated)
+// vectors and returns the flat centroids, the centroid distance table and the codes.
+func (pq *productQuantizer) fitSubspaces(vectors [][]float32, maxIter int) (
+	flatCentroids []float32, centroidDists []float32, codes [][]uint8) {
+	flatCentroids = make([]float32, pq.params.NumCentroids*pq.params.NumSubVectors*pq.subVectorLen)
+	centroidDists = make([]float32, pq.params.NumCentroids*pq.params.NumCentroids*pq.params.NumSubVectors)
+	codes = make([][]uint8, len(vectors))
+	for j := range codes {
+		codes[j] = make([]uint8, pq.params.NumSubVectors)
+	}
 
 	var wg sync.WaitGroup
 	for i := 0; i < pq.params.NumSubVectors; i++ {
@@ -272,31 +360,29 @@ func (pq *productQuantizer) Fit() error {
 
 			kmeans := KMeans{
 				K:         pq.params.NumCentroids,
-				MaxIter:   100,
+				MaxIter:   maxIter,
 				Offset:    i * pq.subVectorLen,
 				VectorLen: pq.subVectorLen,
 			}
-			kmeans.Fit(allVectors)
+			kmeans.Fit(vectors)
 
-			for j := 0; j < len(allPoints); j++ {
-				allPoints[j].CentroidIds[i] = kmeans.Labels[j]
+			for j := 0; j < len(vectors); j++ {
+				codes[j][i] = kmeans.Labels[j]
 			}
 
 			for j := 0; j < pq.params.NumCentroids; j++ {
 				start, end := pq.flatCentroidSlice(i, j)
-				copy(pq.flatCentroids[start:end], kmeans.Centroids[j])
+				copy(flatCentroids[start:end], kmeans.Centroids[j])
 			}
 
 			for j := 0; j < pq.params.NumCentroids; j++ {
 				for k := 0; k < pq.params.NumCentroids; k++ {
 					idx := pq.centroidDistIdx(i, j, k)
-					pq.centroidDists[idx] = pq.distFn(kmeans.Centroids[j], kmeans.Centroids[k])
+					centroidDists[idx] = pq.distFn(kmeans.Centroids[j], kmeans.Centroids[k])
 				}
 			}
 		}(i)
 	}
 	wg.Wait()
-	pq.isFit = true
-	pq.isPreTrain = false
-	return nil
+	return flatCentroids, centroidDists, codes
 }
@@ -0,0 +1,179 @@
+package hnswpq
+
+import (
+	"container/heap"
+	"math"
+	"testing"
+
+	"github.com/sjy-dv/nnv/edge"
+	"github.com/sjy-dv/nnv/pkg/distance"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+	"github.com/sjy-dv/nnv/pkg/models"
+	"github.com/sjy-dv/nnv/pkg/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductQuantizerCosine(t *testing.T) {
+	dim := 32
+	pq, err := newProductQuantizer(edge.COSINE, models.ProductQuantizerParameters{
+		NumCentroids:     32,
+		NumSubVectors:    8,
+		TriggerThreshold: 500,
+	}, dim)
+	assert.Nil(t, err)
+	assert.Equal(t, distance.NewCosine().Type(), pq.DistanceType())
+
+	vectors := make([]gomath.Vector, 1000)
+	for i := range vectors {
+		vectors[i] = gomath.RandomUniformVector(dim)
+		_, err := pq.Set(uint64(i), vectors[i])
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, pq.Fit())
+	assert.True(t, pq.isFit)
+
+	// stored (and trained) vectors are unit length
+	point, err := pq.Get(1)
+	assert.Nil(t, err)
+	var norm float32
+	for _, v := range point.Vector {
+		norm += v * v
+	}
+	assert.InDelta(t, 1, norm, 1e-4)
+
+	cosine := distance.NewCosine()
+	query := gomath.RandomUniformVector(dim)
+	distFn := pq.DistanceFromFloat(query)
+	var errSum float64
+	for i := 0; i < 100; i++ {
+		point, err := pq.Get(uint64(i))
+		assert.Nil(t, err)
+		approx := pq.ToDistance(distFn(point))
+		assert.True(t, approx >= 0 && approx <= 2)
+		errSum += math.Abs(float64(approx - cosine.Distance(query, vectors[i])))
+	}
+	// the ADC approximation must be on the cosine distance scale
+	assert.Less(t, errSum/100, 0.1)
+}
+
+func TestProductQuantizerRetrainWithRotation(t *testing.T) {
+	dim := 16
+	collection := "opq"
+	params := models.ProductQuantizerParameters{
+		NumCentroids:       16,
+		NumSubVectors:      4,
+		TriggerThreshold:   200,
+		OptimizedRotation:  true,
+		RotationIterations: 2,
+	}
+	cfg := models.HnswConfig{
+		Efconstruction: 64,
+		M:              8,
+		Mmax:           16,
+		Mmax0:          16,
+		Ml:             1 / math.Log(8),
+		Dim:            uint32(dim),
+		DistanceType:   edge.EUCLIDEAN,
+	}
+	index := NewProductQuantizationHnsw()
+	assert.Nil(t, index.CreateCollection(collection, cfg, params))
+	pq := index.Collections[collection].PQ
+	assert.Nil(t, pq.PreTrainProductQuantizer(collection, dim, 200))
+
+	// learned rotation is orthogonal: R * R^T = I
+	assert.Len(t, pq.rotation, dim*dim)
+	for i := 0; i < dim; i++ {
+		for j := 0; j < dim; j++ {
+			var dot float32
+			for k := 0; k < dim; k++ {
+				dot += pq.rotation[i*dim+k] * pq.rotation[j*dim+k]
+			}
+			want := float32(0)
+			if i == j {
+				want = 1
+			}
+			assert.InDelta(t, want, dot, 1e-3)
+		}
+	}
+
+	index.Genesis(collection, cfg)
+	vectors := make([]gomath.Vector, 300)
+	for i := range vectors {
+		vectors[i] = gomath.RandomUniformVector(dim)
+		assert.Nil(t, index.Insert(collection, uint64(i+1), vectors[i]))
+	}
+	before := pq.flatCentroids
+
+	assert.Nil(t, <-index.RetrainCodebook(collection, 250))
+	assert.NotEqual(t, before, pq.flatCentroids)
+
+	// every node carries a code of the new codebook
+	for i, vec := range vectors {
+		node := index.Collections[collection].NodeList.Nodes[i+1]
+		assert.Equal(t, pq.encode(vec), node.Centroids)
+	}
+
+	topCandidates := &queue.PriorityQueue{Order: false, Items: []*queue.Item{}}
+	heap.Init(topCandidates)
+	assert.Nil(t, index.Search(collection, vectors[7], topCandidates, 5, 32))
+	assert.Equal(t, 5, topCandidates.Len())
+}
+
+func TestProductQuantizerRetrainKeepsLaterSets(t *testing.T) {
+	dim := 16
+	collection := "opq"
+	params := models.ProductQuantizerParameters{
+		NumCentroids:     16,
+		NumSubVectors:    4,
+		TriggerThreshold: 200,
+	}
+	cfg := models.HnswConfig{
+		Efconstruction: 64,
+		M:              8,
+		Mmax:           16,
+		Mmax0:          16,
+		Ml:             1 / math.Log(8),
+		Dim:            uint32(dim),
+		DistanceType:   edge.EUCLIDEAN,
+	}
+	index := NewProductQuantizationHnsw()
+	assert.Nil(t, index.CreateCollection(collection, cfg, params))
+	pq := index.Collections[collection].PQ
+	assert.Nil(t, pq.PreTrainProductQuantizer(collection, dim, 200))
+	index.Genesis(collection, cfg)
+	for i := 0; i < 300; i++ {
+		assert.Nil(t, index.Insert(collection, uint64(i+1), gomath.RandomUniformVector(dim)))
+	}
+
+	pq.trackDirty()
+	codebook, codes, err := pq.retrain(250)
+	assert.Nil(t, err)
+	// point 8 is written again after the snapshot was encoded
+	moved := gomath.RandomUniformVector(dim)
+	_, err = pq.Set(8, moved)
+	assert.Nil(t, err)
+	index.Collections[collection].NodeList.Nodes[8].Vectors = moved
+	index.Collections[collection].swapCodebook(codebook, codes)
+
+	assert.NotEqual(t, codes[8], pq.encode(moved))
+	point, err := pq.Get(8)
+	assert.Nil(t, err)
+	assert.Equal(t, pq.encode(moved), point.CentroidIds)
+	assert.Equal(t, pq.encode(moved), index.Collections[collection].NodeList.Nodes[8].Centroids)
+	assert.Nil(t, pq.dirty)
+}
+
+func TestPolarFactor(t *testing.T) {
+	// M = U*P with U a rotation by 30 degrees and P = diag(2, 3)
+	c, s := math.Cos(math.Pi/6), math.Sin(math.Pi/6)
+	m := []float64{2 * c, -3 * s, 2 * s, 3 * c}
+	u, ok := polarFactor(m, 2)
+	assert.True(t, ok)
+	for i, want := range []float64{c, -s, s, c} {
+		assert.InDelta(t, want, u[i], 1e-9)
+	}
+
+	// an iteration that never settles gives no factor
+	_, ok = polarFactor([]float64{math.NaN(), 0, 0, 1}, 2)
+	assert.False(t, ok)
+}
@@ -28,6 +28,8 @@ import (
 
 type BitmapIndex struct {
 	Shards             map[string]*IndexShard
+	Geo                map[string]*GeoShard
+	Text               map[string]*TextShard
 	shardLock          sync.RWMutex
 	optimizationTicker *time.Ticker
 	stopOptimization   chan bool
@@ -41,6 +43,8 @@ type IndexShard struct {
 func NewBitmapIndex() *BitmapIndex {
 	return &BitmapIndex{
 		Shards:           make(map[string]*IndexShard),
+		Geo:              make(map[string]*GeoShard),
+		Text:             make(map[string]*TextShard),
 		stopOptimization: make(chan bool),
 	}
 }
@@ -62,6 +66,11 @@ func forcedStringTypeChanger(x interface{}) string {
 	}
 }
 
+// FilterValue is the string a filter names to match the metadata value x.
+func FilterValue(x interface{}) string {
+	return forcedStringTypeChanger(x)
+}
+
 func (idx *BitmapIndex) getShard(key string) *IndexShard {
 	idx.shardLock.RLock()
 	shard, exists := idx.Shards[key]
@@ -85,6 +94,13 @@ func (idx *BitmapIndex) getShard(key string) *IndexShard {
 
 func (idx *BitmapIndex) Add(nodeId uint64, metadata map[string]interface{}) error {
 	for key, val := range metadata {
+		if point, ok := geoValue(val); ok {
+			idx.addGeo(key, nodeId, point)
+			continue
+		}
+		if text, ok := idx.lookupTextShard(key); ok {
+			text.add(nodeId, text.Analyzer.Analyze(forcedStringTypeChanger(val)))
+		}
 		shard := idx.getShard(key)
 		shard.rmu.Lock()
 		if _, exists := shard.ShardIndex[forcedStringTypeChanger(val)]; !exists {
@@ -98,6 +114,13 @@ func (idx *BitmapIndex) Add(nodeId uint64, metadata map[string]interface{}) erro
 
 func (idx *BitmapIndex) Remove(nodeId uint64, metadata map[string]interface{}) error {
 	for key, value := range metadata {
+		if _, ok := geoValue(value); ok {
+			idx.removeGeo(key, nodeId)
+			continue
+		}
+		if text, ok := idx.lookupTextShard(key); ok {
+			text.remove(nodeId)
+		}
 		val := forcedStringTypeChanger(value)
 
 		shard := idx.getShard(key)
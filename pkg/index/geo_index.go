@@ -0,0 +1,340 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"math"
+	"sync"
+
+	roaring "github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/sjy-dv/nnv/pkg/gomath"
+)
+
+// geo points are bucketed on a fixed lat/lon grid, a cell is about 5.5km
+// on the equator. Cells only narrow the candidates, every hit is checked
+// against the exact haversine distance.
+const (
+	geoCellDegree = 0.05
+	geoLatCells   = int64(180 / geoCellDegree)
+	geoLonCells   = int64(360 / geoCellDegree)
+	// above this many cells the query walks the points instead of the grid
+	geoMaxScanCells = 1 << 16
+	metersPerDegree = 111320.0
+)
+
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+type GeoShard struct {
+	Cells  map[int64]*roaring.Bitmap
+	Points map[uint64]GeoPoint
+	gmu    sync.RWMutex
+}
+
+// GeoQuery is a radius (Radius > 0) or a bounding box filter on one geo field.
+type GeoQuery struct {
+	Field string
+	// radius
+	Center GeoPoint
+	Radius float64 // meters
+	// bounding box, MinLon > MaxLon crosses the antimeridian
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+	SortByDistance bool
+}
+
+func (q *GeoQuery) IsRadius() bool {
+	return q.Radius > 0
+}
+
+// Origin is the point distances are sorted from, the center of a bounding box.
+func (q *GeoQuery) Origin() GeoPoint {
+	if q.IsRadius() {
+		return q.Center
+	}
+	lon := (q.MinLon + q.MaxLon) / 2
+	if q.MinLon > q.MaxLon {
+		lon = normalizeLon(lon + 180)
+	}
+	return GeoPoint{Lat: (q.MinLat + q.MaxLat) / 2, Lon: lon}
+}
+
+// geoValue reports whether a metadata value is a geo point, {"lat": .., "lon": ..}
+// ("lng" is accepted as well).
+func geoValue(x interface{}) (GeoPoint, bool) {
+	obj, ok := x.(map[string]interface{})
+	if !ok {
+		return GeoPoint{}, false
+	}
+	lat, ok := geoNumber(obj["lat"])
+	if !ok {
+		return GeoPoint{}, false
+	}
+	lonVal, exists := obj["lon"]
+	if !exists {
+		lonVal = obj["lng"]
+	}
+	lon, ok := geoNumber(lonVal)
+	if !ok {
+		return GeoPoint{}, false
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return GeoPoint{}, false
+	}
+	return GeoPoint{Lat: lat, Lon: lon}, true
+}
+
+func geoNumber(x interface{}) (float64, bool) {
+	switch val := x.(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	}
+	return 0, false
+}
+
+func geoLatCell(lat float64) int64 {
+	return clampCell(int64(math.Floor((lat+90)/geoCellDegree)), geoLatCells)
+}
+
+func geoLonCell(lon float64) int64 {
+	return clampCell(int64(math.Floor((lon+180)/geoCellDegree)), geoLonCells)
+}
+
+func clampCell(cell, cells int64) int64 {
+	if cell < 0 {
+		return 0
+	}
+	if cell >= cells {
+		return cells - 1
+	}
+	return cell
+}
+
+func geoCellKey(latCell, lonCell int64) int64 {
+	return latCell*geoLonCells + lonCell
+}
+
+func haversine(a, b GeoPoint) float64 {
+	return gomath.Haversine(a.Lat, a.Lon, b.Lat, b.Lon)
+}
+
+func normalizeLon(lon float64) float64 {
+	for lon > 180 {
+		lon -= 360
+	}
+	for lon < -180 {
+		lon += 360
+	}
+	return lon
+}
+
+func (idx *BitmapIndex) getGeoShard(key string) *GeoShard {
+	idx.shardLock.RLock()
+	shard, exists := idx.Geo[key]
+	idx.shardLock.RUnlock()
+	if exists {
+		return shard
+	}
+
+	idx.shardLock.Lock()
+	defer idx.shardLock.Unlock()
+	shard, exists = idx.Geo[key]
+	if !exists {
+		shard = &GeoShard{
+			Cells:  make(map[int64]*roaring.Bitmap),
+			Points: make(map[uint64]GeoPoint),
+		}
+		idx.Geo[key] = shard
+	}
+	return shard
+}
+
+func (idx *BitmapIndex) lookupGeoShard(key string) (*GeoShard, bool) {
+	idx.shardLock.RLock()
+	defer idx.shardLock.RUnlock()
+	shard, exists := idx.Geo[key]
+	return shard, exists
+}
+
+func (idx *BitmapIndex) addGeo(key string, nodeId uint64, point GeoPoint) {
+	shard := idx.getGeoShard(key)
+	shard.gmu.Lock()
+	defer shard.gmu.Unlock()
+	if old, exists := shard.Points[nodeId]; exists {
+		shard.removeCell(nodeId, old)
+	}
+	cell := geoCellKey(geoLatCell(point.Lat), geoLonCell(point.Lon))
+	if _, exists := shard.Cells[cell]; !exists {
+		shard.Cells[cell] = roaring.New()
+	}
+	shard.Cells[cell].Add(nodeId)
+	shard.Points[nodeId] = point
+}
+
+func (idx *BitmapIndex) removeGeo(key string, nodeId uint64) {
+	shard, exists := idx.lookupGeoShard(key)
+	if !exists {
+		return
+	}
+	shard.gmu.Lock()
+	if point, exists := shard.Points[nodeId]; exists {
+		shard.removeCell(nodeId, point)
+		delete(shard.Points, nodeId)
+	}
+	empty := len(shard.Points) == 0
+	shard.gmu.Unlock()
+	if empty {
+		idx.shardLock.Lock()
+		delete(idx.Geo, key)
+		idx.shardLock.Unlock()
+	}
+}
+
+func (s *GeoShard) removeCell(nodeId uint64, point GeoPoint) {
+	cell := geoCellKey(geoLatCell(point.Lat), geoLonCell(point.Lon))
+	if bm, exists := s.Cells[cell]; exists {
+		bm.Remove(nodeId)
+		if bm.IsEmpty() {
+			delete(s.Cells, cell)
+		}
+	}
+}
+
+// GeoDistance returns the haversine distance in meters between the node's
+// point on the field and the given point.
+func (idx *BitmapIndex) GeoDistance(field string, nodeId uint64, point GeoPoint) (float64, bool) {
+	shard, exists := idx.lookupGeoShard(field)
+	if !exists {
+		return 0, false
+	}
+	shard.gmu.RLock()
+	p, exists := shard.Points[nodeId]
+	shard.gmu.RUnlock()
+	if !exists {
+		return 0, false
+	}
+	return haversine(p, point), true
+}
+
+// geoBitmap returns every node of the field matching the query.
+func (idx *BitmapIndex) geoBitmap(q *GeoQuery) *roaring.Bitmap {
+	result := roaring.New()
+	shard, exists := idx.lookupGeoShard(q.Field)
+	if !exists {
+		return result
+	}
+	minLat, maxLat, lonRanges := q.bounds()
+
+	shard.gmu.RLock()
+	defer shard.gmu.RUnlock()
+
+	latFrom, latTo := geoLatCell(minLat), geoLatCell(maxLat)
+	collect := func(bm *roaring.Bitmap) {
+		it := bm.Iterator()
+		for it.HasNext() {
+			id := it.Next()
+			if q.match(shard.Points[id]) {
+				result.Add(id)
+			}
+		}
+	}
+	inLonRange := func(lonCell int64) bool {
+		for _, r := range lonRanges {
+			if lonCell >= geoLonCell(r[0]) && lonCell <= geoLonCell(r[1]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	cellCount := int64(0)
+	for _, r := range lonRanges {
+		cellCount += (geoLonCell(r[1]) - geoLonCell(r[0]) + 1) * (latTo - latFrom + 1)
+	}
+	if cellCount > geoMaxScanCells || cellCount > int64(len(shard.Cells)) {
+		// wide area, walking the occupied cells is cheaper than the grid
+		for key, bm := range shard.Cells {
+			latCell, lonCell := key/geoLonCells, key%geoLonCells
+			if latCell >= latFrom && latCell <= latTo && inLonRange(lonCell) {
+				collect(bm)
+			}
+		}
+		return result
+	}
+
+	for latCell := latFrom; latCell <= latTo; latCell++ {
+		for _, r := range lonRanges {
+			for lonCell := geoLonCell(r[0]); lonCell <= geoLonCell(r[1]); lonCell++ {
+				if bm, exists := shard.Cells[geoCellKey(latCell, lonCell)]; exists {
+					collect(bm)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// bounds returns the latitude range and the longitude ranges (two when the
+// area crosses the antimeridian) covering the query.
+func (q *GeoQuery) bounds() (float64, float64, [][2]float64) {
+	if !q.IsRadius() {
+		if q.MinLon > q.MaxLon {
+			return q.MinLat, q.MaxLat, [][2]float64{{q.MinLon, 180}, {-180, q.MaxLon}}
+		}
+		return q.MinLat, q.MaxLat, [][2]float64{{q.MinLon, q.MaxLon}}
+	}
+	dLat := q.Radius / metersPerDegree
+	minLat, maxLat := q.Center.Lat-dLat, q.Center.Lat+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		// covers a pole, every longitude is in reach
+		return math.Max(minLat, -90), math.Min(maxLat, 90), [][2]float64{{-180, 180}}
+	}
+	dLon := dLat / math.Cos(q.Center.Lat*math.Pi/180)
+	if dLon >= 180 {
+		return minLat, maxLat, [][2]float64{{-180, 180}}
+	}
+	minLon, maxLon := q.Center.Lon-dLon, q.Center.Lon+dLon
+	if minLon < -180 {
+		return minLat, maxLat, [][2]float64{{minLon + 360, 180}, {-180, maxLon}}
+	}
+	if maxLon > 180 {
+		return minLat, maxLat, [][2]float64{{minLon, 180}, {-180, maxLon - 360}}
+	}
+	return minLat, maxLat, [][2]float64{{minLon, maxLon}}
+}
+
+func (q *GeoQuery) match(p GeoPoint) bool {
+	if q.IsRadius() {
+		return haversine(p, q.Center) <= q.Radius
+	}
+	if p.Lat < q.MinLat || p.Lat > q.MaxLat {
+		return false
+	}
+	if q.MinLon > q.MaxLon {
+		return p.Lon >= q.MinLon || p.Lon <= q.MaxLon
+	}
+	return p.Lon >= q.MinLon && p.Lon <= q.MaxLon
+}
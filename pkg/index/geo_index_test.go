@@ -0,0 +1,63 @@
+package index
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func geoMeta(lat, lon float64, kind string) map[string]interface{} {
+	return map[string]interface{}{
+		"location": map[string]interface{}{"lat": lat, "lon": lon},
+		"kind":     kind,
+	}
+}
+
+func TestGeoIndexRadiusAndBoundingBox(t *testing.T) {
+	idx := NewBitmapIndex()
+	// Seoul city hall, Gangnam station (~9km), Busan (~325km), Fiji (near the antimeridian)
+	assert.Nil(t, idx.Add(1, geoMeta(37.5663, 126.9779, "cafe")))
+	assert.Nil(t, idx.Add(2, geoMeta(37.4979, 127.0276, "cafe")))
+	assert.Nil(t, idx.Add(3, geoMeta(35.1796, 129.0756, "store")))
+	assert.Nil(t, idx.Add(4, geoMeta(-17.7134, 178.0650, "store")))
+	assert.Nil(t, idx.Add(5, geoMeta(-16.5000, -179.9000, "store")))
+
+	center := GeoPoint{Lat: 37.5665, Lon: 126.9780}
+	assert.Equal(t, []uint64{1}, idx.GeoSearch(&GeoQuery{Field: "location", Center: center, Radius: 5000}))
+	assert.ElementsMatch(t, []uint64{1, 2}, idx.GeoSearch(&GeoQuery{Field: "location", Center: center, Radius: 10000}))
+
+	// sorted by distance from the center
+	sorted := idx.GeoSearch(&GeoQuery{Field: "location", Center: GeoPoint{Lat: 35.1, Lon: 129}, Radius: 400000, SortByDistance: true})
+	assert.Equal(t, []uint64{3, 2, 1}, sorted)
+
+	// tag filter and geo filter together
+	assert.Equal(t, []uint64{3}, idx.PureGeoSearch(map[string]string{"kind": "store"},
+		&GeoQuery{Field: "location", Center: center, Radius: 400000}))
+
+	// bounding box crossing the antimeridian
+	box := &GeoQuery{Field: "location", MinLat: -20, MaxLat: -15, MinLon: 177, MaxLon: -179}
+	assert.ElementsMatch(t, []uint64{4, 5}, idx.GeoSearch(box))
+
+	// geo values are removed with their node
+	assert.Nil(t, idx.Remove(1, geoMeta(37.5663, 126.9779, "cafe")))
+	assert.Empty(t, idx.GeoSearch(&GeoQuery{Field: "location", Center: center, Radius: 5000}))
+	_, ok := idx.GeoDistance("location", 1, center)
+	assert.False(t, ok)
+}
+
+func TestGeoIndexSerialize(t *testing.T) {
+	idx := NewBitmapIndex()
+	assert.Nil(t, idx.Add(1, geoMeta(37.5663, 126.9779, "cafe")))
+	assert.Nil(t, idx.Add(2, geoMeta(35.1796, 129.0756, "store")))
+
+	filename := filepath.Join(t.TempDir(), "geo.bin")
+	assert.Nil(t, idx.SerializeBinary(filename))
+
+	loaded := NewBitmapIndex()
+	assert.Nil(t, loaded.DeserializeBinary(filename))
+	assert.Equal(t, []uint64{2}, loaded.PureSearch(map[string]string{"kind": "store"}))
+	assert.Equal(t, []uint64{1}, loaded.GeoSearch(&GeoQuery{
+		Field: "location", Center: GeoPoint{Lat: 37.5665, Lon: 126.9780}, Radius: 1000,
+	}))
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"os"
 
 	roaring "github.com/RoaringBitmap/roaring/roaring64"
@@ -114,6 +115,43 @@ func (idx *BitmapIndex) SerializeBinary(filename string) error {
 		shard.rmu.RUnlock()
 	}
 
+	// geo fields follow the tag shards, cells are rebuilt from the points on load
+	if err := binary.Write(file, binary.LittleEndian, uint32(len(idx.Geo))); err != nil {
+		idx.shardLock.RUnlock()
+		return fmt.Errorf("failed to write geo field count: %v", err)
+	}
+	for key, shard := range idx.Geo {
+		keyBytes := []byte(key)
+		if err := binary.Write(file, binary.LittleEndian, uint32(len(keyBytes))); err != nil {
+			idx.shardLock.RUnlock()
+			return fmt.Errorf("failed to write geo field length for %s: %v", key, err)
+		}
+		if _, err := file.Write(keyBytes); err != nil {
+			idx.shardLock.RUnlock()
+			return fmt.Errorf("failed to write geo field data for %s: %v", key, err)
+		}
+		shard.gmu.RLock()
+		if err := binary.Write(file, binary.LittleEndian, uint32(len(shard.Points))); err != nil {
+			shard.gmu.RUnlock()
+			idx.shardLock.RUnlock()
+			return fmt.Errorf("failed to write geo point count for %s: %v", key, err)
+		}
+		for id, point := range shard.Points {
+			if err := binary.Write(file, binary.LittleEndian, [3]uint64{
+				id, math.Float64bits(point.Lat), math.Float64bits(point.Lon),
+			}); err != nil {
+				shard.gmu.RUnlock()
+				idx.shardLock.RUnlock()
+				return fmt.Errorf("failed to write geo point for %s: %v", key, err)
+			}
+		}
+		shard.gmu.RUnlock()
+	}
+
+	if err := idx.serializeText(file); err != nil {
+		idx.shardLock.RUnlock()
+		return err
+	}
 	idx.shardLock.RUnlock()
 	return nil
 }
@@ -182,5 +220,40 @@ func (idx *BitmapIndex) DeserializeBinary(filename string) error {
 		}
 	}
 
-	return nil
+	var geoFieldCount uint32
+	if err := binary.Read(file, binary.LittleEndian, &geoFieldCount); err != nil {
+		if err == io.EOF {
+			// written before geo fields existed
+			return nil
+		}
+		return fmt.Errorf("failed to read geo field count: %v", err)
+	}
+	for i := uint32(0); i < geoFieldCount; i++ {
+		var keyLength uint32
+		if err := binary.Read(file, binary.LittleEndian, &keyLength); err != nil {
+			return fmt.Errorf("failed to read geo field length: %v", err)
+		}
+		keyBytes := make([]byte, keyLength)
+		if _, err := io.ReadFull(file, keyBytes); err != nil {
+			return fmt.Errorf("failed to read geo field data: %v", err)
+		}
+		key := string(keyBytes)
+
+		var pointCount uint32
+		if err := binary.Read(file, binary.LittleEndian, &pointCount); err != nil {
+			return fmt.Errorf("failed to read geo point count for %s: %v", key, err)
+		}
+		for j := uint32(0); j < pointCount; j++ {
+			var point [3]uint64
+			if err := binary.Read(file, binary.LittleEndian, &point); err != nil {
+				return fmt.Errorf("failed to read geo point for %s: %v", key, err)
+			}
+			idx.addGeo(key, point[0], GeoPoint{
+				Lat: math.Float64frombits(point[1]),
+				Lon: math.Float64frombits(point[2]),
+			})
+		}
+	}
+
+	return idx.deserializeText(file)
 }
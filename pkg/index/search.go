@@ -17,7 +17,11 @@
 
 package index
 
-import roaring "github.com/RoaringBitmap/roaring/roaring64"
+import (
+	"sort"
+
+	roaring "github.com/RoaringBitmap/roaring/roaring64"
+)
 
 // using hybrid search
 func (idx *BitmapIndex) SearchWitCandidates(
@@ -70,3 +74,59 @@ func (idx *BitmapIndex) PureSearch(filter map[string]string) []uint64 {
 	}
 	return []uint64{}
 }
+
+// using geo search, ids matching the radius or bounding box
+func (idx *BitmapIndex) GeoSearch(q *GeoQuery) []uint64 {
+	return idx.geoSort(idx.geoBitmap(q).ToArray(), q)
+}
+
+// narrows filtered candidates down to the geo query, the candidate order is kept
+// unless the query asks to sort by distance
+func (idx *BitmapIndex) GeoSearchWitCandidates(candidatsIds []uint64, q *GeoQuery) []uint64 {
+	allow := idx.geoBitmap(q)
+	out := make([]uint64, 0, len(candidatsIds))
+	for _, id := range candidatsIds {
+		if allow.Contains(id) {
+			out = append(out, id)
+		}
+	}
+	return idx.geoSort(out, q)
+}
+
+func (idx *BitmapIndex) geoSort(ids []uint64, q *GeoQuery) []uint64 {
+	if !q.SortByDistance {
+		return ids
+	}
+	origin := q.Origin()
+	dists := make(map[uint64]float64, len(ids))
+	for _, id := range ids {
+		dists[id], _ = idx.GeoDistance(q.Field, id, origin)
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return dists[ids[i]] < dists[ids[j]]
+	})
+	return ids
+}
+
+// using pure search with an optional geo query, a geo query alone selects
+// every node it matches
+func (idx *BitmapIndex) PureGeoSearch(filter map[string]string, q *GeoQuery) []uint64 {
+	if q == nil {
+		return idx.PureSearch(filter)
+	}
+	if len(filter) == 0 {
+		return idx.GeoSearch(q)
+	}
+	return idx.GeoSearchWitCandidates(idx.PureSearch(filter), q)
+}
+
+// using hybrid search with an optional geo query
+func (idx *BitmapIndex) SearchWitCandidatesGeo(
+	candidatsIds []uint64, filter map[string]string, q *GeoQuery,
+) []uint64 {
+	merged := idx.SearchWitCandidates(candidatsIds, filter)
+	if q == nil {
+		return merged
+	}
+	return idx.GeoSearchWitCandidates(merged, q)
+}
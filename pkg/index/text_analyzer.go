@@ -0,0 +1,122 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	AnalyzerStandard   = "standard"
+	AnalyzerWhitespace = "whitespace"
+	AnalyzerEnglish    = "english"
+)
+
+// Token is a term and its position in the text, filters that drop tokens
+// leave the positions of the others as they are so phrases keep their gaps.
+type Token struct {
+	Term     string
+	Position uint32
+}
+
+type Tokenizer func(text string) []Token
+
+type TokenFilter func(tokens []Token) []Token
+
+// Analyzer turns a text into the tokens the text index stores, documents
+// and queries go through the same analyzer.
+type Analyzer struct {
+	Name      string
+	tokenizer Tokenizer
+	filters   []TokenFilter
+}
+
+func NewAnalyzer(name string, tokenizer Tokenizer, filters ...TokenFilter) *Analyzer {
+	return &Analyzer{Name: name, tokenizer: tokenizer, filters: filters}
+}
+
+func (a *Analyzer) Analyze(text string) []Token {
+	tokens := a.tokenizer(text)
+	for _, filter := range a.filters {
+		tokens = filter(tokens)
+	}
+	return tokens
+}
+
+var englishStopwords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "but": {},
+	"by": {}, "for": {}, "if": {}, "in": {}, "into": {}, "is": {}, "it": {}, "no": {},
+	"not": {}, "of": {}, "on": {}, "or": {}, "such": {}, "that": {}, "the": {}, "their": {},
+	"then": {}, "there": {}, "these": {}, "they": {}, "this": {}, "to": {}, "was": {},
+	"will": {}, "with": {},
+}
+
+var analyzers = map[string]*Analyzer{
+	AnalyzerStandard:   NewAnalyzer(AnalyzerStandard, StandardTokenizer, LowercaseFilter),
+	AnalyzerWhitespace: NewAnalyzer(AnalyzerWhitespace, WhitespaceTokenizer),
+	AnalyzerEnglish: NewAnalyzer(AnalyzerEnglish, StandardTokenizer, LowercaseFilter,
+		StopwordFilter(englishStopwords)),
+}
+
+// LookupAnalyzer gives the analyzer of the name, unknown names get the standard one.
+func LookupAnalyzer(name string) *Analyzer {
+	if a, ok := analyzers[name]; ok {
+		return a
+	}
+	return analyzers[AnalyzerStandard]
+}
+
+func splitTokenizer(text string, split func(rune) bool) []Token {
+	fields := strings.FieldsFunc(text, split)
+	tokens := make([]Token, len(fields))
+	for i, field := range fields {
+		tokens[i] = Token{Term: field, Position: uint32(i)}
+	}
+	return tokens
+}
+
+// StandardTokenizer splits on every rune that is not a letter or a digit.
+func StandardTokenizer(text string) []Token {
+	return splitTokenizer(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func WhitespaceTokenizer(text string) []Token {
+	return splitTokenizer(text, unicode.IsSpace)
+}
+
+func LowercaseFilter(tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Term = strings.ToLower(tokens[i].Term)
+	}
+	return tokens
+}
+
+func StopwordFilter(stopwords map[string]struct{}) TokenFilter {
+	return func(tokens []Token) []Token {
+		out := tokens[:0]
+		for _, token := range tokens {
+			if _, ok := stopwords[token.Term]; !ok {
+				out = append(out, token)
+			}
+		}
+		return out
+	}
+}
@@ -0,0 +1,432 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	roaring "github.com/RoaringBitmap/roaring/roaring64"
+)
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// a prefix matches at most this many terms, the first ones in order
+	maxPrefixTerms = 64
+)
+
+var ErrNotTextField = errors.New("text search: not a text field")
+
+// TextShard is the positional inverted index of one text field, the tokens
+// of every node are kept to remove it and to write the shard out.
+type TextShard struct {
+	Analyzer *Analyzer
+	// term -> node -> positions
+	Terms    map[string]map[uint64][]uint32
+	Docs     map[uint64][]Token
+	TotalLen uint64
+	tmu      sync.RWMutex
+}
+
+// TextQuery is a bm25 search on one text field, see parseTextQuery for the syntax.
+type TextQuery struct {
+	Field    string
+	Query    string
+	MatchAll bool
+}
+
+type TextHit struct {
+	Id    uint64
+	Score float32
+}
+
+// SetTextField makes the field a text field, a field that already is keeps its shard.
+func (idx *BitmapIndex) SetTextField(key string, analyzer *Analyzer) {
+	idx.shardLock.Lock()
+	defer idx.shardLock.Unlock()
+	if _, exists := idx.Text[key]; exists {
+		return
+	}
+	idx.Text[key] = &TextShard{
+		Analyzer: analyzer,
+		Terms:    make(map[string]map[uint64][]uint32),
+		Docs:     make(map[uint64][]Token),
+	}
+}
+
+// TextFields gives the analyzer name of every text field.
+func (idx *BitmapIndex) TextFields() map[string]string {
+	idx.shardLock.RLock()
+	defer idx.shardLock.RUnlock()
+	fields := make(map[string]string, len(idx.Text))
+	for key, shard := range idx.Text {
+		fields[key] = shard.Analyzer.Name
+	}
+	return fields
+}
+
+func (idx *BitmapIndex) lookupTextShard(key string) (*TextShard, bool) {
+	idx.shardLock.RLock()
+	defer idx.shardLock.RUnlock()
+	shard, exists := idx.Text[key]
+	return shard, exists
+}
+
+func (s *TextShard) add(nodeId uint64, tokens []Token) {
+	s.tmu.Lock()
+	defer s.tmu.Unlock()
+	s.removeDoc(nodeId)
+	for _, token := range tokens {
+		postings, exists := s.Terms[token.Term]
+		if !exists {
+			postings = make(map[uint64][]uint32)
+			s.Terms[token.Term] = postings
+		}
+		postings[nodeId] = append(postings[nodeId], token.Position)
+	}
+	s.Docs[nodeId] = tokens
+	s.TotalLen += uint64(len(tokens))
+}
+
+func (s *TextShard) remove(nodeId uint64) {
+	s.tmu.Lock()
+	defer s.tmu.Unlock()
+	s.removeDoc(nodeId)
+}
+
+func (s *TextShard) removeDoc(nodeId uint64) {
+	tokens, exists := s.Docs[nodeId]
+	if !exists {
+		return
+	}
+	for _, token := range tokens {
+		if postings, exists := s.Terms[token.Term]; exists {
+			delete(postings, nodeId)
+			if len(postings) == 0 {
+				delete(s.Terms, token.Term)
+			}
+		}
+	}
+	delete(s.Docs, nodeId)
+	s.TotalLen -= uint64(len(tokens))
+}
+
+// textClause is a term, a prefix, or a phrase when it has more than one token.
+type textClause struct {
+	tokens []Token
+	prefix bool
+}
+
+/*
+parseTextQuery splits a query into clauses. Whitespace separates the terms,
+"double quotes" make a phrase and a trailing * a prefix. A term the analyzer
+splits in several tokens, like e-mail, is a phrase.
+*/
+func parseTextQuery(query string, analyzer *Analyzer) []textClause {
+	clauses := []textClause{}
+	for {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		if query == "" {
+			return clauses
+		}
+		if query[0] == '"' {
+			var phrase string
+			if end := strings.IndexByte(query[1:], '"'); end < 0 {
+				phrase, query = query[1:], ""
+			} else {
+				phrase, query = query[1:end+1], query[end+2:]
+			}
+			if tokens := analyzer.Analyze(phrase); len(tokens) > 0 {
+				clauses = append(clauses, textClause{tokens: tokens})
+			}
+			continue
+		}
+		end := strings.IndexFunc(query, unicode.IsSpace)
+		if end < 0 {
+			end = len(query)
+		}
+		word := query[:end]
+		query = query[end:]
+		prefix := strings.HasSuffix(word, "*")
+		tokens := analyzer.Analyze(strings.TrimSuffix(word, "*"))
+		if len(tokens) == 0 {
+			continue
+		}
+		if !prefix {
+			clauses = append(clauses, textClause{tokens: tokens})
+			continue
+		}
+		for _, token := range tokens[:len(tokens)-1] {
+			clauses = append(clauses, textClause{tokens: []Token{token}})
+		}
+		clauses = append(clauses, textClause{tokens: tokens[len(tokens)-1:], prefix: true})
+	}
+}
+
+func (s *TextShard) idf(df int) float64 {
+	n := float64(len(s.Docs))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+func (s *TextShard) bm25(nodeId uint64, tf, idf float64) float64 {
+	avg := float64(s.TotalLen) / float64(len(s.Docs))
+	length := float64(len(s.Docs[nodeId]))
+	return idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avg))
+}
+
+// scoreClause gives the bm25 score of every node matching the clause. A prefix
+// scores as its best matching term, a phrase counts its occurrences with the
+// idf of its terms summed.
+func (s *TextShard) scoreClause(clause textClause) map[uint64]float64 {
+	scores := make(map[uint64]float64)
+	if clause.prefix {
+		for _, term := range s.prefixTerms(clause.tokens[0].Term) {
+			postings := s.Terms[term]
+			idf := s.idf(len(postings))
+			for id, positions := range postings {
+				scores[id] = math.Max(scores[id], s.bm25(id, float64(len(positions)), idf))
+			}
+		}
+		return scores
+	}
+	if len(clause.tokens) == 1 {
+		postings := s.Terms[clause.tokens[0].Term]
+		idf := s.idf(len(postings))
+		for id, positions := range postings {
+			scores[id] = s.bm25(id, float64(len(positions)), idf)
+		}
+		return scores
+	}
+
+	var idf float64
+	for _, token := range clause.tokens {
+		idf += s.idf(len(s.Terms[token.Term]))
+	}
+	first := clause.tokens[0]
+	for id, starts := range s.Terms[first.Term] {
+		var tf float64
+		for _, start := range starts {
+			if s.phraseAt(id, clause.tokens, start-first.Position) {
+				tf++
+			}
+		}
+		if tf > 0 {
+			scores[id] = s.bm25(id, tf, idf)
+		}
+	}
+	return scores
+}
+
+// phraseAt reports whether every token of the phrase sits at its offset from base.
+func (s *TextShard) phraseAt(nodeId uint64, tokens []Token, base uint32) bool {
+	for _, token := range tokens[1:] {
+		positions := s.Terms[token.Term][nodeId]
+		want := base + token.Position
+		i := sort.Search(len(positions), func(i int) bool { return positions[i] >= want })
+		if i == len(positions) || positions[i] != want {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TextShard) prefixTerms(prefix string) []string {
+	terms := []string{}
+	for term := range s.Terms {
+		if strings.HasPrefix(term, prefix) {
+			terms = append(terms, term)
+		}
+	}
+	sort.Strings(terms)
+	if len(terms) > maxPrefixTerms {
+		terms = terms[:maxPrefixTerms]
+	}
+	return terms
+}
+
+// TextSearch ranks the nodes matching the query by bm25, best first, within the
+// filter and geo query when they are set. k <= 0 keeps every match.
+func (idx *BitmapIndex) TextSearch(q *TextQuery, filter map[string]string, geo *GeoQuery, k int) ([]TextHit, error) {
+	shard, exists := idx.lookupTextShard(q.Field)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotTextField, q.Field)
+	}
+	var allow *roaring.Bitmap
+	if len(filter) > 0 || geo != nil {
+		allow = roaring.New()
+		allow.AddMany(idx.PureGeoSearch(filter, geo))
+	}
+	clauses := parseTextQuery(q.Query, shard.Analyzer)
+
+	shard.tmu.RLock()
+	defer shard.tmu.RUnlock()
+	if len(clauses) == 0 || len(shard.Docs) == 0 {
+		return []TextHit{}, nil
+	}
+	scores := make(map[uint64]float64)
+	matched := make(map[uint64]int)
+	for _, clause := range clauses {
+		for id, score := range shard.scoreClause(clause) {
+			if allow != nil && !allow.Contains(id) {
+				continue
+			}
+			scores[id] += score
+			matched[id]++
+		}
+	}
+	hits := make([]TextHit, 0, len(scores))
+	for id, score := range scores {
+		if q.MatchAll && matched[id] < len(clauses) {
+			continue
+		}
+		hits = append(hits, TextHit{Id: id, Score: float32(score)})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Id < hits[j].Id
+	})
+	if k > 0 && len(hits) > k {
+		hits = hits[:k]
+	}
+	return hits, nil
+}
+
+func writeString(w io.Writer, s string) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func readString(r io.Reader) (string, error) {
+	var length uint32
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return "", err
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// serializeText writes the text fields after the geo fields, the postings are
+// rebuilt from the tokens of each node. The caller holds shardLock.
+func (idx *BitmapIndex) serializeText(w io.Writer) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(idx.Text))); err != nil {
+		return fmt.Errorf("failed to write text field count: %v", err)
+	}
+	for key, shard := range idx.Text {
+		if err := writeString(w, key); err != nil {
+			return fmt.Errorf("failed to write text field %s: %v", key, err)
+		}
+		if err := writeString(w, shard.Analyzer.Name); err != nil {
+			return fmt.Errorf("failed to write text analyzer for %s: %v", key, err)
+		}
+		shard.tmu.RLock()
+		err := func() error {
+			if err := binary.Write(w, binary.LittleEndian, uint32(len(shard.Docs))); err != nil {
+				return err
+			}
+			for id, tokens := range shard.Docs {
+				if err := binary.Write(w, binary.LittleEndian, id); err != nil {
+					return err
+				}
+				if err := binary.Write(w, binary.LittleEndian, uint32(len(tokens))); err != nil {
+					return err
+				}
+				for _, token := range tokens {
+					if err := binary.Write(w, binary.LittleEndian, token.Position); err != nil {
+						return err
+					}
+					if err := writeString(w, token.Term); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}()
+		shard.tmu.RUnlock()
+		if err != nil {
+			return fmt.Errorf("failed to write text tokens for %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func (idx *BitmapIndex) deserializeText(r io.Reader) error {
+	var fieldCount uint32
+	if err := binary.Read(r, binary.LittleEndian, &fieldCount); err != nil {
+		if err == io.EOF {
+			// written before text fields existed
+			return nil
+		}
+		return fmt.Errorf("failed to read text field count: %v", err)
+	}
+	for i := uint32(0); i < fieldCount; i++ {
+		key, err := readString(r)
+		if err != nil {
+			return fmt.Errorf("failed to read text field: %v", err)
+		}
+		analyzer, err := readString(r)
+		if err != nil {
+			return fmt.Errorf("failed to read text analyzer for %s: %v", key, err)
+		}
+		idx.SetTextField(key, LookupAnalyzer(analyzer))
+		shard, _ := idx.lookupTextShard(key)
+
+		var docCount uint32
+		if err := binary.Read(r, binary.LittleEndian, &docCount); err != nil {
+			return fmt.Errorf("failed to read text node count for %s: %v", key, err)
+		}
+		for j := uint32(0); j < docCount; j++ {
+			var (
+				id         uint64
+				tokenCount uint32
+			)
+			if err := binary.Read(r, binary.LittleEndian, &id); err != nil {
+				return fmt.Errorf("failed to read text node for %s: %v", key, err)
+			}
+			if err := binary.Read(r, binary.LittleEndian, &tokenCount); err != nil {
+				return fmt.Errorf("failed to read text token count for %s: %v", key, err)
+			}
+			tokens := make([]Token, tokenCount)
+			for t := range tokens {
+				if err := binary.Read(r, binary.LittleEndian, &tokens[t].Position); err != nil {
+					return fmt.Errorf("failed to read text token for %s: %v", key, err)
+				}
+				if tokens[t].Term, err = readString(r); err != nil {
+					return fmt.Errorf("failed to read text token for %s: %v", key, err)
+				}
+			}
+			shard.add(id, tokens)
+		}
+	}
+	return nil
+}
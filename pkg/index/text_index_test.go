@@ -0,0 +1,86 @@
+package index
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func textIds(hits []TextHit) []uint64 {
+	ids := make([]uint64, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.Id
+	}
+	return ids
+}
+
+func TestTextIndexBM25PhraseAndPrefix(t *testing.T) {
+	idx := NewBitmapIndex()
+	idx.SetTextField("body", LookupAnalyzer(AnalyzerEnglish))
+	docs := []string{
+		"The quick brown fox jumps over the lazy dog",
+		"A quick brown dog, a quick brown fox",
+		"Brown bears eat fish",
+		"Foxes are quick",
+		"The lazy cat sleeps",
+	}
+	for i, body := range docs {
+		kind := "animal"
+		if i == 1 {
+			kind = "pair"
+		}
+		assert.Nil(t, idx.Add(uint64(i+1), map[string]interface{}{"body": body, "kind": kind}))
+	}
+
+	// bm25: node 4 is two tokens, node 2 has quick twice in six, node 1 once in seven
+	hits, err := idx.TextSearch(&TextQuery{Field: "body", Query: "quick"}, nil, nil, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{4, 2, 1}, textIds(hits))
+	assert.Greater(t, hits[0].Score, hits[2].Score)
+
+	// the stopword in the phrase keeps its gap, lazy dog only sits in node 1
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: `"jumps over the lazy dog"`}, nil, nil, 10)
+	assert.Equal(t, []uint64{1}, textIds(hits))
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: `"brown fox"`}, nil, nil, 10)
+	assert.ElementsMatch(t, []uint64{1, 2}, textIds(hits))
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: `"fox brown"`}, nil, nil, 10)
+	assert.Empty(t, hits)
+
+	// fox* matches fox and foxes
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: "fox*"}, nil, nil, 10)
+	assert.ElementsMatch(t, []uint64{1, 2, 4}, textIds(hits))
+
+	// any clause, or all of them
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: "lazy bears"}, nil, nil, 10)
+	assert.ElementsMatch(t, []uint64{1, 3, 5}, textIds(hits))
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: "lazy dog", MatchAll: true}, nil, nil, 10)
+	assert.Equal(t, []uint64{1}, textIds(hits))
+
+	// filter and k
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: "brown"}, map[string]string{"kind": "animal"}, nil, 10)
+	assert.ElementsMatch(t, []uint64{1, 3}, textIds(hits))
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: "brown"}, nil, nil, 1)
+	assert.Len(t, hits, 1)
+
+	_, err = idx.TextSearch(&TextQuery{Field: "kind", Query: "pair"}, nil, nil, 10)
+	assert.ErrorIs(t, err, ErrNotTextField)
+
+	// exact filters on a text field still work
+	assert.Equal(t, []uint64{5}, idx.PureSearch(map[string]string{"body": "The lazy cat sleeps"}))
+
+	assert.Nil(t, idx.Remove(1, map[string]interface{}{"body": docs[0], "kind": "animal"}))
+	hits, _ = idx.TextSearch(&TextQuery{Field: "body", Query: "lazy"}, nil, nil, 10)
+	assert.Equal(t, []uint64{5}, textIds(hits))
+
+	path := filepath.Join(t.TempDir(), "text.idx")
+	assert.Nil(t, idx.SerializeBinary(path))
+	loaded := NewBitmapIndex()
+	assert.Nil(t, loaded.DeserializeBinary(path))
+	assert.Equal(t, map[string]string{"body": AnalyzerEnglish}, loaded.TextFields())
+	for _, query := range []string{"quick", `"brown fox"`, "fox*", "lazy bears"} {
+		want, _ := idx.TextSearch(&TextQuery{Field: "body", Query: query}, nil, nil, 10)
+		got, _ := loaded.TextSearch(&TextQuery{Field: "body", Query: query}, nil, nil, 10)
+		assert.Equal(t, want, got, query)
+	}
+}
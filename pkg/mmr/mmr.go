@@ -0,0 +1,70 @@
+package mmr
+
+import "math"
+
+// DefaultLambda weighs relevance and novelty alike.
+const DefaultLambda = 0.5
+
+/*
+Select picks k of the candidates greedily by maximal marginal relevance, each
+pick maximizes lambda * relevance - (1 - lambda) * the highest similarity to
+the candidates picked before. Relevance and similarity are expected in [0, 1],
+lambda 1 keeps the relevance order and 0 only looks at novelty. Select gives
+the picked candidates as indexes in pick order, ties go to the lower index.
+*/
+func Select(relevance []float32, similarity func(i, j int) float32, lambda float32, k int) []int {
+	n := len(relevance)
+	if k > n {
+		k = n
+	}
+	picked := make([]int, 0, k)
+	taken := make([]bool, n)
+	// the highest similarity of each candidate to the picked ones
+	maxSim := make([]float32, n)
+	for len(picked) < k {
+		best, bestScore := -1, float32(math.Inf(-1))
+		for i := 0; i < n; i++ {
+			if taken[i] {
+				continue
+			}
+			score := lambda * relevance[i]
+			if len(picked) > 0 {
+				score -= (1 - lambda) * maxSim[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		taken[best] = true
+		picked = append(picked, best)
+		for i := 0; i < n; i++ {
+			if !taken[i] {
+				if sim := similarity(i, best); sim > maxSim[i] {
+					maxSim[i] = sim
+				}
+			}
+		}
+	}
+	return picked
+}
+
+// Normalize scales scores to [0, 1] by their min and max in place, equal
+// scores all become 1.
+func Normalize(scores []float32) []float32 {
+	if len(scores) == 0 {
+		return scores
+	}
+	lo, hi := scores[0], scores[0]
+	for _, score := range scores {
+		lo = float32(math.Min(float64(lo), float64(score)))
+		hi = float32(math.Max(float64(hi), float64(score)))
+	}
+	for i := range scores {
+		if hi == lo {
+			scores[i] = 1
+			continue
+		}
+		scores[i] = (scores[i] - lo) / (hi - lo)
+	}
+	return scores
+}
@@ -0,0 +1,27 @@
+package mmr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectSkipsNearDuplicates(t *testing.T) {
+	// 0 and 1 are near duplicates, 2 is less relevant but different
+	relevance := []float32{1, 0.95, 0.7, 0.1}
+	sims := [][]float32{
+		{1, 0.99, 0.1, 0.2},
+		{0.99, 1, 0.1, 0.2},
+		{0.1, 0.1, 1, 0.3},
+		{0.2, 0.2, 0.3, 1},
+	}
+	similarity := func(i, j int) float32 { return sims[i][j] }
+
+	assert.Equal(t, []int{0, 2, 1}, Select(relevance, similarity, DefaultLambda, 3))
+	// lambda 1 is the relevance order
+	assert.Equal(t, []int{0, 1, 2, 3}, Select(relevance, similarity, 1, 10))
+	assert.Empty(t, Select(nil, similarity, DefaultLambda, 3))
+
+	assert.Equal(t, []float32{1, 0, 0.5}, Normalize([]float32{80, 40, 60}))
+	assert.Equal(t, []float32{1, 1}, Normalize([]float32{3, 3}))
+}
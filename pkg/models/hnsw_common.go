@@ -12,6 +12,11 @@ type ProductQuantizerParameters struct {
 	// Number of points to use to train the quantizer, it will automatically trigger training
 	// when this number of points is reached.
 	TriggerThreshold int `json:"triggerThreshold" binding:"required,min=1000,max=10000"`
+	// Learn an orthogonal rotation (OPQ) before splitting vectors into subvectors,
+	// this spreads correlated dimensions across the subspaces.
+	OptimizedRotation bool `json:"optimizedRotation"`
+	// Number of alternating rotation / codebook refinements, defaults to 5.
+	RotationIterations int `json:"rotationIterations"`
 }
 
 type HnswConfig struct {
@@ -0,0 +1,106 @@
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrInvalidToken = errors.New("invalid page token")
+
+// tokenHeader is the fingerprint, served count and score ahead of the id.
+const tokenHeader = 16
+
+/*
+Token marks where a page of search results ended. Fingerprint ties it to the
+query, Served is how many results the pages so far gave and Score and Id are
+the last of them, the next page starts after it in the Sort order.
+*/
+type Token struct {
+	Fingerprint uint64
+	Served      uint32
+	Score       float32
+	Id          string
+}
+
+// Result is a search result, the candidates of core and edge both fit it.
+type Result interface {
+	GetId() string
+	GetScore() float32
+}
+
+// Fingerprint hashes a query, the paging fields of it are expected cleared so
+// every page of the query hashes alike.
+func Fingerprint(query proto.Message) (uint64, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(query)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return h.Sum64(), nil
+}
+
+func (xx Token) Encode() string {
+	buf := make([]byte, tokenHeader, tokenHeader+len(xx.Id))
+	binary.LittleEndian.PutUint64(buf[0:], xx.Fingerprint)
+	binary.LittleEndian.PutUint32(buf[8:], xx.Served)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(xx.Score))
+	buf = append(buf, xx.Id...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func Decode(token string) (Token, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) < tokenHeader {
+		return Token{}, ErrInvalidToken
+	}
+	return Token{
+		Fingerprint: binary.LittleEndian.Uint64(buf[0:]),
+		Served:      binary.LittleEndian.Uint32(buf[8:]),
+		Score:       math.Float32frombits(binary.LittleEndian.Uint32(buf[12:])),
+		Id:          string(buf[tokenHeader:]),
+	}, nil
+}
+
+// Before tells the token ranks ahead of a result, so the result belongs to a
+// later page.
+func (xx Token) Before(result Result) bool {
+	if xx.Score != result.GetScore() {
+		return xx.Score > result.GetScore()
+	}
+	return xx.Id < result.GetId()
+}
+
+// Sort orders results by score, highest first, and ties by id so every page
+// of a query sees the same order.
+func Sort[T Result](results []T) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].GetScore() != results[j].GetScore() {
+			return results[i].GetScore() > results[j].GetScore()
+		}
+		return results[i].GetId() < results[j].GetId()
+	})
+}
+
+/*
+Page gives up to size of the sorted results after the token, or after the
+first offset results when there is no token. more tells results are left
+behind the page.
+*/
+func Page[T Result](results []T, token *Token, offset, size int) (page []T, more bool) {
+	start := min(offset, len(results))
+	if token != nil {
+		start = 0
+		for start < len(results) && !token.Before(results[start]) {
+			start++
+		}
+	}
+	end := min(start+size, len(results))
+	return results[start:end], end < len(results)
+}
@@ -0,0 +1,42 @@
+package paging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type result struct {
+	id    string
+	score float32
+}
+
+func (xx result) GetId() string     { return xx.id }
+func (xx result) GetScore() float32 { return xx.score }
+
+func TestPagesFollowTokens(t *testing.T) {
+	results := []result{{"c", 90}, {"a", 80}, {"d", 95}, {"b", 90}, {"e", 70}}
+	Sort(results)
+	assert.Equal(t, []result{{"d", 95}, {"b", 90}, {"c", 90}, {"a", 80}, {"e", 70}}, results)
+
+	page, more := Page(results, nil, 0, 2)
+	assert.Equal(t, []result{{"d", 95}, {"b", 90}}, page)
+	assert.True(t, more)
+
+	// the tie at 90 is split by id
+	token, err := Decode(Token{Fingerprint: 7, Served: 2, Score: 90, Id: "b"}.Encode())
+	assert.Nil(t, err)
+	assert.Equal(t, Token{Fingerprint: 7, Served: 2, Score: 90, Id: "b"}, token)
+	page, more = Page(results, &token, 0, 2)
+	assert.Equal(t, []result{{"c", 90}, {"a", 80}}, page)
+	assert.True(t, more)
+
+	page, more = Page(results, nil, 4, 2)
+	assert.Equal(t, []result{{"e", 70}}, page)
+	assert.False(t, more)
+	page, _ = Page(results, nil, 10, 2)
+	assert.Empty(t, page)
+
+	_, err = Decode("not a token")
+	assert.Equal(t, ErrInvalidToken, err)
+}
@@ -70,3 +70,8 @@ func (xx *edgeProtoConn) HybridSearch(ctx context.Context, req *edgeproto.Search
 	*edgeproto.SearchResponse, error) {
 	return edgelites.Edge.HybridSearch(ctx, req)
 }
+
+func (xx *edgeProtoConn) Recommend(ctx context.Context, req *edgeproto.RecommendReq) (
+	*edgeproto.SearchResponse, error) {
+	return edgelites.Edge.Recommend(ctx, req)
+}
@@ -36,6 +36,11 @@ func (xx *coreProtoConn) ReleaseCollection(ctx context.Context, req *coreproto.C
 	return rc.Core.ReleaseCollection(ctx, req)
 }
 
+func (xx *coreProtoConn) AlterCollection(ctx context.Context, req *coreproto.AlterCollectionRequest) (
+	*coreproto.AlterCollectionResponse, error) {
+	return rc.Core.AlterCollection(ctx, req)
+}
+
 func (xx *coreProtoConn) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 	*coreproto.Response, error) {
 	return rc.Core.Insert(ctx, req)
@@ -66,6 +71,11 @@ func (xx *coreProtoConn) HybridSearch(ctx context.Context, req *coreproto.Search
 	return rc.Core.HybridSearch(ctx, req)
 }
 
+func (xx *coreProtoConn) Recommend(ctx context.Context, req *coreproto.RecommendRequest) (
+	*coreproto.SearchResponse, error) {
+	return rc.Core.Recommend(ctx, req)
+}
+
 func (xx *coreProtoConn) CompareDist(ctx context.Context, req *coreproto.CompXyDist) (
 	*coreproto.XyDist, error) {
 	return rc.Core.CompareDist(ctx, req)